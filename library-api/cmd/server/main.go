@@ -1,60 +1,203 @@
 package main
 
 import (
+	"context"
+	"errors"
+	"fmt"
+	"library-api/internal/circulation"
+	"library-api/internal/covers"
 	"library-api/internal/database"
+	"library-api/internal/events"
 	"library-api/internal/handlers"
+	"library-api/internal/jobs"
+	"library-api/internal/limits"
+	"library-api/internal/logging"
+	"library-api/internal/metadata"
+	"library-api/internal/metrics"
+	"library-api/internal/notifications"
+	"library-api/internal/rpc"
+	"library-api/internal/service"
+	"library-api/internal/storage"
+	"library-api/internal/stream"
+	"library-api/internal/tracing"
+	"library-api/internal/webhooks"
+	"log/slog"
+	"net"
+	"net/http"
+	"os"
+	"os/signal"
+	"strings"
+	"syscall"
+	"time"
 
 	"github.com/gin-gonic/gin"
 	swaggerFiles "github.com/swaggo/files"
 	ginSwagger "github.com/swaggo/gin-swagger"
+	"go.opentelemetry.io/contrib/instrumentation/github.com/gin-gonic/gin/otelgin"
+	"google.golang.org/grpc"
+	"gorm.io/gorm"
 
 	"github.com/gin-contrib/cors"
 
 	_ "library-api/docs" // docs gerados pelo swag
 )
 
+// Limites do servidor HTTP. A escrita é generosa por causa dos relatórios
+// em CSV; a exportação e o stream de eventos tiram o limite.
+const (
+	readHeaderTimeout = 5 * time.Second
+	readTimeout       = 30 * time.Second
+	writeTimeout      = 60 * time.Second
+	idleTimeout       = 120 * time.Second
+
+	// shutdownTimeout é quanto as requisições em andamento têm para
+	// terminar depois do SIGTERM
+	shutdownTimeout = 30 * time.Second
+
+	// Tamanho máximo do corpo das requisições; a importação e o envio de
+	// capas têm limites próprios
+	maxBodyBytes   = 1 << 20  // 1 MB
+	maxImportBytes = 32 << 20 // 32 MB
+	maxCoverBytes  = covers.MaxBytes + 64<<10
+)
+
+// Cotas padrão por cliente, ajustáveis por RATE_LIMIT_<GRUPO>
+var (
+	defaultRateLimit = limits.Limit{Requests: 120, Per: time.Minute}
+	reportRateLimit  = limits.Limit{Requests: 30, Per: time.Minute}
+	bulkRateLimit    = limits.Limit{Requests: 10, Per: time.Minute}
+)
+
 // @title Library API
 // @version 1.0
 // @description API para gerenciar livros, autores e empréstimos
 // @host localhost:8080
 // @BasePath /
 func main() {
+	// Logs estruturados (LOG_LEVEL, LOG_FORMAT)
+	logging.Setup()
+
+	// Tracing com OpenTelemetry (OTEL_TRACES_EXPORTER)
+	shutdownTracing, err := tracing.Setup(context.Background())
+	if err != nil {
+		logging.Fatal("Failed to set up tracing", err)
+	}
+
 	// Conecta no banco
 	database.Connect()
 
-	// Cria router do Gin
-	r := gin.Default()
+	// Provedor de metadados por ISBN (METADATA_PROVIDER)
+	handlers.SetMetadataProvider(metadata.FromEnv())
+
+	// Storage das capas (STORAGE_DIR)
+	files, err := storage.FromEnv()
+	if err != nil {
+		logging.Fatal("Failed to set up storage", err)
+	}
+	handlers.SetStorage(files)
+
+	// Lembretes de devolução e avisos de atraso (NOTIFIER, REMINDER_*)
+	notifier, err := notifications.FromEnv()
+	if err != nil {
+		logging.Fatal("Failed to set up notifications", err)
+	}
+	reminders, err := notifications.ConfigFromEnv()
+	if err != nil {
+		logging.Fatal("Failed to set up notifications", err)
+	}
+
+	// Métricas do Prometheus, inclusive das consultas ao banco
+	if err := metrics.Register(database.DB); err != nil {
+		logging.Fatal("Failed to register metrics", err)
+	}
+
+	// Spans das consultas ao banco
+	if err := tracing.Register(database.DB); err != nil {
+		logging.Fatal("Failed to register tracing", err)
+	}
+
+	// Cria router do Gin, com tracing, request ID, log de acesso e métricas.
+	// As métricas e o log ficam antes do Recovery para contar o panic como 500.
+	r := gin.New()
+	r.Use(otelgin.Middleware(tracing.ServiceName), logging.RequestID(), logging.AccessLog(), metrics.Middleware(), logging.Recovery())
+	r.Use(limits.MaxBodySize(maxBodyBytes))
+
+	// O IP do cliente só vem do X-Forwarded-For se a conexão chegar de um
+	// proxy confiável (TRUSTED_PROXIES, separados por vírgula)
+	if err := r.SetTrustedProxies(trustedProxies()); err != nil {
+		logging.Fatal("Invalid TRUSTED_PROXIES", err)
+	}
+
+	// Cota por API key conhecida (API_KEYS) ou por IP, separada por grupo de
+	// rotas
+	limits.SetAPIKeys(limits.APIKeysFromEnv())
+	catalogLimit := limits.FromEnv("default", defaultRateLimit)
+	rateLimit := func(group string, fallback limits.Limit) gin.HandlerFunc {
+		return limits.RateLimit(limits.FromEnv(group, fallback))
+	}
 
 	r.Use(cors.New(cors.Config{
 		AllowOrigins:     []string{"http://localhost:3000"}, // ajuste conforme front
 		AllowMethods:     []string{"GET", "POST", "PUT", "DELETE"},
-		AllowHeaders:     []string{"Origin", "Content-Type"},
-		ExposeHeaders:    []string{"Content-Length"},
+		AllowHeaders:     []string{"Origin", "Content-Type", logging.RequestIDHeader, "traceparent", "tracestate", limits.APIKeyHeader},
+		ExposeHeaders:    []string{"Content-Length", logging.RequestIDHeader, "Retry-After", limits.HeaderLimit, limits.HeaderRemaining, limits.HeaderReset, limits.HeaderPolicy},
 		AllowCredentials: true,
 	}))
 
 	// Rotas para Livros
-	books := r.Group("/books")
+	books := r.Group("/books", rateLimit("books", catalogLimit))
 	{
-		books.GET("", handlers.GetBooks)          // GET /books
-		books.POST("", handlers.CreateBook)       // POST /books
-		books.GET("/:id", handlers.GetBook)       // GET /books/:id
-		books.PUT("/:id", handlers.UpdateBook)    // PUT /books/:id
-		books.DELETE("/:id", handlers.DeleteBook) // DELETE /books/:id
+		books.GET("", handlers.GetBooks)                 // GET /books
+		books.POST("", handlers.CreateBook)              // POST /books
+		books.POST("/lookup", handlers.LookupBook)       // POST /books/lookup
+		books.GET("/:id", handlers.GetBook)              // GET /books/:id
+		books.PUT("/:id", handlers.UpdateBook)           // PUT /books/:id
+		books.DELETE("/:id", handlers.DeleteBook)        // DELETE /books/:id
+		books.GET("/:id/next", handlers.GetNextInSeries) // GET /books/:id/next
+		books.GET("/:id/cover", handlers.GetCover)       // GET /books/:id/cover
+		books.GET("/:id/loans", handlers.GetBookLoans)   // GET /books/:id/loans
+		books.GET("/:id/stats", handlers.GetBookStats)   // GET /books/:id/stats
+
+		// A capa tem limite de tamanho próprio
+		books.PUT("/:id/cover", limits.MaxBodySize(maxCoverBytes), handlers.UploadCover) // PUT /books/:id/cover
 	}
 
 	// Rotas para Autores
-	authors := r.Group("/authors")
+	authors := r.Group("/authors", rateLimit("authors", catalogLimit))
+	{
+		authors.GET("", handlers.GetAuthors)                     // GET /authors
+		authors.GET("/duplicates", handlers.GetAuthorDuplicates) // GET /authors/duplicates
+		authors.GET("/lookup", handlers.LookupAuthor)            // GET /authors/lookup
+		authors.POST("", handlers.CreateAuthor)                  // POST /authors
+		authors.GET("/:id", handlers.GetAuthor)                  // GET /authors/:id
+		authors.PUT("/:id", handlers.UpdateAuthor)               // PUT /authors/:id
+		authors.DELETE("/:id", handlers.DeleteAuthor)            // DELETE /authors/:id
+		authors.POST("/:id/merge", handlers.MergeAuthors)        // POST /authors/:id/merge
+	}
+
+	// Rotas para Assuntos
+	subjects := r.Group("/subjects", rateLimit("subjects", catalogLimit))
 	{
-		authors.GET("", handlers.GetAuthors)          // GET /authors
-		authors.POST("", handlers.CreateAuthor)       // POST /authors
-		authors.GET("/:id", handlers.GetAuthor)       // GET /authors/:id
-		authors.PUT("/:id", handlers.UpdateAuthor)    // PUT /authors/:id
-		authors.DELETE("/:id", handlers.DeleteAuthor) // DELETE /authors/:id
+		subjects.GET("", handlers.GetSubjects)          // GET /subjects
+		subjects.POST("", handlers.CreateSubject)       // POST /subjects
+		subjects.GET("/:id", handlers.GetSubject)       // GET /subjects/:id
+		subjects.PUT("/:id", handlers.UpdateSubject)    // PUT /subjects/:id
+		subjects.DELETE("/:id", handlers.DeleteSubject) // DELETE /subjects/:id
+	}
+
+	// Rotas para Séries
+	series := r.Group("/series", rateLimit("series", catalogLimit))
+	{
+		series.GET("", handlers.GetSeriesList)            // GET /series
+		series.POST("", handlers.CreateSeries)            // POST /series
+		series.GET("/:id", handlers.GetSeries)            // GET /series/:id
+		series.PUT("/:id", handlers.UpdateSeries)         // PUT /series/:id
+		series.DELETE("/:id", handlers.DeleteSeries)      // DELETE /series/:id
+		series.PUT("/:id/books", handlers.SetSeriesBooks) // PUT /series/:id/books
 	}
 
 	// Rotas para Empréstimos
-	loans := r.Group("/loans")
+	loans := r.Group("/loans", rateLimit("loans", catalogLimit))
 	{
 		loans.GET("", handlers.GetLoans)              // GET /loans
 		loans.POST("", handlers.CreateLoan)           // POST /loans
@@ -63,8 +206,241 @@ func main() {
 		loans.DELETE("/:id", handlers.DeleteLoan)     // DELETE /loans/:id
 	}
 
+	// Rotas de leitores (identificados pelo user_name dos empréstimos)
+	patrons := r.Group("/patrons", rateLimit("patrons", catalogLimit))
+	{
+		patrons.GET("/:id", handlers.GetPatron)            // GET /patrons/:id
+		patrons.PUT("/:id", handlers.UpdatePatron)         // PUT /patrons/:id
+		patrons.GET("/:id/loans", handlers.GetPatronLoans) // GET /patrons/:id/loans
+	}
+
+	// Rotas de relatórios de circulação
+	reports := r.Group("/reports", rateLimit("reports", reportRateLimit))
+	{
+		reports.GET("/summary", handlers.GetReportSummary)              // GET /reports/summary
+		reports.GET("/loans", handlers.GetLoansReport)                  // GET /reports/loans
+		reports.GET("/top-books", handlers.GetTopBooksReport)           // GET /reports/top-books
+		reports.GET("/top-authors", handlers.GetTopAuthorsReport)       // GET /reports/top-authors
+		reports.GET("/never-borrowed", handlers.GetNeverBorrowedReport) // GET /reports/never-borrowed
+		reports.GET("/active-patrons", handlers.GetActivePatronsReport) // GET /reports/active-patrons
+	}
+
+	// Rotas de webhooks
+	hooks := r.Group("/webhooks", rateLimit("webhooks", catalogLimit))
+	{
+		hooks.GET("", handlers.GetWebhooks)                                             // GET /webhooks
+		hooks.POST("", handlers.CreateWebhook)                                          // POST /webhooks
+		hooks.GET("/:id", handlers.GetWebhook)                                          // GET /webhooks/:id
+		hooks.PUT("/:id", handlers.UpdateWebhook)                                       // PUT /webhooks/:id
+		hooks.DELETE("/:id", handlers.DeleteWebhook)                                    // DELETE /webhooks/:id
+		hooks.GET("/:id/deliveries", handlers.GetWebhookDeliveries)                     // GET /webhooks/:id/deliveries
+		hooks.POST("/:id/deliveries/:delivery_id/retry", handlers.RetryWebhookDelivery) // POST /webhooks/:id/deliveries/:delivery_id/retry
+	}
+
+	// Rotas de importação em lote
+	imports := r.Group("/import", rateLimit("import", bulkRateLimit), limits.MaxBodySize(maxImportBytes))
+	{
+		imports.POST("/books", handlers.ImportBooks) // POST /import/books
+	}
+
+	// Rotas de exportação do catálogo
+	exports := r.Group("/export", rateLimit("export", bulkRateLimit))
+	{
+		exports.GET("/books", handlers.ExportBooks) // GET /export/books
+	}
+
+	// Rotas de administração
+	admin := r.Group("/admin", rateLimit("admin", catalogLimit))
+	{
+		admin.GET("/jobs", handlers.GetJobs)           // GET /admin/jobs
+		admin.POST("/jobs/:name/run", handlers.RunJob) // POST /admin/jobs/:name/run
+	}
+
+	// GraphQL
+	r.POST("/graphql", rateLimit("graphql", catalogLimit), handlers.GraphQL) // POST /graphql
+
+	// Gateway HTTP/JSON da API gRPC, com as rotas de livros, autores e
+	// empréstimos em /v1 (GRPC_ADDR; "off" desliga os dois)
+	grpcAddr := rpc.AddrFromEnv()
+	gatewayCtx, stopGateway := context.WithCancel(context.Background())
+	defer stopGateway()
+	if grpcAddr != "" {
+		gateway, err := rpc.Gateway(gatewayCtx, grpcAddr)
+		if err != nil {
+			logging.Fatal("Failed to set up gRPC gateway", err)
+		}
+		r.Match([]string{http.MethodGet, http.MethodPost, http.MethodPut, http.MethodDelete},
+			"/v1/*path", rateLimit("v1", catalogLimit), gin.WrapH(gateway)) // /v1/books, /v1/authors, /v1/loans
+	}
+
+	// Eventos em tempo real (SSE)
+	r.GET("/events", rateLimit("events", catalogLimit), handlers.StreamEvents) // GET /events
+
+	// Probes de liveness e readiness
+	r.GET("/healthz", handlers.Healthz)
+	r.GET("/readyz", handlers.Readyz)
+
+	r.GET("/metrics", metrics.Handler())
 	r.GET("/swagger/*any", ginSwagger.WrapHandler(swaggerFiles.Handler))
 
+	// Eventos de domínio: a outbox é lida em segundo plano e os eventos vão
+	// para os assinantes, como os webhooks
+	events.Subscribe("webhooks", webhooks.Subscriber(database.DB))
+	broker := stream.FromEnv()
+	events.Subscribe("stream", broker.Handle, stream.SourceTypes...)
+	handlers.SetEventStream(broker)
+	stopEvents := events.Start(database.DB)
+	stopWebhooks := webhooks.Start(database.DB)
+
+	// Jobs periódicos, com trava no banco para rodar em uma réplica só
+	if err := registerJobs(notifier, reminders, files); err != nil {
+		logging.Fatal("Failed to register jobs", err)
+	}
+	stopJobs := jobs.Start(database.DB)
+
 	// Sobe servidor na porta 8080
-	r.Run(":8080")
+	srv := &http.Server{
+		Addr:              ":8080",
+		Handler:           r,
+		ReadHeaderTimeout: readHeaderTimeout,
+		ReadTimeout:       readTimeout,
+		WriteTimeout:      writeTimeout,
+		IdleTimeout:       idleTimeout,
+	}
+
+	// Os streams de /events ficariam abertos até o fim do prazo de
+	// desligamento; são encerrados assim que ele começa
+	srv.RegisterOnShutdown(broker.Close)
+
+	ctx, stop := signal.NotifyContext(context.Background(), syscall.SIGINT, syscall.SIGTERM)
+	defer stop()
+
+	serveErr := make(chan error, 1)
+	go func() {
+		slog.Info("Server listening", "addr", srv.Addr)
+		serveErr <- srv.ListenAndServe()
+	}()
+
+	// Servidor gRPC, em porta própria
+	var grpcSrv *grpc.Server
+	if grpcAddr != "" {
+		lis, err := net.Listen("tcp", grpcAddr)
+		if err != nil {
+			logging.Fatal("Failed to listen for gRPC", err)
+		}
+		grpcSrv = rpc.NewServer(database.DB)
+		go func() {
+			slog.Info("gRPC server listening", "addr", lis.Addr().String())
+			if err := grpcSrv.Serve(lis); err != nil {
+				serveErr <- err
+			}
+		}()
+	}
+
+	select {
+	case err := <-serveErr:
+		if !errors.Is(err, http.ErrServerClosed) && !errors.Is(err, grpc.ErrServerStopped) {
+			logging.Fatal("Server failed", err)
+		}
+	case <-ctx.Done():
+	}
+
+	// Para de aceitar conexões e espera as requisições em andamento
+	// (empréstimos sendo gravados, por exemplo) antes de fechar o banco
+	slog.Info("Shutting down", "timeout", shutdownTimeout.String())
+	shutdownCtx, cancel := context.WithTimeout(context.Background(), shutdownTimeout)
+	defer cancel()
+
+	if err := srv.Shutdown(shutdownCtx); err != nil {
+		slog.Error("Graceful shutdown failed", "error", err)
+	}
+	if grpcSrv != nil {
+		stopGRPC(shutdownCtx, grpcSrv)
+	}
+	stopGateway()
+	stopJobs()
+	stopEvents()
+	stopWebhooks()
+	if err := database.Close(); err != nil {
+		slog.Error("Failed to close database", "error", err)
+	}
+	if err := shutdownTracing(shutdownCtx); err != nil {
+		slog.Error("Failed to flush traces", "error", err)
+	}
+	slog.Info("Server stopped")
+}
+
+// trustedProxies lê TRUSTED_PROXIES; vazio, nenhum proxy é confiável
+func trustedProxies() []string {
+	var proxies []string
+	for _, proxy := range strings.Split(os.Getenv("TRUSTED_PROXIES"), ",") {
+		if proxy = strings.TrimSpace(proxy); proxy != "" {
+			proxies = append(proxies, proxy)
+		}
+	}
+	return proxies
+}
+
+// stopGRPC espera as chamadas gRPC em andamento até o fim do prazo e
+// então encerra as que restarem
+func stopGRPC(ctx context.Context, srv *grpc.Server) {
+	done := make(chan struct{})
+	go func() {
+		srv.GracefulStop()
+		close(done)
+	}()
+
+	select {
+	case <-done:
+	case <-ctx.Done():
+		srv.Stop()
+	}
+}
+
+// registerJobs registra os jobs periódicos. Os agendamentos podem ser
+// trocados por JOB_SCHEDULE_<NOME>; sem notifier, não há lembretes.
+func registerJobs(notifier notifications.Notifier, reminders notifications.Config, files storage.Storage) error {
+	list := []jobs.Job{
+		{
+			Name:     "overdue",
+			Schedule: "* * * * *",
+			Run: func(ctx context.Context, db *gorm.DB) (string, error) {
+				marked, err := circulation.MarkOverdue(db, time.Now())
+				return fmt.Sprintf("%d loans marked overdue", marked), err
+			},
+		},
+		{
+			Name:     "prune-outbox",
+			Schedule: "@hourly",
+			Run: func(ctx context.Context, db *gorm.DB) (string, error) {
+				pruned, err := events.Prune(db, time.Now())
+				return fmt.Sprintf("%d events pruned", pruned), err
+			},
+		},
+		{
+			Name:     "purge-trash",
+			Schedule: "30 3 * * *",
+			Run: func(ctx context.Context, db *gorm.DB) (string, error) {
+				purged, err := service.PurgeTrash(ctx, db, files, time.Now())
+				return fmt.Sprintf("%d deleted records purged", purged), err
+			},
+		},
+	}
+	if notifier != nil {
+		list = append(list, jobs.Job{
+			Name:     "reminders",
+			Schedule: "@every " + reminders.Interval.String(),
+			Run: func(ctx context.Context, db *gorm.DB) (string, error) {
+				sent, err := notifications.SendReminders(ctx, db, notifier, reminders, time.Now())
+				return fmt.Sprintf("%d notifications sent", sent), err
+			},
+		})
+	}
+
+	for _, job := range list {
+		if err := jobs.Register(job); err != nil {
+			return err
+		}
+	}
+	return nil
 }
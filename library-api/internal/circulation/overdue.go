@@ -0,0 +1,45 @@
+package circulation
+
+import (
+	"library-api/internal/events"
+	"library-api/internal/models"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// MarkOverdue registra o atraso dos empréstimos que passaram do prazo sem
+// devolução e publica LoanOverdue, uma única vez por empréstimo. Devolve
+// quantos foram marcados.
+func MarkOverdue(db *gorm.DB, now time.Time) (int, error) {
+	// due_date é gravado com o deslocamento do fuso de quem criou o
+	// empréstimo; julianday compara o instante, não o texto
+	var loans []models.Loan
+	err := db.Preload("Book").
+		Where("return_date IS NULL AND overdue_at IS NULL AND julianday(due_date) < julianday(?)", now).
+		Find(&loans).Error
+	if err != nil {
+		return 0, err
+	}
+
+	marked := 0
+	for _, loan := range loans {
+		err := db.Transaction(func(tx *gorm.DB) error {
+			result := tx.Model(&models.Loan{}).Where("id = ? AND overdue_at IS NULL", loan.ID).
+				Update("overdue_at", now)
+			if result.Error != nil || result.RowsAffected == 0 {
+				return result.Error
+			}
+			loan.Overdue = true
+			if err := events.Publish(tx, events.LoanOverdue{Loan: loan}); err != nil {
+				return err
+			}
+			marked++
+			return nil
+		})
+		if err != nil {
+			return marked, err
+		}
+	}
+	return marked, nil
+}
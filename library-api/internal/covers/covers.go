@@ -0,0 +1,138 @@
+package covers
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"image"
+	"image/color"
+	"image/jpeg"
+	"net/http"
+
+	_ "image/png" // decodificadores registrados em image.Decode
+
+	"golang.org/x/image/draw"
+	_ "golang.org/x/image/webp"
+)
+
+// Limites das imagens enviadas
+const (
+	MaxBytes     = 5 << 20 // 5 MB
+	MaxDimension = 6000    // largura ou altura, em pixels
+)
+
+// Original é o tamanho que devolve o arquivo enviado, sem alteração
+const Original = "original"
+
+// Sizes são as larguras máximas das miniaturas geradas, que mantêm a
+// proporção da imagem
+var Sizes = map[string]int{
+	"small":  120,
+	"medium": 300,
+	"large":  600,
+}
+
+// Key é a chave de um tamanho da capa no storage
+func Key(bookID uint, size, ext string) string {
+	return fmt.Sprintf("covers/%d/%s%s", bookID, size, ext)
+}
+
+// Keys lista as chaves de todos os arquivos de uma capa: o original, com
+// a extensão do formato enviado, e as miniaturas em JPEG
+func Keys(bookID uint, ext string) []string {
+	keys := []string{Key(bookID, Original, ext)}
+	for size := range Sizes {
+		keys = append(keys, Key(bookID, size, ".jpg"))
+	}
+	return keys
+}
+
+// Formatos aceitos, pelo tipo detectado no conteúdo
+var extensions = map[string]string{
+	"image/jpeg": ".jpg",
+	"image/png":  ".png",
+	"image/webp": ".webp",
+}
+
+var (
+	ErrTooLarge    = fmt.Errorf("image larger than %d bytes", MaxBytes)
+	ErrUnsupported = errors.New("image must be JPEG, PNG or WebP")
+	ErrDimensions  = fmt.Errorf("image larger than %dx%d pixels", MaxDimension, MaxDimension)
+)
+
+// Cover é uma capa já validada, com as miniaturas em JPEG
+type Cover struct {
+	ContentType string
+	Extension   string
+	Width       int
+	Height      int
+	Original    []byte
+	Thumbnails  map[string][]byte
+}
+
+// Process confere o tipo pelo conteúdo (não pela extensão ou pelo
+// cabeçalho enviado), decodifica a imagem e gera as miniaturas
+func Process(data []byte) (*Cover, error) {
+	if len(data) > MaxBytes {
+		return nil, ErrTooLarge
+	}
+
+	contentType := http.DetectContentType(data)
+	ext, ok := extensions[contentType]
+	if !ok {
+		return nil, ErrUnsupported
+	}
+
+	// Confere as dimensões antes de decodificar a imagem inteira
+	config, _, err := image.DecodeConfig(bytes.NewReader(data))
+	if err != nil {
+		return nil, ErrUnsupported
+	}
+	if config.Width > MaxDimension || config.Height > MaxDimension {
+		return nil, ErrDimensions
+	}
+
+	img, _, err := image.Decode(bytes.NewReader(data))
+	if err != nil {
+		return nil, ErrUnsupported
+	}
+
+	cover := &Cover{
+		ContentType: contentType,
+		Extension:   ext,
+		Width:       config.Width,
+		Height:      config.Height,
+		Original:    data,
+		Thumbnails:  map[string][]byte{},
+	}
+	for name, width := range Sizes {
+		thumb, err := thumbnail(img, width)
+		if err != nil {
+			return nil, err
+		}
+		cover.Thumbnails[name] = thumb
+	}
+
+	return cover, nil
+}
+
+// thumbnail reduz a imagem até a largura informada (nunca amplia) e
+// codifica em JPEG, sobre fundo branco para imagens com transparência
+func thumbnail(img image.Image, width int) ([]byte, error) {
+	bounds := img.Bounds()
+	w, h := bounds.Dx(), bounds.Dy()
+	if w > width {
+		h = max(1, h*width/w)
+		w = width
+	}
+
+	dst := image.NewRGBA(image.Rect(0, 0, w, h))
+	draw.Draw(dst, dst.Bounds(), image.NewUniform(color.White), image.Point{}, draw.Src)
+	draw.CatmullRom.Scale(dst, dst.Bounds(), img, bounds, draw.Over, nil)
+
+	var buf bytes.Buffer
+	if err := jpeg.Encode(&buf, dst, &jpeg.Options{Quality: 85}); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
@@ -0,0 +1,91 @@
+package covers
+
+import (
+	"bytes"
+	"errors"
+	"image"
+	"image/color"
+	"image/jpeg"
+	"image/png"
+	"testing"
+)
+
+func encodePNG(t *testing.T, img image.Image) []byte {
+	t.Helper()
+	var buf bytes.Buffer
+	if err := png.Encode(&buf, img); err != nil {
+		t.Fatal(err)
+	}
+	return buf.Bytes()
+}
+
+func TestProcessRejects(t *testing.T) {
+	valid := encodePNG(t, image.NewRGBA(image.Rect(0, 0, 10, 10)))
+	gif := []byte("GIF89a\x01\x00\x01\x00\x00\x00\x00;")
+
+	tests := []struct {
+		name string
+		data []byte
+		want error
+	}{
+		{"oversized", append(bytes.Clone(valid), make([]byte, MaxBytes)...), ErrTooLarge},
+		{"text", []byte("isto não é uma imagem"), ErrUnsupported},
+		{"html", []byte("<html><body>capa</body></html>"), ErrUnsupported},
+		{"gif", gif, ErrUnsupported},
+		{"truncated png", valid[:20], ErrUnsupported},
+		{"too wide", encodePNG(t, image.NewGray(image.Rect(0, 0, MaxDimension+1, 1))), ErrDimensions},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := Process(tt.data); !errors.Is(err, tt.want) {
+				t.Errorf("err = %v, want %v", err, tt.want)
+			}
+		})
+	}
+}
+
+func TestProcessReencodesThumbnails(t *testing.T) {
+	// PNG transparente de 800x400: as miniaturas saem em JPEG, com fundo
+	// branco e a mesma proporção; a maior não passa da largura original
+	img := image.NewNRGBA(image.Rect(0, 0, 800, 400))
+	data := encodePNG(t, img)
+
+	cover, err := Process(data)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if cover.ContentType != "image/png" || cover.Extension != ".png" || cover.Width != 800 || cover.Height != 400 {
+		t.Errorf("cover = %s %s %dx%d, want image/png .png 800x400", cover.ContentType, cover.Extension, cover.Width, cover.Height)
+	}
+	if !bytes.Equal(cover.Original, data) {
+		t.Error("original was changed")
+	}
+
+	for name, width := range Sizes {
+		thumb, err := jpeg.Decode(bytes.NewReader(cover.Thumbnails[name]))
+		if err != nil {
+			t.Fatalf("%s: not a JPEG: %v", name, err)
+		}
+		if got := thumb.Bounds().Size(); got != image.Pt(width, width/2) {
+			t.Errorf("%s: size %v, want %dx%d", name, got, width, width/2)
+		}
+		r, g, b, _ := thumb.At(0, 0).RGBA()
+		if r>>8 < 250 || g>>8 < 250 || b>>8 < 250 {
+			t.Errorf("%s: background %v, want white", name, color.RGBA{uint8(r >> 8), uint8(g >> 8), uint8(b >> 8), 255})
+		}
+	}
+}
+
+func TestProcessDoesNotEnlarge(t *testing.T) {
+	cover, err := Process(encodePNG(t, image.NewGray(image.Rect(0, 0, 200, 100))))
+	if err != nil {
+		t.Fatal(err)
+	}
+	thumb, err := jpeg.Decode(bytes.NewReader(cover.Thumbnails["large"]))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got := thumb.Bounds().Size(); got != image.Pt(200, 100) {
+		t.Errorf("large: size %v, want 200x100", got)
+	}
+}
@@ -1,8 +1,16 @@
 package database
 
 import (
+	"context"
+	"errors"
+	"fmt"
+	"library-api/internal/logging"
 	"library-api/internal/models"
-	"log"
+	"log/slog"
+	"os"
+	"strconv"
+	"sync/atomic"
+	"time"
 
 	"gorm.io/driver/sqlite"
 	"gorm.io/gorm"
@@ -10,22 +18,115 @@ import (
 
 var DB *gorm.DB
 
+// schema são os modelos migrados na conexão, na ordem da migração
+var schema = []interface{}{
+	&models.Book{}, &models.Author{}, &models.AuthorAlias{}, &models.Subject{}, &models.Series{},
+	&models.BookAuthor{}, &models.BookCover{}, &models.Loan{}, &models.Webhook{}, &models.WebhookDelivery{},
+	&models.OutboxEvent{}, &models.Patron{}, &models.Notification{}, &models.Job{},
+}
+
+// migrated indica que a migração e os preenchimentos terminaram
+var migrated atomic.Bool
+
 func Connect() {
 	var err error
-	DB, err = gorm.Open(sqlite.Open("library.db"), &gorm.Config{})
+	DB, err = gorm.Open(sqlite.Open("library.db"), &gorm.Config{
+		Logger: logging.NewGormLogger(slowQueryThreshold()),
+	})
 	if err != nil {
-		log.Fatal("Failed to connect to database: ", err)
+		logging.Fatal("Failed to connect to database", err)
+	}
+
+	// book_authors guarda também o papel e a ordem de cada autor
+	if err := DB.SetupJoinTable(&models.Book{}, "Authors", &models.BookAuthor{}); err != nil {
+		logging.Fatal("Failed to set up book_authors", err)
+	}
+	if err := DB.SetupJoinTable(&models.Author{}, "Books", &models.BookAuthor{}); err != nil {
+		logging.Fatal("Failed to set up book_authors", err)
 	}
 
 	// Auto-migrate models
-	err = DB.AutoMigrate(&models.Book{}, &models.Author{}, &models.Loan{})
+	err = DB.AutoMigrate(schema...)
 	if err != nil {
-		log.Fatal("Failed to migrate database:", err)
+		logging.Fatal("Failed to migrate database", err)
 	}
 
-	log.Println("Database connected and migrated successfully")
+	backfillSortNames()
+	backfillDueDates()
+	migrated.Store(true)
+
+	slog.Info("Database connected and migrated successfully")
+}
+
+// slowQueryThreshold lê SLOW_QUERY_MS (padrão 200); 0 desativa o aviso
+// de consulta lenta
+func slowQueryThreshold() time.Duration {
+	ms, err := strconv.Atoi(os.Getenv("SLOW_QUERY_MS"))
+	if err != nil || ms < 0 {
+		ms = 200
+	}
+	return time.Duration(ms) * time.Millisecond
 }
 
 func GetDB() *gorm.DB {
 	return DB
 }
+
+// Ping verifica se o banco responde
+func Ping(ctx context.Context) error {
+	if DB == nil {
+		return errors.New("database not connected")
+	}
+	sqlDB, err := DB.DB()
+	if err != nil {
+		return err
+	}
+	return sqlDB.PingContext(ctx)
+}
+
+// CheckMigrations verifica se a migração terminou e se as tabelas de
+// todos os modelos existem
+func CheckMigrations(ctx context.Context) error {
+	if !migrated.Load() {
+		return errors.New("migrations not applied")
+	}
+	migrator := DB.WithContext(ctx).Migrator()
+	for _, model := range schema {
+		if !migrator.HasTable(model) {
+			return fmt.Errorf("table for %T is missing", model)
+		}
+	}
+	return nil
+}
+
+// Close fecha as conexões com o banco
+func Close() error {
+	if DB == nil {
+		return nil
+	}
+	sqlDB, err := DB.DB()
+	if err != nil {
+		return err
+	}
+	return sqlDB.Close()
+}
+
+// backfillSortNames gera a forma de ordenação dos autores criados antes
+// da coluna sort_name existir
+func backfillSortNames() {
+	var authors []models.Author
+	DB.Select("id", "name").Where("sort_name IS NULL OR sort_name = ''").Find(&authors)
+	for _, author := range authors {
+		DB.Model(&author).Update("sort_name", models.SortName(author.Name))
+	}
+}
+
+// backfillDueDates define o prazo dos empréstimos feitos antes da coluna
+// due_date existir, contando o prazo padrão a partir do empréstimo
+func backfillDueDates() {
+	var loans []models.Loan
+	DB.Select("id", "loan_date").Where("due_date IS NULL").Find(&loans)
+	for _, loan := range loans {
+		DB.Model(&loan).Update("due_date", loan.LoanDate.Add(models.LoanPeriod))
+	}
+}
@@ -0,0 +1,176 @@
+package dedup
+
+import (
+	"sort"
+	"strings"
+	"unicode"
+
+	"golang.org/x/text/runes"
+	"golang.org/x/text/transform"
+	"golang.org/x/text/unicode/norm"
+)
+
+// DefaultThreshold é a similaridade mínima para dois nomes serem
+// considerados o mesmo autor
+const DefaultThreshold = 0.85
+
+// Candidate é um nome a comparar
+type Candidate struct {
+	ID   uint
+	Name string
+}
+
+// Group é um conjunto de prováveis duplicatas
+type Group struct {
+	IDs   []uint  // em ordem crescente
+	Score float64 // menor similaridade entre os pares que formaram o grupo
+}
+
+// Normalize reduz um nome à forma usada na comparação: sem acentos, sem
+// pontuação, em minúsculas, em ordem direta ("Tolkien, J. R. R." vira
+// "jrr tolkien") e com iniciais seguidas juntas ("j r r" vira "jrr")
+func Normalize(name string) string {
+	// "Sobrenome, Nome" vira "Nome Sobrenome"
+	if surname, forename, ok := strings.Cut(name, ","); ok && strings.TrimSpace(forename) != "" {
+		name = forename + " " + surname
+	}
+
+	t := transform.Chain(norm.NFD, runes.Remove(runes.In(unicode.Mn)), norm.NFC)
+	name, _, _ = transform.String(t, name)
+
+	name = strings.Map(func(r rune) rune {
+		switch {
+		case unicode.IsLetter(r) || unicode.IsDigit(r):
+			return unicode.ToLower(r)
+		default:
+			return ' '
+		}
+	}, name)
+
+	var tokens []string
+	initials := ""
+	for _, token := range strings.Fields(name) {
+		if len([]rune(token)) == 1 {
+			initials += token
+			continue
+		}
+		if initials != "" {
+			tokens = append(tokens, initials)
+			initials = ""
+		}
+		tokens = append(tokens, token)
+	}
+	if initials != "" {
+		tokens = append(tokens, initials)
+	}
+
+	return strings.Join(tokens, " ")
+}
+
+// Similarity compara dois nomes já normalizados, de 0 (diferentes) a 1
+// (iguais), pela distância de Levenshtein
+func Similarity(a, b string) float64 {
+	if a == b {
+		return 1
+	}
+	ra, rb := []rune(a), []rune(b)
+	longest := max(len(ra), len(rb))
+	if longest == 0 {
+		return 1
+	}
+	return 1 - float64(levenshtein(ra, rb))/float64(longest)
+}
+
+// FindDuplicates agrupa os nomes com similaridade a partir de threshold.
+// Só são comparados nomes cujo último termo (o sobrenome, na ordem
+// direta) começa com a mesma letra, o que evita comparar todos com todos.
+func FindDuplicates(candidates []Candidate, threshold float64) []Group {
+	keys := make([]string, len(candidates))
+	blocks := map[rune][]int{}
+	for i, c := range candidates {
+		keys[i] = Normalize(c.Name)
+		fields := strings.Fields(keys[i])
+		if len(fields) == 0 {
+			continue
+		}
+		first := []rune(fields[len(fields)-1])[0]
+		blocks[first] = append(blocks[first], i)
+	}
+
+	// union-find dos pares parecidos
+	parent := make([]int, len(candidates))
+	for i := range parent {
+		parent[i] = i
+	}
+	var find func(int) int
+	find = func(i int) int {
+		if parent[i] != i {
+			parent[i] = find(parent[i])
+		}
+		return parent[i]
+	}
+
+	scores := map[int]float64{}
+	for _, block := range blocks {
+		for x := 0; x < len(block); x++ {
+			for y := x + 1; y < len(block); y++ {
+				i, j := block[x], block[y]
+				score := Similarity(keys[i], keys[j])
+				if score < threshold {
+					continue
+				}
+				ri, rj := find(i), find(j)
+				low := min(score, groupScore(scores, ri), groupScore(scores, rj))
+				if ri != rj {
+					parent[rj] = ri
+					delete(scores, rj)
+				}
+				scores[ri] = low
+			}
+		}
+	}
+
+	members := map[int][]uint{}
+	for i, c := range candidates {
+		root := find(i)
+		if _, ok := scores[root]; ok {
+			members[root] = append(members[root], c.ID)
+		}
+	}
+
+	groups := make([]Group, 0, len(members))
+	for root, ids := range members {
+		sort.Slice(ids, func(a, b int) bool { return ids[a] < ids[b] })
+		groups = append(groups, Group{IDs: ids, Score: scores[root]})
+	}
+	sort.Slice(groups, func(a, b int) bool { return groups[a].IDs[0] < groups[b].IDs[0] })
+
+	return groups
+}
+
+func groupScore(scores map[int]float64, root int) float64 {
+	if score, ok := scores[root]; ok {
+		return score
+	}
+	return 1
+}
+
+func levenshtein(a, b []rune) int {
+	prev := make([]int, len(b)+1)
+	curr := make([]int, len(b)+1)
+	for j := range prev {
+		prev[j] = j
+	}
+	for i := 1; i <= len(a); i++ {
+		curr[0] = i
+		for j := 1; j <= len(b); j++ {
+			cost := 1
+			if a[i-1] == b[j-1] {
+				cost = 0
+			}
+			curr[j] = min(prev[j]+1, curr[j-1]+1, prev[j-1]+cost)
+		}
+		prev, curr = curr, prev
+	}
+	return prev[len(b)]
+}
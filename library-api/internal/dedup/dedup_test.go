@@ -0,0 +1,90 @@
+package dedup
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestNormalize(t *testing.T) {
+	tests := []struct {
+		name string
+		want string
+	}{
+		{"J. R. R. Tolkien", "jrr tolkien"},
+		{"JRR Tolkien", "jrr tolkien"},
+		{"Tolkien, J.R.R.", "jrr tolkien"},
+		{"José Saramago", "jose saramago"},
+		{"JOSÉ SARAMAGO", "jose saramago"},
+		{"Saramago, José", "jose saramago"},
+		{"Gabriel García Márquez", "gabriel garcia marquez"},
+		{"  Machado   de Assis ", "machado de assis"},
+		{"Machado de Assis (1839-1908)", "machado de assis 1839 1908"},
+		{"Tolkien,", "tolkien"},
+		{"", ""},
+	}
+	for _, tt := range tests {
+		if got := Normalize(tt.name); got != tt.want {
+			t.Errorf("Normalize(%q) = %q, want %q", tt.name, got, tt.want)
+		}
+	}
+}
+
+func TestSimilarity(t *testing.T) {
+	tests := []struct {
+		a, b string
+		want float64
+	}{
+		{"jrr tolkien", "jrr tolkien", 1},
+		{"", "", 1},
+		{"abcd", "", 0},
+		{"agatha mary christie", "agata mari cristie", 0.85},
+		{"agatha mary christie", "agata mari cristi", 0.80},
+	}
+	for _, tt := range tests {
+		if got := Similarity(tt.a, tt.b); got < tt.want-1e-9 || got > tt.want+1e-9 {
+			t.Errorf("Similarity(%q, %q) = %v, want %v", tt.a, tt.b, got, tt.want)
+		}
+	}
+}
+
+func TestFindDuplicatesThreshold(t *testing.T) {
+	tests := []struct {
+		name      string
+		other     string
+		threshold float64
+		want      []Group
+	}{
+		{"exactly at the threshold", "Agata Mari Cristie", DefaultThreshold, []Group{{IDs: []uint{1, 2}, Score: 0.85}}},
+		{"just below the threshold", "Agata Mari Cristi", DefaultThreshold, []Group{}},
+		{"lower threshold", "Agata Mari Cristi", 0.80, []Group{{IDs: []uint{1, 2}, Score: 0.80}}},
+		{"same normalized name", "CHRISTIE, Agatha Mary", 1, []Group{{IDs: []uint{1, 2}, Score: 1}}},
+		// Sobrenomes com iniciais diferentes nem chegam a ser comparados
+		{"different block", "Agatha Mary Kristie", 0.5, []Group{}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := FindDuplicates([]Candidate{{ID: 1, Name: "Agatha Mary Christie"}, {ID: 2, Name: tt.other}}, tt.threshold)
+			for i := range got {
+				// Score vem de uma divisão; compara arredondado
+				got[i].Score = float64(int(got[i].Score*1000+0.5)) / 1000
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("got %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestFindDuplicatesGroupsTransitively(t *testing.T) {
+	candidates := []Candidate{
+		{ID: 3, Name: "J. R. R. Tolkien"},
+		{ID: 1, Name: "JRR Tolkien"},
+		{ID: 2, Name: "Tolkien, J.R.R."},
+		{ID: 4, Name: "Christopher Tolkien"},
+		{ID: 5, Name: "José Saramago"},
+	}
+	want := []Group{{IDs: []uint{1, 2, 3}, Score: 1}}
+	if got := FindDuplicates(candidates, DefaultThreshold); !reflect.DeepEqual(got, want) {
+		t.Errorf("got %+v, want %+v", got, want)
+	}
+}
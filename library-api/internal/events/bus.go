@@ -0,0 +1,50 @@
+package events
+
+import (
+	"context"
+	"sync"
+)
+
+// Handler processa um evento. Pode ser chamado mais de uma vez para o
+// mesmo evento (se o processo cair no meio, por exemplo), então deve
+// tolerar repetições; Event.ID serve para descartá-las.
+type Handler func(ctx context.Context, event Event) error
+
+type subscription struct {
+	name    string
+	types   map[string]bool // vazio recebe todos
+	handler Handler
+}
+
+var (
+	mu            sync.RWMutex
+	subscriptions []subscription
+)
+
+// Subscribe registra um assinante dos tipos informados (ou de todos, sem
+// tipos). O nome identifica o assinante na outbox e deve ser único e
+// estável entre versões.
+func Subscribe(name string, handler Handler, types ...string) {
+	sub := subscription{name: name, types: map[string]bool{}, handler: handler}
+	for _, t := range types {
+		sub.types[t] = true
+	}
+
+	mu.Lock()
+	defer mu.Unlock()
+	subscriptions = append(subscriptions, sub)
+}
+
+// subscribers devolve os assinantes de um tipo de evento
+func subscribers(eventType string) []subscription {
+	mu.RLock()
+	defer mu.RUnlock()
+
+	var subs []subscription
+	for _, sub := range subscriptions {
+		if len(sub.types) == 0 || sub.types[eventType] {
+			subs = append(subs, sub)
+		}
+	}
+	return subs
+}
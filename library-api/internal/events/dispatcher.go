@@ -0,0 +1,172 @@
+package events
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"library-api/internal/models"
+	"log/slog"
+	"slices"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// Parâmetros do dispatcher
+const (
+	// MaxAttempts é o número de tentativas antes de o evento falhar
+	MaxAttempts = 10
+
+	// Retention é por quanto tempo os eventos entregues ficam na outbox
+	Retention = 7 * 24 * time.Hour
+
+	pollInterval = time.Second
+	batchSize    = 50
+	leaseTime    = time.Minute // posse de um evento por uma instância
+	retryBase    = 5 * time.Second
+	retryMax     = 10 * time.Minute
+)
+
+// Backoff é a espera antes de tentar de novo: 5s, 10s, 20s... até 10min
+func Backoff(attempts int) time.Duration {
+	wait := retryBase << (attempts - 1)
+	if wait <= 0 || wait > retryMax {
+		return retryMax
+	}
+	return wait
+}
+
+// Start inicia o dispatcher, que lê a outbox e entrega os eventos aos
+// assinantes. Os eventos saem na ordem em que foram gravados, mas um
+// evento com falha é tentado de novo depois, sem segurar os seguintes.
+// A função devolvida para o dispatcher e espera o lote em andamento.
+func Start(db *gorm.DB) func() {
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		run(ctx, db)
+	}()
+
+	return func() {
+		cancel()
+		<-done
+	}
+}
+
+func run(ctx context.Context, db *gorm.DB) {
+	ticker := time.NewTicker(pollInterval)
+	defer ticker.Stop()
+
+	for {
+		dispatchPending(ctx, db)
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// dispatchPending entrega os eventos pendentes cuja vez chegou e os que
+// ficaram com uma instância que caiu no meio da entrega
+func dispatchPending(ctx context.Context, db *gorm.DB) {
+	var pending []models.OutboxEvent
+	err := claimable(db, time.Now()).Order("id").Limit(batchSize).Find(&pending).Error
+	if err != nil {
+		slog.Error("Failed to load outbox", "error", err)
+		return
+	}
+
+	for i := range pending {
+		if ctx.Err() != nil {
+			return
+		}
+		claimed, err := claim(db, pending[i].ID)
+		if err != nil {
+			slog.Error("Failed to claim outbox event", "event_id", pending[i].EventID, "error", err)
+			continue
+		}
+		if claimed {
+			dispatch(ctx, db, &pending[i])
+		}
+	}
+}
+
+// claimable filtra os eventos que podem ser pegos agora. As datas passam
+// por julianday porque o SQLite compara o texto gravado, e o deslocamento
+// do fuso muda o texto sem mudar o instante.
+func claimable(db *gorm.DB, now time.Time) *gorm.DB {
+	return db.Where("(status = ? AND julianday(next_attempt_at) <= julianday(?)) OR (status = ? AND julianday(locked_until) < julianday(?))",
+		models.OutboxPending, now, models.OutboxProcessing, now)
+}
+
+// claim pega o evento por leaseTime, para que as outras réplicas não o
+// entreguem ao mesmo tempo. Devolve false se outra instância chegou antes.
+func claim(db *gorm.DB, id uint) (bool, error) {
+	now := time.Now()
+	result := claimable(db.Model(&models.OutboxEvent{}), now).Where("id = ?", id).
+		Updates(map[string]interface{}{"status": models.OutboxProcessing, "locked_until": now.Add(leaseTime)})
+	return result.RowsAffected == 1, result.Error
+}
+
+// dispatch entrega o evento aos assinantes que ainda não o processaram e
+// grava o resultado. Quem já processou não recebe de novo nas tentativas
+// seguintes.
+func dispatch(ctx context.Context, db *gorm.DB, row *models.OutboxEvent) {
+	event := Event{ID: row.EventID, Type: row.Type, CreatedAt: row.CreatedAt, Data: json.RawMessage(row.Data)}
+
+	var failures []error
+	for _, sub := range subscribers(row.Type) {
+		if slices.Contains(row.Handled, sub.name) {
+			continue
+		}
+		if err := call(ctx, sub, event); err != nil {
+			failures = append(failures, fmt.Errorf("%s: %w", sub.name, err))
+			continue
+		}
+		row.Handled = append(row.Handled, sub.name)
+	}
+
+	now := time.Now()
+	row.Attempts++
+	row.Status = models.OutboxPending
+	row.LockedUntil = nil
+	if len(failures) == 0 {
+		row.Status = models.OutboxDispatched
+		row.DispatchedAt = &now
+		row.LastError = ""
+	} else {
+		row.LastError = fmt.Sprint(failures)
+		if row.Attempts >= MaxAttempts {
+			row.Status = models.OutboxFailed
+		} else {
+			row.NextAttemptAt = now.Add(Backoff(row.Attempts))
+		}
+		slog.Warn("Event dispatch failed", "event_id", row.EventID, "type", row.Type,
+			"attempts", row.Attempts, "status", row.Status, "error", row.LastError)
+	}
+
+	if err := db.Save(row).Error; err != nil {
+		slog.Error("Failed to save outbox event", "event_id", row.EventID, "error", err)
+	}
+}
+
+// call chama o assinante, transformando um panic em erro
+func call(ctx context.Context, sub subscription, event Event) (err error) {
+	defer func() {
+		if r := recover(); r != nil {
+			err = fmt.Errorf("panic: %v", r)
+		}
+	}()
+	return sub.handler(ctx, event)
+}
+
+// Prune apaga os eventos entregues há mais de Retention e devolve
+// quantos foram apagados. Roda como o job "prune-outbox".
+func Prune(db *gorm.DB, now time.Time) (int64, error) {
+	result := db.Where("status = ? AND julianday(dispatched_at) < julianday(?)", models.OutboxDispatched, now.Add(-Retention)).
+		Delete(&models.OutboxEvent{})
+	return result.RowsAffected, result.Error
+}
@@ -0,0 +1,131 @@
+package events
+
+import (
+	"context"
+	"library-api/internal/models"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"gorm.io/driver/sqlite"
+	"gorm.io/gorm"
+	"gorm.io/gorm/logger"
+)
+
+func openOutbox(t *testing.T) *gorm.DB {
+	t.Helper()
+	db, err := gorm.Open(sqlite.Open(filepath.Join(t.TempDir(), "outbox.db")), &gorm.Config{Logger: logger.Discard})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := db.AutoMigrate(&models.OutboxEvent{}); err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() {
+		sqlDB, _ := db.DB()
+		sqlDB.Close()
+	})
+	return db
+}
+
+func TestClaim(t *testing.T) {
+	db := openOutbox(t)
+	now := time.Now()
+
+	rows := []models.OutboxEvent{
+		{EventID: "ready", Type: TypeBookCreated, Data: "{}", Status: models.OutboxPending, NextAttemptAt: now.Add(-time.Second)},
+		{EventID: "later", Type: TypeBookCreated, Data: "{}", Status: models.OutboxPending, NextAttemptAt: now.Add(time.Hour)},
+		{EventID: "expired", Type: TypeBookCreated, Data: "{}", Status: models.OutboxProcessing, LockedUntil: ptr(now.Add(-time.Second))},
+		{EventID: "leased", Type: TypeBookCreated, Data: "{}", Status: models.OutboxProcessing, LockedUntil: ptr(now.Add(time.Minute))},
+		{EventID: "done", Type: TypeBookCreated, Data: "{}", Status: models.OutboxDispatched},
+	}
+	if err := db.Create(&rows).Error; err != nil {
+		t.Fatal(err)
+	}
+
+	want := map[string]bool{"ready": true, "later": false, "expired": true, "leased": false, "done": false}
+	for _, row := range rows {
+		claimed, err := claim(db, row.ID)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if claimed != want[row.EventID] {
+			t.Errorf("%s: claimed = %v, want %v", row.EventID, claimed, want[row.EventID])
+		}
+
+		// A segunda instância não pega o que a primeira já pegou
+		if claimed {
+			if again, _ := claim(db, row.ID); again {
+				t.Errorf("%s: claimed twice", row.EventID)
+			}
+		}
+	}
+}
+
+func TestDispatchReleasesClaim(t *testing.T) {
+	db := openOutbox(t)
+
+	row := models.OutboxEvent{EventID: "e1", Type: "test.claim", Data: "{}", Status: models.OutboxPending, NextAttemptAt: time.Now()}
+	if err := db.Create(&row).Error; err != nil {
+		t.Fatal(err)
+	}
+
+	calls := 0
+	Subscribe("test-claim", func(ctx context.Context, event Event) error {
+		calls++
+		return nil
+	}, "test.claim")
+
+	// Duas réplicas lendo a mesma fila entregam o evento uma vez só
+	dispatchPending(context.Background(), db)
+	dispatchPending(context.Background(), db)
+	if calls != 1 {
+		t.Errorf("handler called %d times, want 1", calls)
+	}
+
+	db.First(&row, row.ID)
+	if row.Status != models.OutboxDispatched || row.LockedUntil != nil {
+		t.Errorf("status = %q, locked_until = %v, want dispatched without lock", row.Status, row.LockedUntil)
+	}
+}
+
+// Um evento recém-publicado pode ser pego na hora mesmo a oeste de UTC,
+// onde o texto da hora local é "menor" que o da hora em UTC
+func TestPublishedEventIsClaimableWestOfUTC(t *testing.T) {
+	loc, err := time.LoadLocation("America/Sao_Paulo")
+	if err != nil {
+		t.Fatal(err)
+	}
+	local := time.Local
+	time.Local = loc
+	t.Cleanup(func() { time.Local = local })
+
+	db := openOutbox(t)
+	if err := Publish(db, BookCreated{Book: models.Book{ID: 1, Title: "Dom Casmurro"}}); err != nil {
+		t.Fatal(err)
+	}
+	// Linha gravada em UTC, como fazia a versão anterior do Publish
+	old := models.OutboxEvent{EventID: "utc", Type: TypeBookCreated, Data: "{}", Status: models.OutboxPending,
+		NextAttemptAt: time.Now().UTC()}
+	db.Create(&old)
+
+	var count int64
+	claimable(db.Model(&models.OutboxEvent{}), time.Now()).Count(&count)
+	if count != 2 {
+		t.Errorf("claimable events = %d, want 2", count)
+	}
+
+	delivered := 0
+	Subscribe("test-west", func(ctx context.Context, event Event) error {
+		delivered++
+		return nil
+	}, TypeBookCreated)
+	dispatchPending(context.Background(), db)
+	if delivered != 2 {
+		t.Errorf("delivered %d events, want 2", delivered)
+	}
+}
+
+func ptr[T any](v T) *T {
+	return &v
+}
@@ -0,0 +1,140 @@
+package events
+
+import (
+	"crypto/rand"
+	"encoding/hex"
+	"encoding/json"
+	"library-api/internal/models"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// Tipos dos eventos de domínio
+const (
+	TypeLoanCreated   = "loan.created"
+	TypeLoanReturned  = "loan.returned"
+	TypeLoanOverdue   = "loan.overdue"
+	TypeBookCreated   = "book.created"
+	TypeBookUpdated   = "book.updated"
+	TypeBookDeleted   = "book.deleted"
+	TypeAuthorCreated = "author.created"
+	TypeAuthorUpdated = "author.updated"
+	TypeAuthorDeleted = "author.deleted"
+	TypeAuthorMerged  = "author.merged"
+)
+
+// Types lista todos os tipos de evento
+var Types = []string{
+	TypeLoanCreated, TypeLoanReturned, TypeLoanOverdue,
+	TypeBookCreated, TypeBookUpdated, TypeBookDeleted,
+	TypeAuthorCreated, TypeAuthorUpdated, TypeAuthorDeleted, TypeAuthorMerged,
+}
+
+// Payload é o conteúdo de um evento de domínio
+type Payload interface {
+	EventType() string
+}
+
+// LoanCreated: um livro foi emprestado
+type LoanCreated struct {
+	Loan models.Loan `json:"loan"`
+}
+
+// LoanReturned: um empréstimo foi devolvido; Late indica devolução depois
+// do prazo
+type LoanReturned struct {
+	Loan models.Loan `json:"loan"`
+	Late bool        `json:"late"`
+}
+
+// LoanOverdue: um empréstimo passou do prazo sem ser devolvido
+type LoanOverdue struct {
+	Loan models.Loan `json:"loan"`
+}
+
+// BookCreated: um livro entrou no acervo, pelo cadastro ou pela importação
+type BookCreated struct {
+	Book models.Book `json:"book"`
+}
+
+// BookUpdated: os dados de um livro mudaram
+type BookUpdated struct {
+	Book models.Book `json:"book"`
+}
+
+// BookDeleted: um livro saiu do acervo
+type BookDeleted struct {
+	Book models.Book `json:"book"`
+}
+
+// AuthorCreated: um autor foi cadastrado
+type AuthorCreated struct {
+	Author models.Author `json:"author"`
+}
+
+// AuthorUpdated: os dados de um autor mudaram
+type AuthorUpdated struct {
+	Author models.Author `json:"author"`
+}
+
+// AuthorDeleted: um autor foi removido
+type AuthorDeleted struct {
+	Author models.Author `json:"author"`
+}
+
+// AuthorMerged: autores duplicados foram unidos a Author
+type AuthorMerged struct {
+	Author    models.Author `json:"author"`
+	MergedIDs []uint        `json:"merged_ids"`
+}
+
+func (LoanCreated) EventType() string   { return TypeLoanCreated }
+func (LoanReturned) EventType() string  { return TypeLoanReturned }
+func (LoanOverdue) EventType() string   { return TypeLoanOverdue }
+func (BookCreated) EventType() string   { return TypeBookCreated }
+func (BookUpdated) EventType() string   { return TypeBookUpdated }
+func (BookDeleted) EventType() string   { return TypeBookDeleted }
+func (AuthorCreated) EventType() string { return TypeAuthorCreated }
+func (AuthorUpdated) EventType() string { return TypeAuthorUpdated }
+func (AuthorDeleted) EventType() string { return TypeAuthorDeleted }
+func (AuthorMerged) EventType() string  { return TypeAuthorMerged }
+
+// Event é um evento lido da outbox, como os assinantes o recebem
+type Event struct {
+	ID        string          `json:"id"`
+	Type      string          `json:"type"`
+	CreatedAt time.Time       `json:"created_at"`
+	Data      json.RawMessage `json:"data"`
+}
+
+// Decode lê o payload no tipo do evento (LoanCreated, BookUpdated...)
+func (e Event) Decode(payload Payload) error {
+	return json.Unmarshal(e.Data, payload)
+}
+
+// Publish grava o evento na outbox. Deve receber a transação da mudança
+// que gerou o evento, para que os dois sejam gravados ou desfeitos juntos.
+func Publish(tx *gorm.DB, payload Payload) error {
+	data, err := json.Marshal(payload)
+	if err != nil {
+		return err
+	}
+
+	now := time.Now()
+	return tx.Create(&models.OutboxEvent{
+		EventID:       NewID(),
+		Type:          payload.EventType(),
+		Data:          string(data),
+		Status:        models.OutboxPending,
+		NextAttemptAt: now,
+		CreatedAt:     now.UTC(),
+	}).Error
+}
+
+// NewID gera um identificador aleatório para eventos
+func NewID() string {
+	b := make([]byte, 16)
+	rand.Read(b)
+	return hex.EncodeToString(b)
+}
@@ -0,0 +1,201 @@
+package exporter
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"io"
+	"library-api/internal/marc"
+	"library-api/internal/models"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Formatos de exportação suportados
+const (
+	FormatCSV     = "csv"
+	FormatJSONL   = "jsonl"
+	FormatMARC21  = "marc21"
+	FormatMARCXML = "marcxml"
+)
+
+// Writer grava livros um a um no formato escolhido
+type Writer interface {
+	Write(book models.Book) error
+	Close() error
+}
+
+// ContentType devolve o Content-Type e a extensão de arquivo do formato
+func ContentType(format string) (string, string) {
+	switch format {
+	case FormatCSV:
+		return "text/csv; charset=utf-8", "csv"
+	case FormatJSONL:
+		return "application/x-ndjson", "jsonl"
+	case FormatMARC21:
+		return "application/marc", "mrc"
+	case FormatMARCXML:
+		return "application/marcxml+xml", "xml"
+	default:
+		return "", ""
+	}
+}
+
+// NewWriter cria o writer do formato informado
+func NewWriter(format string, w io.Writer) (Writer, error) {
+	switch format {
+	case FormatCSV:
+		return newCSVWriter(w)
+	case FormatJSONL:
+		return &jsonlWriter{enc: json.NewEncoder(w)}, nil
+	case FormatMARC21:
+		return &marcWriter{mw: marc.NewWriter(w)}, nil
+	case FormatMARCXML:
+		xw, err := marc.NewXMLWriter(w)
+		if err != nil {
+			return nil, err
+		}
+		return &marcXMLWriter{xw: xw}, nil
+	default:
+		return nil, fmt.Errorf("unsupported format %q", format)
+	}
+}
+
+// authorNames devolve os nomes dos autores na ordem em que foram carregados
+func authorNames(book models.Book) []string {
+	names := make([]string, 0, len(book.Authors))
+	for _, author := range book.Authors {
+		names = append(names, author.Name)
+	}
+	return names
+}
+
+func subjectNames(book models.Book) []string {
+	names := make([]string, 0, len(book.Subjects))
+	for _, subject := range book.Subjects {
+		names = append(names, subject.Name)
+	}
+	return names
+}
+
+// csvWriter usa as mesmas colunas aceitas pela importação
+type csvWriter struct {
+	w *csv.Writer
+}
+
+func newCSVWriter(w io.Writer) (*csvWriter, error) {
+	cw := csv.NewWriter(w)
+	err := cw.Write([]string{
+		"id", "title", "isbn", "authors", "available", "publisher", "publication_year",
+		"edition", "language", "pages", "description", "subjects", "created_at", "updated_at",
+	})
+	return &csvWriter{w: cw}, err
+}
+
+func (c *csvWriter) Write(book models.Book) error {
+	err := c.w.Write([]string{
+		strconv.FormatUint(uint64(book.ID), 10),
+		book.Title,
+		book.ISBN,
+		strings.Join(authorNames(book), "; "),
+		strconv.FormatBool(book.Available),
+		book.Publisher,
+		optionalNumber(book.PublicationYear),
+		book.Edition,
+		book.Language,
+		optionalNumber(book.Pages),
+		book.Description,
+		strings.Join(subjectNames(book), "; "),
+		book.CreatedAt.Format(time.RFC3339),
+		book.UpdatedAt.Format(time.RFC3339),
+	})
+	if err != nil {
+		return err
+	}
+	// Envia cada linha para o cliente sem acumular no buffer
+	c.w.Flush()
+	return c.w.Error()
+}
+
+func (c *csvWriter) Close() error {
+	c.w.Flush()
+	return c.w.Error()
+}
+
+// optionalNumber deixa a célula vazia quando o valor não foi informado
+func optionalNumber(n int) string {
+	if n == 0 {
+		return ""
+	}
+	return strconv.Itoa(n)
+}
+
+// jsonlRow segue o formato aceito pela importação JSONL
+type jsonlRow struct {
+	ID              uint      `json:"id"`
+	Title           string    `json:"title"`
+	ISBN            string    `json:"isbn"`
+	Authors         []string  `json:"authors"`
+	Available       bool      `json:"available"`
+	Publisher       string    `json:"publisher,omitempty"`
+	PublicationYear int       `json:"publication_year,omitempty"`
+	Edition         string    `json:"edition,omitempty"`
+	Language        string    `json:"language,omitempty"`
+	Pages           int       `json:"pages,omitempty"`
+	Description     string    `json:"description,omitempty"`
+	Subjects        []string  `json:"subjects"`
+	CreatedAt       time.Time `json:"created_at"`
+	UpdatedAt       time.Time `json:"updated_at"`
+}
+
+type jsonlWriter struct {
+	enc *json.Encoder
+}
+
+func (j *jsonlWriter) Write(book models.Book) error {
+	return j.enc.Encode(jsonlRow{
+		ID:              book.ID,
+		Title:           book.Title,
+		ISBN:            book.ISBN,
+		Authors:         authorNames(book),
+		Available:       book.Available,
+		Publisher:       book.Publisher,
+		PublicationYear: book.PublicationYear,
+		Edition:         book.Edition,
+		Language:        book.Language,
+		Pages:           book.Pages,
+		Description:     book.Description,
+		Subjects:        subjectNames(book),
+		CreatedAt:       book.CreatedAt,
+		UpdatedAt:       book.UpdatedAt,
+	})
+}
+
+func (j *jsonlWriter) Close() error {
+	return nil
+}
+
+type marcWriter struct {
+	mw *marc.Writer
+}
+
+func (m *marcWriter) Write(book models.Book) error {
+	return m.mw.Write(marc.FromBook(book))
+}
+
+func (m *marcWriter) Close() error {
+	return nil
+}
+
+type marcXMLWriter struct {
+	xw *marc.XMLWriter
+}
+
+func (m *marcXMLWriter) Write(book models.Book) error {
+	return m.xw.Write(marc.FromBook(book))
+}
+
+func (m *marcXMLWriter) Close() error {
+	return m.xw.Close()
+}
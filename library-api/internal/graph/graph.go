@@ -0,0 +1,78 @@
+// Package graph implementa o endpoint /graphql sobre livros, autores e
+// empréstimos. As consultas usam loaders por requisição para buscar as
+// relações em lote; as mutações passam pelas mesmas regras da API REST
+// (internal/service).
+package graph
+
+import (
+	"context"
+	"library-api/internal/service"
+
+	"github.com/graphql-go/graphql"
+	"gorm.io/gorm"
+)
+
+// Request é o corpo de POST /graphql
+type Request struct {
+	Query         string                 `json:"query"`
+	OperationName string                 `json:"operationName"`
+	Variables     map[string]interface{} `json:"variables"`
+}
+
+type dbKey struct{}
+
+// Execute roda a consulta com a conexão informada, que já deve levar o
+// contexto da requisição
+func Execute(ctx context.Context, db *gorm.DB, req Request) *graphql.Result {
+	ctx = context.WithValue(ctx, dbKey{}, db)
+	ctx = context.WithValue(ctx, loadersKey{}, newLoaders(db))
+
+	return graphql.Do(graphql.Params{
+		Schema:         schema,
+		RequestString:  req.Query,
+		OperationName:  req.OperationName,
+		VariableValues: req.Variables,
+		Context:        ctx,
+	})
+}
+
+func dbFrom(ctx context.Context) *gorm.DB {
+	return ctx.Value(dbKey{}).(*gorm.DB)
+}
+
+// Códigos de erro, em extensions.code
+const (
+	CodeBadInput = "BAD_USER_INPUT"
+	CodeNotFound = "NOT_FOUND"
+	CodeConflict = "CONFLICT"
+	CodeInternal = "INTERNAL_SERVER_ERROR"
+)
+
+// Error é um erro de resolução com o código em extensions.code
+type Error struct {
+	Code    string
+	Message string
+}
+
+func (e *Error) Error() string {
+	return e.Message
+}
+
+// Extensions implementa gqlerrors.ExtendedError
+func (e *Error) Extensions() map[string]interface{} {
+	return map[string]interface{}{"code": e.Code}
+}
+
+// fail traduz um erro do serviço para o código correspondente
+func fail(err error) error {
+	code := CodeInternal
+	switch service.KindOf(err) {
+	case service.Invalid:
+		code = CodeBadInput
+	case service.NotFound:
+		code = CodeNotFound
+	case service.Conflict:
+		code = CodeConflict
+	}
+	return &Error{Code: code, Message: err.Error()}
+}
@@ -0,0 +1,200 @@
+package graph
+
+import (
+	"context"
+	"library-api/internal/models"
+	"sync"
+
+	"gorm.io/gorm"
+)
+
+// loader junta os IDs pedidos num mesmo nível da consulta e os busca de
+// uma vez, quando o primeiro resultado é lido. O GraphQL resolve os campos
+// em largura: os autores de todos os livros de uma lista são pedidos antes
+// de qualquer um ser lido, e saem numa única consulta.
+type loader[V any] struct {
+	fetch func(ids []uint) (map[uint]V, error)
+
+	mu      sync.Mutex
+	pending []uint
+	done    map[uint]bool
+	results map[uint]V
+	errs    map[uint]error
+}
+
+func newLoader[V any](fetch func(ids []uint) (map[uint]V, error)) *loader[V] {
+	return &loader[V]{fetch: fetch, done: map[uint]bool{}, results: map[uint]V{}, errs: map[uint]error{}}
+}
+
+// load agenda o ID e devolve a função que o GraphQL chama para ler o
+// resultado
+func (l *loader[V]) load(id uint) func() (V, error) {
+	l.mu.Lock()
+	if !l.done[id] {
+		l.done[id] = true
+		l.pending = append(l.pending, id)
+	}
+	l.mu.Unlock()
+
+	return func() (V, error) {
+		l.mu.Lock()
+		defer l.mu.Unlock()
+
+		if len(l.pending) > 0 {
+			batch := l.pending
+			l.pending = nil
+			found, err := l.fetch(batch)
+			for _, key := range batch {
+				if err != nil {
+					l.errs[key] = err
+				} else {
+					l.results[key] = found[key]
+				}
+			}
+		}
+		return l.results[id], l.errs[id]
+	}
+}
+
+// loaders são os loaders de uma requisição; o cache não passa de uma
+// requisição para outra
+type loaders struct {
+	book            *loader[models.Book]
+	authorsByBook   *loader[[]models.Author]
+	booksByAuthor   *loader[[]models.Book]
+	aliasesByAuthor *loader[[]models.AuthorAlias]
+}
+
+type loadersKey struct{}
+
+func newLoaders(db *gorm.DB) *loaders {
+	return &loaders{
+		book:            newLoader(func(ids []uint) (map[uint]models.Book, error) { return fetchBooks(db, ids) }),
+		authorsByBook:   newLoader(func(ids []uint) (map[uint][]models.Author, error) { return fetchAuthorsByBook(db, ids) }),
+		booksByAuthor:   newLoader(func(ids []uint) (map[uint][]models.Book, error) { return fetchBooksByAuthor(db, ids) }),
+		aliasesByAuthor: newLoader(func(ids []uint) (map[uint][]models.AuthorAlias, error) { return fetchAliases(db, ids) }),
+	}
+}
+
+func loadersFrom(ctx context.Context) *loaders {
+	return ctx.Value(loadersKey{}).(*loaders)
+}
+
+// credit é uma linha de book_authors
+type credit struct {
+	BookID   uint
+	AuthorID uint
+	Role     string
+	Position int
+}
+
+// fetchBooks busca os livros pelo ID, inclusive os removidos, que ainda
+// aparecem nos empréstimos antigos
+func fetchBooks(db *gorm.DB, ids []uint) (map[uint]models.Book, error) {
+	var books []models.Book
+	if err := db.Unscoped().Find(&books, ids).Error; err != nil {
+		return nil, err
+	}
+	result := make(map[uint]models.Book, len(books))
+	for _, book := range books {
+		result[book.ID] = book
+	}
+	return result, nil
+}
+
+// fetchAuthorsByBook busca os autores de cada livro, com papel e na ordem
+// dos créditos
+func fetchAuthorsByBook(db *gorm.DB, bookIDs []uint) (map[uint][]models.Author, error) {
+	var credits []credit
+	err := db.Model(&models.BookAuthor{}).Where("book_id IN ?", bookIDs).
+		Order("book_id, position").Find(&credits).Error
+	if err != nil {
+		return nil, err
+	}
+
+	ids := make([]uint, 0, len(credits))
+	seen := map[uint]bool{}
+	for _, c := range credits {
+		if !seen[c.AuthorID] {
+			seen[c.AuthorID] = true
+			ids = append(ids, c.AuthorID)
+		}
+	}
+	if len(ids) == 0 {
+		return nil, nil
+	}
+	var authors []models.Author
+	if err := db.Find(&authors, ids).Error; err != nil {
+		return nil, err
+	}
+	byID := make(map[uint]models.Author, len(authors))
+	for _, author := range authors {
+		byID[author.ID] = author
+	}
+
+	result := make(map[uint][]models.Author, len(bookIDs))
+	for _, c := range credits {
+		author, ok := byID[c.AuthorID]
+		if !ok {
+			continue
+		}
+		author.Role, author.Position = c.Role, c.Position
+		result[c.BookID] = append(result[c.BookID], author)
+	}
+	return result, nil
+}
+
+// fetchBooksByAuthor busca os livros de cada autor, com o papel dele em
+// cada um
+func fetchBooksByAuthor(db *gorm.DB, authorIDs []uint) (map[uint][]models.Book, error) {
+	var credits []credit
+	err := db.Model(&models.BookAuthor{}).Where("author_id IN ?", authorIDs).
+		Order("author_id, book_id").Find(&credits).Error
+	if err != nil {
+		return nil, err
+	}
+
+	ids := make([]uint, 0, len(credits))
+	seen := map[uint]bool{}
+	for _, c := range credits {
+		if !seen[c.BookID] {
+			seen[c.BookID] = true
+			ids = append(ids, c.BookID)
+		}
+	}
+	if len(ids) == 0 {
+		return nil, nil
+	}
+	var books []models.Book
+	if err := db.Find(&books, ids).Error; err != nil {
+		return nil, err
+	}
+	byID := make(map[uint]models.Book, len(books))
+	for _, book := range books {
+		byID[book.ID] = book
+	}
+
+	result := make(map[uint][]models.Book, len(authorIDs))
+	for _, c := range credits {
+		book, ok := byID[c.BookID]
+		if !ok {
+			continue
+		}
+		book.Role, book.Position = c.Role, c.Position
+		result[c.AuthorID] = append(result[c.AuthorID], book)
+	}
+	return result, nil
+}
+
+// fetchAliases busca os nomes alternativos de cada autor
+func fetchAliases(db *gorm.DB, authorIDs []uint) (map[uint][]models.AuthorAlias, error) {
+	var aliases []models.AuthorAlias
+	if err := db.Where("author_id IN ?", authorIDs).Order("id").Find(&aliases).Error; err != nil {
+		return nil, err
+	}
+	result := make(map[uint][]models.AuthorAlias, len(authorIDs))
+	for _, alias := range aliases {
+		result[alias.AuthorID] = append(result[alias.AuthorID], alias)
+	}
+	return result, nil
+}
@@ -0,0 +1,315 @@
+package graph
+
+import (
+	"library-api/internal/models"
+	"library-api/internal/service"
+	"time"
+
+	"github.com/graphql-go/graphql"
+	"gorm.io/gorm"
+)
+
+var creditInput = graphql.NewInputObject(graphql.InputObjectConfig{
+	Name: "CreditInput",
+	Fields: graphql.InputObjectConfigFieldMap{
+		"authorId": &graphql.InputObjectFieldConfig{Type: graphql.NewNonNull(graphql.ID)},
+		"role":     &graphql.InputObjectFieldConfig{Type: graphql.String, Description: "author (padrão), editor, translator ou illustrator"},
+	},
+})
+
+var bookInput = graphql.NewInputObject(graphql.InputObjectConfig{
+	Name:        "BookInput",
+	Description: "Dados de um livro. Na atualização, só os campos enviados mudam; credits (ou authorIds) e subjectIds substituem os atuais.",
+	Fields: graphql.InputObjectConfigFieldMap{
+		"title":           &graphql.InputObjectFieldConfig{Type: graphql.String},
+		"isbn":            &graphql.InputObjectFieldConfig{Type: graphql.String},
+		"available":       &graphql.InputObjectFieldConfig{Type: graphql.Boolean},
+		"publisher":       &graphql.InputObjectFieldConfig{Type: graphql.String},
+		"publicationYear": &graphql.InputObjectFieldConfig{Type: graphql.Int},
+		"edition":         &graphql.InputObjectFieldConfig{Type: graphql.String},
+		"language":        &graphql.InputObjectFieldConfig{Type: graphql.String},
+		"pages":           &graphql.InputObjectFieldConfig{Type: graphql.Int},
+		"description":     &graphql.InputObjectFieldConfig{Type: graphql.String},
+		"coverUrl":        &graphql.InputObjectFieldConfig{Type: graphql.String},
+		"seriesId":        &graphql.InputObjectFieldConfig{Type: graphql.ID},
+		"seriesVolume":    &graphql.InputObjectFieldConfig{Type: graphql.Int},
+		"credits":         &graphql.InputObjectFieldConfig{Type: graphql.NewList(graphql.NewNonNull(creditInput))},
+		"authorIds":       &graphql.InputObjectFieldConfig{Type: graphql.NewList(graphql.NewNonNull(graphql.ID))},
+		"subjectIds":      &graphql.InputObjectFieldConfig{Type: graphql.NewList(graphql.NewNonNull(graphql.ID))},
+	},
+})
+
+var aliasInput = graphql.NewInputObject(graphql.InputObjectConfig{
+	Name: "AliasInput",
+	Fields: graphql.InputObjectConfigFieldMap{
+		"name": &graphql.InputObjectFieldConfig{Type: graphql.NewNonNull(graphql.String)},
+		"kind": &graphql.InputObjectFieldConfig{Type: graphql.String, Description: "alias (padrão) ou pseudonym"},
+	},
+})
+
+var authorInput = graphql.NewInputObject(graphql.InputObjectConfig{
+	Name:        "AuthorInput",
+	Description: "Dados de um autor. Na atualização, só os campos enviados mudam; aliases substitui todos os nomes alternativos.",
+	Fields: graphql.InputObjectConfigFieldMap{
+		"name":        &graphql.InputObjectFieldConfig{Type: graphql.String},
+		"sortName":    &graphql.InputObjectFieldConfig{Type: graphql.String},
+		"bio":         &graphql.InputObjectFieldConfig{Type: graphql.String},
+		"birthYear":   &graphql.InputObjectFieldConfig{Type: graphql.Int},
+		"deathYear":   &graphql.InputObjectFieldConfig{Type: graphql.Int},
+		"nationality": &graphql.InputObjectFieldConfig{Type: graphql.String},
+		"orcid":       &graphql.InputObjectFieldConfig{Type: graphql.String},
+		"viaf":        &graphql.InputObjectFieldConfig{Type: graphql.String},
+		"isni":        &graphql.InputObjectFieldConfig{Type: graphql.String},
+		"aliases":     &graphql.InputObjectFieldConfig{Type: graphql.NewList(graphql.NewNonNull(aliasInput))},
+	},
+})
+
+var loanInput = graphql.NewInputObject(graphql.InputObjectConfig{
+	Name: "LoanInput",
+	Fields: graphql.InputObjectConfigFieldMap{
+		"bookId":   &graphql.InputObjectFieldConfig{Type: graphql.NewNonNull(graphql.ID)},
+		"userName": &graphql.InputObjectFieldConfig{Type: graphql.NewNonNull(graphql.String)},
+		"dueDate":  &graphql.InputObjectFieldConfig{Type: graphql.DateTime, Description: "Prazo de devolução (padrão: 14 dias)"},
+	},
+})
+
+func mutationType() *graphql.Object {
+	idArg := func() graphql.FieldConfigArgument {
+		return graphql.FieldConfigArgument{"id": &graphql.ArgumentConfig{Type: graphql.NewNonNull(graphql.ID)}}
+	}
+	withInput := func(input *graphql.InputObject) graphql.FieldConfigArgument {
+		args := idArg()
+		args["input"] = &graphql.ArgumentConfig{Type: graphql.NewNonNull(input)}
+		return args
+	}
+	inputOnly := func(input *graphql.InputObject) graphql.FieldConfigArgument {
+		return graphql.FieldConfigArgument{"input": &graphql.ArgumentConfig{Type: graphql.NewNonNull(input)}}
+	}
+
+	return graphql.NewObject(graphql.ObjectConfig{
+		Name: "Mutation",
+		Fields: graphql.Fields{
+			"createBook": &graphql.Field{
+				Type: graphql.NewNonNull(bookType),
+				Args: inputOnly(bookInput),
+				Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+					input, err := bookFromInput(p.Args["input"])
+					if err != nil {
+						return nil, err
+					}
+					return result(service.CreateBook(dbFrom(p.Context), input, nil))
+				},
+			},
+			"updateBook": &graphql.Field{
+				Type: graphql.NewNonNull(bookType),
+				Args: withInput(bookInput),
+				Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+					id, err := parseID(p.Args["id"])
+					if err != nil {
+						return nil, err
+					}
+					input, err := bookFromInput(p.Args["input"])
+					if err != nil {
+						return nil, err
+					}
+					available := boolArg(p.Args["input"].(map[string]interface{}), "available")
+					return result(service.UpdateBook(dbFrom(p.Context), id, input, available))
+				},
+			},
+			"deleteBook": &graphql.Field{
+				Type: graphql.NewNonNull(graphql.Boolean),
+				Args: idArg(),
+				Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+					return remove(p, service.DeleteBook)
+				},
+			},
+			"createAuthor": &graphql.Field{
+				Type: graphql.NewNonNull(authorType),
+				Args: inputOnly(authorInput),
+				Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+					return result(service.CreateAuthor(dbFrom(p.Context), authorFromInput(p.Args["input"])))
+				},
+			},
+			"updateAuthor": &graphql.Field{
+				Type: graphql.NewNonNull(authorType),
+				Args: withInput(authorInput),
+				Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+					id, err := parseID(p.Args["id"])
+					if err != nil {
+						return nil, err
+					}
+					return result(service.UpdateAuthor(dbFrom(p.Context), id, authorFromInput(p.Args["input"])))
+				},
+			},
+			"deleteAuthor": &graphql.Field{
+				Type: graphql.NewNonNull(graphql.Boolean),
+				Args: idArg(),
+				Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+					return remove(p, service.DeleteAuthor)
+				},
+			},
+			"createLoan": &graphql.Field{
+				Type:        graphql.NewNonNull(loanType),
+				Description: "Empresta o livro, se estiver disponível",
+				Args:        inputOnly(loanInput),
+				Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+					input := p.Args["input"].(map[string]interface{})
+					bookID, err := parseID(input["bookId"])
+					if err != nil {
+						return nil, err
+					}
+					loan := models.Loan{BookID: bookID, UserName: input["userName"].(string)}
+					if due, ok := input["dueDate"].(time.Time); ok {
+						loan.DueDate = due
+					}
+					return result(service.CreateLoan(dbFrom(p.Context), loan))
+				},
+			},
+			"returnLoan": &graphql.Field{
+				Type: graphql.NewNonNull(loanType),
+				Args: idArg(),
+				Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+					id, err := parseID(p.Args["id"])
+					if err != nil {
+						return nil, err
+					}
+					return result(service.ReturnLoan(dbFrom(p.Context), id))
+				},
+			},
+			"deleteLoan": &graphql.Field{
+				Type: graphql.NewNonNull(graphql.Boolean),
+				Args: idArg(),
+				Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+					return remove(p, service.DeleteLoan)
+				},
+			},
+		},
+	})
+}
+
+// result devolve o registro ou o erro traduzido
+func result[T any](record T, err error) (interface{}, error) {
+	if err != nil {
+		return nil, fail(err)
+	}
+	return record, nil
+}
+
+// remove chama a remoção com o argumento id
+func remove(p graphql.ResolveParams, del func(db *gorm.DB, id uint) error) (interface{}, error) {
+	id, err := parseID(p.Args["id"])
+	if err != nil {
+		return nil, err
+	}
+	if err := del(dbFrom(p.Context), id); err != nil {
+		return nil, fail(err)
+	}
+	return true, nil
+}
+
+// bookFromInput converte BookInput no livro recebido pelo serviço
+func bookFromInput(value interface{}) (models.Book, error) {
+	input := value.(map[string]interface{})
+	book := models.Book{
+		Title:           stringArg(input, "title"),
+		ISBN:            stringArg(input, "isbn"),
+		Publisher:       stringArg(input, "publisher"),
+		PublicationYear: intArg(input, "publicationYear"),
+		Edition:         stringArg(input, "edition"),
+		Language:        stringArg(input, "language"),
+		Pages:           intArg(input, "pages"),
+		Description:     stringArg(input, "description"),
+		CoverURL:        stringArg(input, "coverUrl"),
+		SeriesVolume:    intArg(input, "seriesVolume"),
+	}
+	if available, ok := input["available"].(bool); ok {
+		book.Available = available
+	}
+	if v, ok := input["seriesId"]; ok && v != nil {
+		id, err := parseID(v)
+		if err != nil {
+			return book, err
+		}
+		book.SeriesID = &id
+	}
+
+	if list, ok := input["credits"].([]interface{}); ok {
+		book.Credits = []models.AuthorCredit{}
+		for _, item := range list {
+			credit := item.(map[string]interface{})
+			id, err := parseID(credit["authorId"])
+			if err != nil {
+				return book, err
+			}
+			book.Credits = append(book.Credits, models.AuthorCredit{AuthorID: id, Role: stringArg(credit, "role")})
+		}
+	}
+	for _, field := range []struct {
+		name string
+		ids  *[]uint
+	}{{"authorIds", &book.AuthorIDs}, {"subjectIds", &book.SubjectIDs}} {
+		list, ok := input[field.name].([]interface{})
+		if !ok {
+			continue
+		}
+		*field.ids = []uint{}
+		for _, item := range list {
+			id, err := parseID(item)
+			if err != nil {
+				return book, err
+			}
+			*field.ids = append(*field.ids, id)
+		}
+	}
+	return book, nil
+}
+
+// authorFromInput converte AuthorInput no autor recebido pelo serviço
+func authorFromInput(value interface{}) models.Author {
+	input := value.(map[string]interface{})
+	author := models.Author{
+		Name:        stringArg(input, "name"),
+		SortName:    stringArg(input, "sortName"),
+		Bio:         stringArg(input, "bio"),
+		Nationality: stringArg(input, "nationality"),
+	}
+	if v, ok := input["birthYear"].(int); ok {
+		author.BirthYear = &v
+	}
+	if v, ok := input["deathYear"].(int); ok {
+		author.DeathYear = &v
+	}
+	for field, target := range map[string]**string{"orcid": &author.ORCID, "viaf": &author.VIAF, "isni": &author.ISNI} {
+		if v, ok := input[field].(string); ok {
+			*target = &v
+		}
+	}
+	if list, ok := input["aliases"].([]interface{}); ok {
+		author.Aliases = []models.AuthorAlias{}
+		for _, item := range list {
+			alias := item.(map[string]interface{})
+			author.Aliases = append(author.Aliases, models.AuthorAlias{Name: stringArg(alias, "name"), Kind: stringArg(alias, "kind")})
+		}
+	}
+	return author
+}
+
+func stringArg(args map[string]interface{}, name string) string {
+	s, _ := args[name].(string)
+	return s
+}
+
+func intArg(args map[string]interface{}, name string) int {
+	n, _ := args[name].(int)
+	return n
+}
+
+// boolArg devolve nil se o argumento não foi enviado, para que false não
+// se confunda com a ausência
+func boolArg(args map[string]interface{}, name string) *bool {
+	b, ok := args[name].(bool)
+	if !ok {
+		return nil
+	}
+	return &b
+}
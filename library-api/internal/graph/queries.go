@@ -0,0 +1,185 @@
+package graph
+
+import (
+	"errors"
+	"library-api/internal/models"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/graphql-go/graphql"
+	"gorm.io/gorm"
+)
+
+// Limites de paginação, os mesmos da API REST
+const (
+	defaultPageSize = 20
+	maxPageSize     = 100
+)
+
+// pageArgs são os argumentos de paginação das listagens
+func pageArgs(extra graphql.FieldConfigArgument) graphql.FieldConfigArgument {
+	extra["page"] = &graphql.ArgumentConfig{Type: graphql.Int, DefaultValue: 1, Description: "Página, a partir de 1"}
+	extra["pageSize"] = &graphql.ArgumentConfig{Type: graphql.Int, DefaultValue: defaultPageSize, Description: "Itens por página (máximo 100)"}
+	return extra
+}
+
+func queryType() *graphql.Object {
+	idArg := graphql.FieldConfigArgument{"id": &graphql.ArgumentConfig{Type: graphql.NewNonNull(graphql.ID)}}
+
+	return graphql.NewObject(graphql.ObjectConfig{
+		Name: "Query",
+		Fields: graphql.Fields{
+			"book": &graphql.Field{
+				Type: bookType,
+				Args: idArg,
+				Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+					return find[models.Book](p, "Book not found")
+				},
+			},
+			"books": &graphql.Field{
+				Type: graphql.NewNonNull(pageType("BookPage", bookType)),
+				Args: pageArgs(graphql.FieldConfigArgument{
+					"title":     &graphql.ArgumentConfig{Type: graphql.String, Description: "Parte do título"},
+					"isbn":      &graphql.ArgumentConfig{Type: graphql.String},
+					"available": &graphql.ArgumentConfig{Type: graphql.Boolean},
+					"authorId":  &graphql.ArgumentConfig{Type: graphql.ID},
+				}),
+				Resolve: resolveBooks,
+			},
+			"author": &graphql.Field{
+				Type: authorType,
+				Args: idArg,
+				Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+					return find[models.Author](p, "Author not found")
+				},
+			},
+			"authors": &graphql.Field{
+				Type: graphql.NewNonNull(pageType("AuthorPage", authorType)),
+				Args: pageArgs(graphql.FieldConfigArgument{
+					"q": &graphql.ArgumentConfig{Type: graphql.String, Description: "Parte do nome, da forma de ordenação ou de um nome alternativo"},
+				}),
+				Resolve: resolveAuthors,
+			},
+			"loan": &graphql.Field{
+				Type: loanType,
+				Args: idArg,
+				Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+					return find[models.Loan](p, "Loan not found")
+				},
+			},
+			"loans": &graphql.Field{
+				Type: graphql.NewNonNull(pageType("LoanPage", loanType)),
+				Args: pageArgs(graphql.FieldConfigArgument{
+					"userName": &graphql.ArgumentConfig{Type: graphql.String, Description: "Nome do leitor (sem diferenciar maiúsculas)"},
+					"status":   &graphql.ArgumentConfig{Type: loanStatusEnum},
+				}),
+				Resolve: resolveLoans,
+			},
+		},
+	})
+}
+
+// find busca o registro pelo argumento id; um ID que não existe resolve
+// como null, com o erro NOT_FOUND
+func find[T any](p graphql.ResolveParams, notFound string) (interface{}, error) {
+	id, err := parseID(p.Args["id"])
+	if err != nil {
+		return nil, err
+	}
+
+	var record T
+	if err := dbFrom(p.Context).First(&record, id).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, &Error{Code: CodeNotFound, Message: notFound}
+		}
+		return nil, fail(err)
+	}
+	return record, nil
+}
+
+func resolveBooks(p graphql.ResolveParams) (interface{}, error) {
+	query := dbFrom(p.Context).Model(&models.Book{})
+
+	if title, _ := p.Args["title"].(string); title != "" {
+		query = query.Where("books.title LIKE ?", "%"+title+"%")
+	}
+	if isbn, _ := p.Args["isbn"].(string); isbn != "" {
+		query = query.Where("books.isbn = ?", isbn)
+	}
+	if available, ok := p.Args["available"].(bool); ok {
+		query = query.Where("books.available = ?", available)
+	}
+	if v, ok := p.Args["authorId"]; ok {
+		authorID, err := parseID(v)
+		if err != nil {
+			return nil, err
+		}
+		query = query.Where("books.id IN (?)", dbFrom(p.Context).Table("book_authors").
+			Select("book_id").Where("author_id = ?", authorID))
+	}
+
+	return paginate[models.Book](p, query, "books.id")
+}
+
+func resolveAuthors(p graphql.ResolveParams) (interface{}, error) {
+	query := dbFrom(p.Context).Model(&models.Author{})
+
+	if q, _ := p.Args["q"].(string); strings.TrimSpace(q) != "" {
+		pattern := "%" + strings.ToLower(strings.TrimSpace(q)) + "%"
+		query = query.Where("LOWER(name) LIKE ? OR LOWER(sort_name) LIKE ? OR id IN (?)", pattern, pattern,
+			dbFrom(p.Context).Model(&models.AuthorAlias{}).Select("author_id").Where("LOWER(name) LIKE ?", pattern))
+	}
+
+	return paginate[models.Author](p, query, "id")
+}
+
+func resolveLoans(p graphql.ResolveParams) (interface{}, error) {
+	query := dbFrom(p.Context).Model(&models.Loan{})
+
+	if userName, _ := p.Args["userName"].(string); strings.TrimSpace(userName) != "" {
+		query = query.Where("LOWER(user_name) = ?", strings.ToLower(strings.TrimSpace(userName)))
+	}
+
+	switch p.Args["status"] {
+	case "active":
+		query = query.Where("return_date IS NULL")
+	case "returned":
+		query = query.Where("return_date IS NOT NULL")
+	case "overdue":
+		query = query.Where("return_date IS NULL AND julianday(due_date) < julianday(?)", time.Now())
+	}
+
+	return paginate[models.Loan](p, query, "id DESC")
+}
+
+// paginate conta e busca, na ordem informada, a página pedida em page e
+// pageSize
+func paginate[T any](p graphql.ResolveParams, query *gorm.DB, order string) (interface{}, error) {
+	result := page[T]{Page: p.Args["page"].(int), PageSize: p.Args["pageSize"].(int), Items: []T{}}
+	if result.Page < 1 {
+		return nil, &Error{Code: CodeBadInput, Message: "page must be a positive number"}
+	}
+	if result.PageSize < 1 || result.PageSize > maxPageSize {
+		return nil, &Error{Code: CodeBadInput, Message: "pageSize must be between 1 and 100"}
+	}
+
+	if err := query.Count(&result.Total).Error; err != nil {
+		return nil, fail(err)
+	}
+	err := query.Order(order).Offset((result.Page - 1) * result.PageSize).Limit(result.PageSize).Find(&result.Items).Error
+	if err != nil {
+		return nil, fail(err)
+	}
+	return result, nil
+}
+
+// parseID lê um argumento do tipo ID
+func parseID(value interface{}) (uint, error) {
+	s, _ := value.(string)
+	id, err := strconv.ParseUint(s, 10, 64)
+	if err != nil || id == 0 {
+		return 0, &Error{Code: CodeBadInput, Message: "Invalid id " + strconv.Quote(s)}
+	}
+	return uint(id), nil
+}
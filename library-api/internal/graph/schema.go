@@ -0,0 +1,181 @@
+package graph
+
+import (
+	"library-api/internal/models"
+	"time"
+
+	"github.com/graphql-go/graphql"
+)
+
+// Tipos do schema. São montados em init porque Book e Author se
+// referenciam.
+var (
+	bookType   *graphql.Object
+	authorType *graphql.Object
+	aliasType  *graphql.Object
+	loanType   *graphql.Object
+	schema     graphql.Schema
+)
+
+// page é uma página de uma listagem
+type page[T any] struct {
+	Page     int   `json:"page"`
+	PageSize int   `json:"pageSize"`
+	Total    int64 `json:"total"`
+	Items    []T   `json:"items"`
+}
+
+var loanStatusEnum = graphql.NewEnum(graphql.EnumConfig{
+	Name: "LoanStatus",
+	Values: graphql.EnumValueConfigMap{
+		"ACTIVE":   &graphql.EnumValueConfig{Value: "active", Description: "Em aberto"},
+		"RETURNED": &graphql.EnumValueConfig{Value: "returned", Description: "Devolvido"},
+		"OVERDUE":  &graphql.EnumValueConfig{Value: "overdue", Description: "Em aberto e atrasado"},
+	},
+})
+
+func init() {
+	aliasType = graphql.NewObject(graphql.ObjectConfig{
+		Name:        "AuthorAlias",
+		Description: "Outro nome pelo qual o autor é conhecido",
+		Fields: graphql.Fields{
+			"id":   &graphql.Field{Type: graphql.NewNonNull(graphql.ID)},
+			"name": &graphql.Field{Type: graphql.NewNonNull(graphql.String)},
+			"kind": &graphql.Field{Type: graphql.NewNonNull(graphql.String), Description: "alias ou pseudonym"},
+		},
+	})
+
+	bookType = graphql.NewObject(graphql.ObjectConfig{
+		Name: "Book",
+		Fields: graphql.FieldsThunk(func() graphql.Fields {
+			return graphql.Fields{
+				"id":              &graphql.Field{Type: graphql.NewNonNull(graphql.ID)},
+				"title":           &graphql.Field{Type: graphql.NewNonNull(graphql.String)},
+				"isbn":            &graphql.Field{Type: graphql.String},
+				"available":       &graphql.Field{Type: graphql.NewNonNull(graphql.Boolean)},
+				"publisher":       &graphql.Field{Type: graphql.String},
+				"publicationYear": &graphql.Field{Type: graphql.Int},
+				"edition":         &graphql.Field{Type: graphql.String},
+				"language":        &graphql.Field{Type: graphql.String, Description: "Código ISO 639"},
+				"pages":           &graphql.Field{Type: graphql.Int},
+				"description":     &graphql.Field{Type: graphql.String},
+				"coverUrl":        &graphql.Field{Type: graphql.String},
+				"seriesVolume":    &graphql.Field{Type: graphql.Int},
+				"role":            &graphql.Field{Type: graphql.String, Description: "Papel do autor no livro, em Author.books"},
+				"createdAt":       &graphql.Field{Type: graphql.NewNonNull(graphql.DateTime)},
+				"updatedAt":       &graphql.Field{Type: graphql.NewNonNull(graphql.DateTime)},
+				"authors": &graphql.Field{
+					Type:        listOf(authorType),
+					Description: "Autores na ordem dos créditos, com o papel de cada um",
+					Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+						return thunk(loadersFrom(p.Context).authorsByBook.load(p.Source.(models.Book).ID)), nil
+					},
+				},
+			}
+		}),
+	})
+
+	authorType = graphql.NewObject(graphql.ObjectConfig{
+		Name: "Author",
+		Fields: graphql.FieldsThunk(func() graphql.Fields {
+			return graphql.Fields{
+				"id":          &graphql.Field{Type: graphql.NewNonNull(graphql.ID)},
+				"name":        &graphql.Field{Type: graphql.NewNonNull(graphql.String)},
+				"sortName":    &graphql.Field{Type: graphql.String},
+				"bio":         &graphql.Field{Type: graphql.String},
+				"birthYear":   &graphql.Field{Type: graphql.Int},
+				"deathYear":   &graphql.Field{Type: graphql.Int},
+				"nationality": &graphql.Field{Type: graphql.String},
+				"orcid":       &graphql.Field{Type: graphql.String},
+				"viaf":        &graphql.Field{Type: graphql.String},
+				"isni":        &graphql.Field{Type: graphql.String},
+				"role":        &graphql.Field{Type: graphql.String, Description: "Papel no livro, em Book.authors"},
+				"createdAt":   &graphql.Field{Type: graphql.NewNonNull(graphql.DateTime)},
+				"updatedAt":   &graphql.Field{Type: graphql.NewNonNull(graphql.DateTime)},
+				"aliases": &graphql.Field{
+					Type: listOf(aliasType),
+					Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+						return thunk(loadersFrom(p.Context).aliasesByAuthor.load(p.Source.(models.Author).ID)), nil
+					},
+				},
+				"books": &graphql.Field{
+					Type:        listOf(bookType),
+					Description: "Livros do autor, com o papel dele em cada um",
+					Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+						return thunk(loadersFrom(p.Context).booksByAuthor.load(p.Source.(models.Author).ID)), nil
+					},
+				},
+			}
+		}),
+	})
+
+	loanType = graphql.NewObject(graphql.ObjectConfig{
+		Name: "Loan",
+		Fields: graphql.Fields{
+			"id":         &graphql.Field{Type: graphql.NewNonNull(graphql.ID)},
+			"userName":   &graphql.Field{Type: graphql.NewNonNull(graphql.String)},
+			"loanDate":   &graphql.Field{Type: graphql.NewNonNull(graphql.DateTime)},
+			"dueDate":    &graphql.Field{Type: graphql.DateTime},
+			"returnDate": &graphql.Field{Type: graphql.DateTime},
+			"overdue": &graphql.Field{
+				Type:        graphql.NewNonNull(graphql.Boolean),
+				Description: "Ainda não devolvido e com prazo vencido",
+				Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+					return p.Source.(models.Loan).IsOverdue(time.Now()), nil
+				},
+			},
+			"book": &graphql.Field{
+				Type: graphql.NewNonNull(bookType),
+				Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+					get := loadersFrom(p.Context).book.load(p.Source.(models.Loan).BookID)
+					return func() (interface{}, error) {
+						book, err := get()
+						if err == nil && book.ID == 0 {
+							err = &Error{Code: CodeNotFound, Message: "Book not found"}
+						}
+						return book, err
+					}, nil
+				},
+			},
+		},
+	})
+
+	var err error
+	schema, err = graphql.NewSchema(graphql.SchemaConfig{
+		Query:    queryType(),
+		Mutation: mutationType(),
+	})
+	if err != nil {
+		panic(err)
+	}
+}
+
+// pageType é o tipo de uma página de itens do tipo informado
+func pageType(name string, item *graphql.Object) *graphql.Object {
+	return graphql.NewObject(graphql.ObjectConfig{
+		Name: name,
+		Fields: graphql.Fields{
+			"page":     &graphql.Field{Type: graphql.NewNonNull(graphql.Int)},
+			"pageSize": &graphql.Field{Type: graphql.NewNonNull(graphql.Int)},
+			"total":    &graphql.Field{Type: graphql.NewNonNull(graphql.Int)},
+			"items":    &graphql.Field{Type: listOf(item)},
+		},
+	})
+}
+
+// listOf é uma lista não nula de itens não nulos
+func listOf(item graphql.Type) graphql.Output {
+	return graphql.NewNonNull(graphql.NewList(graphql.NewNonNull(item)))
+}
+
+// thunk adapta o resultado de um loader à forma que o GraphQL espera para
+// resolver o campo depois
+func thunk[V any](get func() (V, error)) func() (interface{}, error) {
+	return func() (interface{}, error) {
+		value, err := get()
+		if err != nil {
+			return nil, err
+		}
+		return value, nil
+	}
+}
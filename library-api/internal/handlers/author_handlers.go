@@ -1,8 +1,8 @@
 package handlers
 
 import (
-	"library-api/internal/database"
 	"library-api/internal/models"
+	"library-api/internal/service"
 	"net/http"
 	"strconv"
 
@@ -10,36 +10,106 @@ import (
 )
 
 // GetAuthors godoc
-// @Summary Lista todos os autores
+// @Description Com q, busca no nome, na forma de ordenação e nos nomes alternativos (aliases e pseudônimos)
 // @Tags authors
 // @Produce json
+// @Param q query string false "Parte do nome, da forma de ordenação ou de um nome alternativo"
+// @Param nationality query string false "Nacionalidade"
 // @Success 200 {array} models.Author
 // @Router /authors [get]
 func GetAuthors(c *gin.Context) {
-	var authors []models.Author
-	database.DB.Preload("Books").Find(&authors) // já traz livros
+	authors, err := service.ListAuthors(requestDB(c), service.AuthorFilter{
+		Query:       c.Query("q"),
+		Nationality: c.Query("nationality"),
+	})
+	if err != nil {
+		serviceError(c, err)
+		return
+	}
 	c.JSON(http.StatusOK, authors)
 }
 
+// LookupAuthor godoc
+// @Summary Busca um autor por identificador externo
+// @Description Informe apenas um entre orcid, viaf e isni; aceita também a URL do registro
+// @Tags authors
+// @Produce json
+// @Param orcid query string false "ORCID, como 0000-0002-1825-0097"
+// @Param viaf query string false "Número VIAF"
+// @Param isni query string false "ISNI, com ou sem espaços"
+// @Success 200 {object} models.Author
+// @Failure 400 {object} map[string]string
+// @Failure 404 {object} map[string]string
+// @Router /authors/lookup [get]
+func LookupAuthor(c *gin.Context) {
+	var lookup models.Author
+	given := 0
+	if v := c.Query("orcid"); v != "" {
+		lookup.ORCID = &v
+		given++
+	}
+	if v := c.Query("viaf"); v != "" {
+		lookup.VIAF = &v
+		given++
+	}
+	if v := c.Query("isni"); v != "" {
+		lookup.ISNI = &v
+		given++
+	}
+	if given != 1 {
+		errorJSON(c, http.StatusBadRequest, "Provide exactly one of orcid, viaf or isni")
+		return
+	}
+	if err := service.NormalizeIdentifiers(&lookup); err != nil {
+		errorJSON(c, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	query := requestDB(c).Preload("Books").Preload("Aliases")
+	switch {
+	case lookup.ORCID != nil:
+		query = query.Where("orcid = ?", *lookup.ORCID)
+	case lookup.VIAF != nil:
+		query = query.Where("viaf = ?", *lookup.VIAF)
+	default:
+		query = query.Where("isni = ?", *lookup.ISNI)
+	}
+
+	var author models.Author
+	if err := query.First(&author).Error; err != nil {
+		errorJSON(c, http.StatusNotFound, "Author not found")
+		return
+	}
+
+	models.FillBookRoles(requestDB(c), &author)
+	c.JSON(http.StatusOK, author)
+}
+
 // CreateAuthor godoc
 // @Summary Cria um novo autor
+// @Description sort_name é gerado a partir do nome quando não informado. ORCID, VIAF e ISNI não podem se repetir entre autores.
 // @Tags authors
 // @Accept json
 // @Produce json
 // @Param author body models.Author true "Dados do autor"
 // @Success 201 {object} models.Author
 // @Failure 400 {object} map[string]string
+// @Failure 409 {object} map[string]string
 // @Router /authors [post]
 func CreateAuthor(c *gin.Context) {
 	var author models.Author
 
-	if err := c.ShouldBindJSON(&author); err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+	if !bindJSON(c, &author) {
+		return
+	}
+
+	created, err := service.CreateAuthor(requestDB(c), author)
+	if err != nil {
+		serviceError(c, err)
 		return
 	}
 
-	database.DB.Create(&author)
-	c.JSON(http.StatusCreated, author)
+	c.JSON(http.StatusCreated, created)
 }
 
 // GetAuthor godoc
@@ -52,18 +122,18 @@ func CreateAuthor(c *gin.Context) {
 // @Router /authors/{id} [get]
 func GetAuthor(c *gin.Context) {
 	id, _ := strconv.Atoi(c.Param("id"))
-	var author models.Author
 
-	if err := database.DB.Preload("Books").First(&author, id).Error; err != nil {
-		c.JSON(http.StatusNotFound, gin.H{"error": "Author not found"})
+	author, err := service.GetAuthor(requestDB(c), uint(id))
+	if err != nil {
+		serviceError(c, err)
 		return
 	}
-
 	c.JSON(http.StatusOK, author)
 }
 
 // UpdateAuthor godoc
 // @Summary Atualiza um autor
+// @Description Campos omitidos mantêm o valor atual. Se aliases for enviado, substitui todos os nomes alternativos.
 // @Tags authors
 // @Accept json
 // @Produce json
@@ -72,27 +142,22 @@ func GetAuthor(c *gin.Context) {
 // @Success 200 {object} models.Author
 // @Failure 400 {object} map[string]string
 // @Failure 404 {object} map[string]string
+// @Failure 409 {object} map[string]string
 // @Router /authors/{id} [put]
 func UpdateAuthor(c *gin.Context) {
 	id, _ := strconv.Atoi(c.Param("id"))
-	var author models.Author
 
-	if err := database.DB.First(&author, id).Error; err != nil {
-		c.JSON(http.StatusNotFound, gin.H{"error": "Author not found"})
+	var input models.Author
+	if !bindJSON(c, &input) {
 		return
 	}
 
-	var input models.Author
-	if err := c.ShouldBindJSON(&input); err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+	author, err := service.UpdateAuthor(requestDB(c), uint(id), input)
+	if err != nil {
+		serviceError(c, err)
 		return
 	}
 
-	database.DB.Model(&author).Updates(models.Author{
-		Name: input.Name,
-		Bio:  input.Bio,
-	})
-
 	c.JSON(http.StatusOK, author)
 }
 
@@ -105,13 +170,11 @@ func UpdateAuthor(c *gin.Context) {
 // @Router /authors/{id} [delete]
 func DeleteAuthor(c *gin.Context) {
 	id, _ := strconv.Atoi(c.Param("id"))
-	var author models.Author
 
-	if err := database.DB.First(&author, id).Error; err != nil {
-		c.JSON(http.StatusNotFound, gin.H{"error": "Author not found"})
+	if err := service.DeleteAuthor(requestDB(c), uint(id)); err != nil {
+		serviceError(c, err)
 		return
 	}
 
-	database.DB.Delete(&author)
 	c.JSON(http.StatusOK, gin.H{"message": "Author deleted"})
 }
@@ -0,0 +1,251 @@
+package handlers
+
+import (
+	"library-api/internal/dedup"
+	"library-api/internal/events"
+	"library-api/internal/models"
+	"math"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+	"gorm.io/gorm"
+)
+
+// DuplicateGroup é um grupo de autores que parecem ser a mesma pessoa
+type DuplicateGroup struct {
+	Score       float64         `json:"score"`
+	SuggestedID uint            `json:"suggested_id"` // o autor com mais livros
+	Authors     []models.Author `json:"authors"`
+}
+
+// MergeInput lista os autores que serão unidos ao autor da rota
+type MergeInput struct {
+	AuthorIDs []uint `json:"author_ids"`
+}
+
+// GetAuthorDuplicates godoc
+// @Summary Lista prováveis autores duplicados
+// @Description Agrupa autores com nomes parecidos depois de normalizados (sem acentos, pontuação ou diferença entre "Sobrenome, Nome" e "Nome Sobrenome")
+// @Tags authors
+// @Produce json
+// @Param threshold query number false "Similaridade mínima entre 0 e 1 (padrão 0.85)"
+// @Success 200 {array} DuplicateGroup
+// @Failure 400 {object} map[string]string
+// @Router /authors/duplicates [get]
+func GetAuthorDuplicates(c *gin.Context) {
+	threshold := dedup.DefaultThreshold
+	if v := c.Query("threshold"); v != "" {
+		t, err := strconv.ParseFloat(v, 64)
+		if err != nil || t <= 0 || t > 1 {
+			errorJSON(c, http.StatusBadRequest, "threshold must be between 0 and 1")
+			return
+		}
+		threshold = t
+	}
+
+	var authors []models.Author
+	requestDB(c).Select("id", "name").Find(&authors)
+
+	candidates := make([]dedup.Candidate, len(authors))
+	for i, author := range authors {
+		candidates[i] = dedup.Candidate{ID: author.ID, Name: author.Name}
+	}
+
+	groups := dedup.FindDuplicates(candidates, threshold)
+
+	// Quantidade de livros de cada autor, para sugerir quem permanece
+	var ids []uint
+	for _, group := range groups {
+		ids = append(ids, group.IDs...)
+	}
+	var counts []struct {
+		AuthorID uint
+		Total    int
+	}
+	requestDB(c).Model(&models.BookAuthor{}).Select("author_id, COUNT(*) AS total").
+		Where("author_id IN ?", ids).Group("author_id").Scan(&counts)
+	books := map[uint]int{}
+	for _, row := range counts {
+		books[row.AuthorID] = row.Total
+	}
+
+	result := make([]DuplicateGroup, 0, len(groups))
+	for _, group := range groups {
+		dg := DuplicateGroup{Score: math.Round(group.Score*1000) / 1000}
+		requestDB(c).Order("id").Find(&dg.Authors, group.IDs)
+
+		best := -1
+		for _, id := range group.IDs {
+			if books[id] > best {
+				best = books[id]
+				dg.SuggestedID = id
+			}
+		}
+		result = append(result, dg)
+	}
+
+	c.JSON(http.StatusOK, result)
+}
+
+// MergeAuthors godoc
+// @Summary Une autores duplicados
+// @Description Move os livros dos autores informados para o autor da rota, guarda os nomes deles como aliases, completa datas, nacionalidade e identificadores que faltam e remove os duplicados
+// @Tags authors
+// @Accept json
+// @Produce json
+// @Param id path int true "ID do autor que permanece"
+// @Param input body MergeInput true "IDs dos autores duplicados"
+// @Success 200 {object} models.Author
+// @Failure 400 {object} map[string]string
+// @Failure 404 {object} map[string]string
+// @Router /authors/{id}/merge [post]
+func MergeAuthors(c *gin.Context) {
+	id, _ := strconv.Atoi(c.Param("id"))
+	var survivor models.Author
+
+	if err := requestDB(c).Preload("Aliases").First(&survivor, id).Error; err != nil {
+		errorJSON(c, http.StatusNotFound, "Author not found")
+		return
+	}
+
+	var input MergeInput
+	if !bindJSON(c, &input) {
+		return
+	}
+	if len(input.AuthorIDs) == 0 {
+		errorJSON(c, http.StatusBadRequest, "author_ids is required")
+		return
+	}
+	seen := map[uint]bool{}
+	for _, mergedID := range input.AuthorIDs {
+		if mergedID == survivor.ID {
+			errorJSON(c, http.StatusBadRequest, "An author cannot be merged into itself")
+			return
+		}
+		if seen[mergedID] {
+			errorJSON(c, http.StatusBadRequest, "Each author can appear only once in author_ids")
+			return
+		}
+		seen[mergedID] = true
+	}
+
+	var merged []models.Author
+	requestDB(c).Find(&merged, input.AuthorIDs)
+	if len(merged) != len(input.AuthorIDs) {
+		errorJSON(c, http.StatusNotFound, "Some authors were not found")
+		return
+	}
+
+	// Nomes que o autor já tem, para não repetir aliases
+	known := map[string]bool{strings.ToLower(survivor.Name): true}
+	for _, alias := range survivor.Aliases {
+		known[strings.ToLower(alias.Name)] = true
+	}
+
+	err := requestDB(c).Transaction(func(tx *gorm.DB) error {
+		for _, author := range merged {
+			// Livros que os dois já tinham ficam só com o vínculo do sobrevivente
+			err := tx.Where("author_id = ? AND book_id IN (?)", author.ID,
+				tx.Model(&models.BookAuthor{}).Select("book_id").Where("author_id = ?", survivor.ID)).
+				Delete(&models.BookAuthor{}).Error
+			if err != nil {
+				return err
+			}
+
+			err = tx.Model(&models.BookAuthor{}).Where("author_id = ?", author.ID).
+				Update("author_id", survivor.ID).Error
+			if err != nil {
+				return err
+			}
+
+			var aliases []models.AuthorAlias
+			if err := tx.Where("author_id = ?", author.ID).Find(&aliases).Error; err != nil {
+				return err
+			}
+			aliases = append([]models.AuthorAlias{{Name: author.Name, Kind: models.AliasVariant}}, aliases...)
+			if err := tx.Where("author_id = ?", author.ID).Delete(&models.AuthorAlias{}).Error; err != nil {
+				return err
+			}
+
+			for _, alias := range aliases {
+				if known[strings.ToLower(alias.Name)] {
+					continue
+				}
+				known[strings.ToLower(alias.Name)] = true
+				err := tx.Create(&models.AuthorAlias{AuthorID: survivor.ID, Name: alias.Name, Kind: alias.Kind}).Error
+				if err != nil {
+					return err
+				}
+			}
+
+			// O duplicado perde os identificadores, que são únicos mesmo entre
+			// autores removidos; os que faltam no sobrevivente passam para ele
+			fill := mergeAuthority(&survivor, author)
+			err = tx.Model(&author).Updates(map[string]interface{}{"orcid": nil, "viaf": nil, "isni": nil}).Error
+			if err != nil {
+				return err
+			}
+			if len(fill) > 0 {
+				if err := tx.Model(&survivor).Updates(fill).Error; err != nil {
+					return err
+				}
+			}
+
+			if err := tx.Delete(&author).Error; err != nil {
+				return err
+			}
+		}
+
+		var result models.Author
+		if err := tx.Preload("Books").Preload("Aliases").First(&result, survivor.ID).Error; err != nil {
+			return err
+		}
+		models.FillBookRoles(tx, &result)
+		return events.Publish(tx, events.AuthorMerged{Author: result, MergedIDs: input.AuthorIDs})
+	})
+	if err != nil {
+		errorJSON(c, http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	requestDB(c).Preload("Books").Preload("Aliases").First(&survivor, survivor.ID)
+	models.FillBookRoles(requestDB(c), &survivor)
+	c.JSON(http.StatusOK, survivor)
+}
+
+// mergeAuthority copia para o sobrevivente os dados que só o duplicado tem
+// e devolve as colunas alteradas
+func mergeAuthority(survivor *models.Author, author models.Author) map[string]interface{} {
+	fill := map[string]interface{}{}
+	if survivor.Bio == "" && author.Bio != "" {
+		survivor.Bio = author.Bio
+		fill["bio"] = author.Bio
+	}
+	if survivor.BirthYear == nil && author.BirthYear != nil {
+		survivor.BirthYear = author.BirthYear
+		fill["birth_year"] = *author.BirthYear
+	}
+	if survivor.DeathYear == nil && author.DeathYear != nil {
+		survivor.DeathYear = author.DeathYear
+		fill["death_year"] = *author.DeathYear
+	}
+	if survivor.Nationality == "" && author.Nationality != "" {
+		survivor.Nationality = author.Nationality
+		fill["nationality"] = author.Nationality
+	}
+	if survivor.ORCID == nil && author.ORCID != nil {
+		survivor.ORCID = author.ORCID
+		fill["orcid"] = *author.ORCID
+	}
+	if survivor.VIAF == nil && author.VIAF != nil {
+		survivor.VIAF = author.VIAF
+		fill["viaf"] = *author.VIAF
+	}
+	if survivor.ISNI == nil && author.ISNI != nil {
+		survivor.ISNI = author.ISNI
+		fill["isni"] = *author.ISNI
+	}
+	return fill
+}
@@ -0,0 +1,44 @@
+package handlers
+
+import (
+	"library-api/internal/database"
+	"library-api/internal/models"
+	"net/http"
+	"strconv"
+	"strings"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+)
+
+func TestMergeAuthorsInput(t *testing.T) {
+	connectTestDB(t)
+	gin.SetMode(gin.TestMode)
+	r := gin.New()
+	r.POST("/authors/:id/merge", MergeAuthors)
+
+	authors := []models.Author{{Name: "Machado de Assis"}, {Name: "Assis, Machado de"}}
+	database.DB.Create(&authors)
+	survivor, duplicate := strconv.Itoa(int(authors[0].ID)), strconv.Itoa(int(authors[1].ID))
+
+	tests := []struct {
+		name string
+		ids  string
+		want int
+		msg  string
+	}{
+		{"empty", ``, http.StatusBadRequest, "author_ids is required"},
+		{"itself", survivor, http.StatusBadRequest, "cannot be merged into itself"},
+		{"repeated id", duplicate + "," + duplicate, http.StatusBadRequest, "only once"},
+		{"unknown id", duplicate + ",9999", http.StatusNotFound, "not found"},
+		{"merged", duplicate, http.StatusOK, "Machado de Assis"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			w := serve(r, http.MethodPost, "/authors/"+survivor+"/merge", `{"author_ids": [`+tt.ids+`]}`)
+			if w.Code != tt.want || !strings.Contains(w.Body.String(), tt.msg) {
+				t.Errorf("status %d, body %s; want %d containing %q", w.Code, w.Body, tt.want, tt.msg)
+			}
+		})
+	}
+}
@@ -1,8 +1,10 @@
 package handlers
 
 import (
-	"library-api/internal/database"
+	"errors"
+	"library-api/internal/metadata"
 	"library-api/internal/models"
+	"library-api/internal/service"
 	"net/http"
 	"strconv"
 
@@ -11,37 +13,138 @@ import (
 
 // GetBooks godoc
 // @Summary Lista todos os livros
-// @Description Retorna a lista completa de livros cadastrados
+// @Description Retorna a lista de livros cadastrados, com filtros opcionais
 // @Tags books
 // @Produce json
+// @Param title query string false "Parte do título"
+// @Param isbn query string false "ISBN exato"
+// @Param available query bool false "Somente disponíveis (true) ou emprestados (false)"
+// @Param author_id query int false "ID do autor"
+// @Param author query string false "Parte do nome do autor"
+// @Param publisher query string false "Parte do nome da editora"
+// @Param language query string false "Idioma (ISO 639)"
+// @Param year query int false "Ano de publicação"
+// @Param year_from query int false "Publicados a partir deste ano"
+// @Param year_to query int false "Publicados até este ano"
+// @Param subject_id query int false "ID do assunto"
+// @Param subject query string false "Parte do nome do assunto"
+// @Param series_id query int false "ID da série"
 // @Success 200 {array} models.Book
+// @Failure 400 {object} map[string]string
 // @Router /books [get]
 func GetBooks(c *gin.Context) {
-	var books []models.Book
-	database.DB.Find(&books)
+	filter, err := bookFilter(c)
+	if err != nil {
+		errorJSON(c, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	books, err := service.ListBooks(requestDB(c), filter)
+	if err != nil {
+		serviceError(c, err)
+		return
+	}
 	c.JSON(http.StatusOK, books)
 }
 
+// bookFilter lê os filtros de listagem de livros da query string
+func bookFilter(c *gin.Context) (service.BookFilter, error) {
+	filter := service.BookFilter{
+		Title:     c.Query("title"),
+		ISBN:      c.Query("isbn"),
+		Author:    c.Query("author"),
+		Publisher: c.Query("publisher"),
+		Language:  c.Query("language"),
+		Subject:   c.Query("subject"),
+	}
+
+	if v := c.Query("available"); v != "" {
+		available, err := strconv.ParseBool(v)
+		if err != nil {
+			return filter, errors.New("Invalid available value")
+		}
+		filter.Available = &available
+	}
+
+	for _, f := range []struct {
+		param string
+		id    *uint
+	}{
+		{"author_id", &filter.AuthorID},
+		{"subject_id", &filter.SubjectID},
+		{"series_id", &filter.SeriesID},
+	} {
+		if v := c.Query(f.param); v != "" {
+			id, err := strconv.ParseUint(v, 10, 0)
+			if err != nil {
+				return filter, errors.New("Invalid " + f.param + " value")
+			}
+			*f.id = uint(id)
+		}
+	}
+
+	for _, f := range []struct {
+		param string
+		year  **int
+	}{
+		{"year", &filter.Year},
+		{"year_from", &filter.YearFrom},
+		{"year_to", &filter.YearTo},
+	} {
+		if v := c.Query(f.param); v != "" {
+			year, err := strconv.Atoi(v)
+			if err != nil {
+				return filter, errors.New("Invalid " + f.param + " value")
+			}
+			*f.year = &year
+		}
+	}
+
+	return filter, nil
+}
+
 // CreateBook godoc
 // @Summary Cria um novo livro
-// @Description Cria um livro com título, ISBN e autores opcionais
+// @Description Cria um livro com título, ISBN, metadados bibliográficos e autores e assuntos opcionais. Os autores vão em credits, com papel (author, editor, translator, illustrator) e na ordem de exibição; author_ids continua aceito para autores sem papel definido. Com autofill=true, os campos vazios, os autores e os assuntos não enviados são preenchidos pelo provedor de metadados a partir do ISBN.
 // @Tags books
 // @Accept json
 // @Produce json
 // @Param book body models.Book true "Dados do livro"
+// @Param autofill query bool false "Completa os campos vazios pelo ISBN"
 // @Success 201 {object} models.Book
 // @Failure 400 {object} map[string]string
+// @Failure 502 {object} map[string]string
+// @Failure 503 {object} map[string]string
 // @Router /books [post]
 func CreateBook(c *gin.Context) {
 	var book models.Book
 
-	if err := c.ShouldBindJSON(&book); err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+	if !bindJSON(c, &book) {
 		return
 	}
 
-	database.DB.Create(&book)
-	c.JSON(http.StatusCreated, book)
+	// ISBN desconhecido pelo provedor não impede o cadastro
+	var meta *metadata.Metadata
+	if autofill, _ := strconv.ParseBool(c.Query("autofill")); autofill && book.ISBN != "" {
+		var status int
+		var err error
+		meta, status, err = lookupMetadata(c, book.ISBN)
+		if err != nil && status != http.StatusNotFound {
+			errorJSON(c, status, err.Error())
+			return
+		}
+		if meta != nil {
+			fillBook(&book, meta)
+		}
+	}
+
+	created, err := service.CreateBook(requestDB(c), book, meta)
+	if err != nil {
+		serviceError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusCreated, created)
 }
 
 // GetBook godoc
@@ -54,18 +157,25 @@ func CreateBook(c *gin.Context) {
 // @Router /books/{id} [get]
 func GetBook(c *gin.Context) {
 	id, _ := strconv.Atoi(c.Param("id"))
-	var book models.Book
 
-	if err := database.DB.First(&book, id).Error; err != nil {
-		c.JSON(http.StatusNotFound, gin.H{"error": "Book not found"})
+	book, err := service.GetBook(requestDB(c), uint(id))
+	if err != nil {
+		serviceError(c, err)
 		return
 	}
-
 	c.JSON(http.StatusOK, book)
 }
 
+// bookUpdate é o corpo do PUT /books/{id}. Available é ponteiro para
+// separar "available": false de um campo não enviado.
+type bookUpdate struct {
+	models.Book
+	Available *bool `json:"available"`
+}
+
 // UpdateBook godoc
 // @Summary Atualiza um livro existente
+// @Description Quando credits (ou author_ids) é enviado, substitui todos os autores do livro
 // @Tags books
 // @Accept json
 // @Produce json
@@ -77,33 +187,18 @@ func GetBook(c *gin.Context) {
 // @Router /books/{id} [put]
 func UpdateBook(c *gin.Context) {
 	id, _ := strconv.Atoi(c.Param("id"))
-	var book models.Book
 
-	if err := database.DB.First(&book, id).Error; err != nil {
-		c.JSON(http.StatusNotFound, gin.H{"error": "Book not found"})
+	var input bookUpdate
+	if !bindJSON(c, &input) {
 		return
 	}
 
-	var input models.Book
-	if err := c.ShouldBindJSON(&input); err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+	book, err := service.UpdateBook(requestDB(c), uint(id), input.Book, input.Available)
+	if err != nil {
+		serviceError(c, err)
 		return
 	}
 
-	// Atualiza dados básicos
-	database.DB.Model(&book).Updates(models.Book{
-		Title:     input.Title,
-		ISBN:      input.ISBN,
-		Available: input.Available,
-	})
-
-	// Atualiza autores se AuthorIDs foi enviado
-	if len(input.AuthorIDs) > 0 {
-		var authors []models.Author
-		database.DB.Find(&authors, input.AuthorIDs)
-		database.DB.Model(&book).Association("Authors").Replace(&authors)
-	}
-
 	c.JSON(http.StatusOK, book)
 }
 
@@ -116,13 +211,11 @@ func UpdateBook(c *gin.Context) {
 // @Router /books/{id} [delete]
 func DeleteBook(c *gin.Context) {
 	id, _ := strconv.Atoi(c.Param("id"))
-	var book models.Book
 
-	if err := database.DB.First(&book, id).Error; err != nil {
-		c.JSON(http.StatusNotFound, gin.H{"error": "Book not found"})
+	if err := service.DeleteBook(requestDB(c), uint(id)); err != nil {
+		serviceError(c, err)
 		return
 	}
 
-	database.DB.Delete(&book)
 	c.JSON(http.StatusOK, gin.H{"message": "Book deleted"})
 }
@@ -0,0 +1,54 @@
+package handlers
+
+import (
+	"encoding/json"
+	"library-api/internal/database"
+	"library-api/internal/models"
+	"net/http"
+	"strconv"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+)
+
+// connectTestDB abre um banco novo, já migrado, no diretório temporário
+// do teste
+func connectTestDB(t *testing.T) {
+	t.Helper()
+	t.Chdir(t.TempDir())
+	database.Connect()
+	t.Cleanup(func() { database.Close() })
+}
+
+func TestUpdateBookAvailable(t *testing.T) {
+	connectTestDB(t)
+	gin.SetMode(gin.TestMode)
+	r := gin.New()
+	r.PUT("/books/:id", UpdateBook)
+
+	book := models.Book{Title: "Dom Casmurro", Available: true}
+	database.DB.Create(&book)
+	path := "/books/" + strconv.FormatUint(uint64(book.ID), 10)
+
+	steps := []struct {
+		name string
+		body string
+		want bool
+	}{
+		{"explicit false", `{"available": false}`, false},
+		{"omitted keeps false", `{"title": "Dom Casmurro: romance"}`, false},
+		{"explicit true", `{"available": true}`, true},
+		{"omitted keeps true", `{"pages": 208}`, true},
+	}
+	for _, step := range steps {
+		w := serve(r, http.MethodPut, path, step.body)
+		if w.Code != http.StatusOK {
+			t.Fatalf("%s: status = %d: %s", step.name, w.Code, w.Body)
+		}
+		var got models.Book
+		json.Unmarshal(w.Body.Bytes(), &got)
+		if got.Available != step.want {
+			t.Errorf("%s: available = %v, want %v", step.name, got.Available, step.want)
+		}
+	}
+}
@@ -0,0 +1,121 @@
+package handlers
+
+import (
+	"library-api/internal/models"
+	"math"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/gin-gonic/gin"
+)
+
+// BookLoans é uma página do histórico de empréstimos de um livro
+type BookLoans struct {
+	Page
+	Loans []models.Loan `json:"loans"`
+}
+
+// BookStats resume a circulação de um livro
+type BookStats struct {
+	BookID          uint         `json:"book_id"`
+	TotalLoans      int64        `json:"total_loans"`
+	Borrowers       int64        `json:"borrowers"`         // leitores diferentes
+	AverageLoanDays *float64     `json:"average_loan_days"` // só empréstimos devolvidos
+	LastBorrowedAt  *time.Time   `json:"last_borrowed_at"`
+	CurrentBorrower *string      `json:"current_borrower"`
+	CurrentLoan     *models.Loan `json:"current_loan,omitempty"`
+}
+
+// GetBookLoans godoc
+// @Summary Histórico de empréstimos de um livro
+// @Description Lista os empréstimos do livro, do mais recente para o mais antigo
+// @Tags books
+// @Produce json
+// @Param id path int true "Book ID"
+// @Param page query int false "Página, a partir de 1"
+// @Param page_size query int false "Itens por página (padrão 20, máximo 100)"
+// @Success 200 {object} BookLoans
+// @Failure 400 {object} map[string]string
+// @Failure 404 {object} map[string]string
+// @Router /books/{id}/loans [get]
+func GetBookLoans(c *gin.Context) {
+	id, _ := strconv.Atoi(c.Param("id"))
+	var book models.Book
+
+	if err := requestDB(c).First(&book, id).Error; err != nil {
+		errorJSON(c, http.StatusNotFound, "Book not found")
+		return
+	}
+
+	page, err := pagination(c)
+	if err != nil {
+		errorJSON(c, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	result := BookLoans{Page: page, Loans: []models.Loan{}}
+	requestDB(c).Model(&models.Loan{}).Where("book_id = ?", book.ID).Count(&result.Total)
+
+	requestDB(c).Preload("Book").Where("book_id = ?", book.ID).
+		Order("loan_date DESC").Offset(page.Offset()).Limit(page.PageSize).
+		Find(&result.Loans)
+	flagOverdue(result.Loans)
+
+	c.JSON(http.StatusOK, result)
+}
+
+// GetBookStats godoc
+// @Summary Estatísticas de circulação de um livro
+// @Description Total de empréstimos, leitores diferentes, duração média (em dias, dos empréstimos já devolvidos), último empréstimo e leitor atual
+// @Tags books
+// @Produce json
+// @Param id path int true "Book ID"
+// @Success 200 {object} BookStats
+// @Failure 404 {object} map[string]string
+// @Router /books/{id}/stats [get]
+func GetBookStats(c *gin.Context) {
+	id, _ := strconv.Atoi(c.Param("id"))
+	var book models.Book
+
+	if err := requestDB(c).First(&book, id).Error; err != nil {
+		errorJSON(c, http.StatusNotFound, "Book not found")
+		return
+	}
+
+	stats := BookStats{BookID: book.ID}
+
+	var totals struct {
+		Total     int64
+		Borrowers int64
+		AvgDays   *float64
+	}
+	requestDB(c).Model(&models.Loan{}).
+		Select("COUNT(*) AS total, COUNT(DISTINCT LOWER(user_name)) AS borrowers, "+
+			"AVG(CASE WHEN return_date IS NOT NULL THEN julianday(return_date) - julianday(loan_date) END) AS avg_days").
+		Where("book_id = ?", book.ID).Scan(&totals)
+	stats.TotalLoans = totals.Total
+	stats.Borrowers = totals.Borrowers
+	if totals.AvgDays != nil {
+		days := math.Round(*totals.AvgDays*10) / 10
+		stats.AverageLoanDays = &days
+	}
+
+	// Último empréstimo pelo índice (book_id, loan_date)
+	var last models.Loan
+	requestDB(c).Where("book_id = ?", book.ID).Order("loan_date DESC").Limit(1).Find(&last)
+	if last.ID != 0 {
+		stats.LastBorrowedAt = &last.LoanDate
+	}
+
+	var current models.Loan
+	requestDB(c).Where("book_id = ? AND return_date IS NULL", book.ID).Order("loan_date DESC").Limit(1).Find(&current)
+	if current.ID != 0 {
+		current.Book = book
+		current.Overdue = current.IsOverdue(time.Now())
+		stats.CurrentBorrower = &current.UserName
+		stats.CurrentLoan = &current
+	}
+
+	c.JSON(http.StatusOK, stats)
+}
@@ -0,0 +1,54 @@
+package handlers
+
+import (
+	"library-api/internal/database"
+	"library-api/internal/limits"
+	"library-api/internal/logging"
+	"library-api/internal/service"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"gorm.io/gorm"
+)
+
+// requestDB devolve a conexão com o contexto da requisição, que leva o
+// request ID aos logs das consultas
+func requestDB(c *gin.Context) *gorm.DB {
+	return database.DB.WithContext(c.Request.Context())
+}
+
+// errorJSON responde com a mensagem de erro e o request ID, para que o
+// cliente possa citá-lo ao relatar o problema
+func errorJSON(c *gin.Context, status int, message string) {
+	c.JSON(status, gin.H{"error": message, "request_id": logging.RequestIDFrom(c.Request.Context())})
+}
+
+// bindJSON lê o corpo JSON em obj; responde 413 se o corpo passou do
+// limite (limits.MaxBodySize) e 400 para os demais erros
+func bindJSON(c *gin.Context, obj interface{}) bool {
+	err := c.ShouldBindJSON(obj)
+	switch {
+	case err == nil:
+		return true
+	case limits.IsTooLarge(err):
+		errorJSON(c, http.StatusRequestEntityTooLarge, "Request body too large")
+	default:
+		errorJSON(c, http.StatusBadRequest, err.Error())
+	}
+	return false
+}
+
+// serviceError responde com o status que corresponde ao erro do serviço;
+// erros inesperados viram 500
+func serviceError(c *gin.Context, err error) {
+	switch service.KindOf(err) {
+	case service.Invalid:
+		errorJSON(c, http.StatusBadRequest, err.Error())
+	case service.NotFound:
+		errorJSON(c, http.StatusNotFound, err.Error())
+	case service.Conflict:
+		errorJSON(c, http.StatusConflict, err.Error())
+	default:
+		errorJSON(c, http.StatusInternalServerError, err.Error())
+	}
+}
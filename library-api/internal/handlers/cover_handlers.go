@@ -0,0 +1,206 @@
+package handlers
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"library-api/internal/covers"
+	"library-api/internal/models"
+	"library-api/internal/storage"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+	"gorm.io/gorm"
+)
+
+// fileStorage guarda as capas enviadas
+var fileStorage storage.Storage
+
+// SetStorage define onde as capas são gravadas
+func SetStorage(s storage.Storage) {
+	fileStorage = s
+}
+
+// UploadCover godoc
+// @Summary Envia a capa de um livro
+// @Description Recebe uma imagem JPEG, PNG ou WebP de até 5 MB no campo "file". O tipo é detectado pelo conteúdo. Gera as miniaturas small, medium e large e aponta cover_url do livro para a capa enviada.
+// @Tags books
+// @Accept multipart/form-data
+// @Produce json
+// @Param id path int true "Book ID"
+// @Param file formData file true "Imagem da capa"
+// @Success 200 {object} models.BookCover
+// @Failure 400 {object} map[string]string
+// @Failure 404 {object} map[string]string
+// @Failure 413 {object} map[string]string
+// @Failure 415 {object} map[string]string
+// @Router /books/{id}/cover [put]
+func UploadCover(c *gin.Context) {
+	id, _ := strconv.Atoi(c.Param("id"))
+	var book models.Book
+
+	if err := requestDB(c).First(&book, id).Error; err != nil {
+		errorJSON(c, http.StatusNotFound, "Book not found")
+		return
+	}
+
+	// O limite do corpo vem da rota (limits.MaxBodySize em main.go)
+	header, err := c.FormFile("file")
+	if err != nil {
+		var tooLarge *http.MaxBytesError
+		if errors.As(err, &tooLarge) {
+			errorJSON(c, http.StatusRequestEntityTooLarge, covers.ErrTooLarge.Error())
+			return
+		}
+		errorJSON(c, http.StatusBadRequest, "file is required")
+		return
+	}
+	if header.Size > covers.MaxBytes {
+		errorJSON(c, http.StatusRequestEntityTooLarge, covers.ErrTooLarge.Error())
+		return
+	}
+
+	file, err := header.Open()
+	if err != nil {
+		errorJSON(c, http.StatusBadRequest, err.Error())
+		return
+	}
+	defer file.Close()
+
+	data, err := io.ReadAll(io.LimitReader(file, covers.MaxBytes+1))
+	if err != nil {
+		errorJSON(c, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	cover, err := covers.Process(data)
+	switch {
+	case errors.Is(err, covers.ErrTooLarge):
+		errorJSON(c, http.StatusRequestEntityTooLarge, err.Error())
+		return
+	case errors.Is(err, covers.ErrUnsupported):
+		errorJSON(c, http.StatusUnsupportedMediaType, err.Error())
+		return
+	case err != nil:
+		errorJSON(c, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	ctx := c.Request.Context()
+	var previous models.BookCover
+	requestDB(c).Where("book_id = ?", book.ID).Limit(1).Find(&previous)
+
+	if err := fileStorage.Put(ctx, covers.Key(book.ID, covers.Original, cover.Extension),
+		bytes.NewReader(cover.Original), cover.ContentType); err != nil {
+		errorJSON(c, http.StatusInternalServerError, err.Error())
+		return
+	}
+	for size, thumb := range cover.Thumbnails {
+		if err := fileStorage.Put(ctx, covers.Key(book.ID, size, ".jpg"), bytes.NewReader(thumb), "image/jpeg"); err != nil {
+			errorJSON(c, http.StatusInternalServerError, err.Error())
+			return
+		}
+	}
+	// Um original anterior em outro formato fica sem uso
+	if previous.BookID != 0 && previous.Extension != cover.Extension {
+		fileStorage.Delete(ctx, covers.Key(book.ID, covers.Original, previous.Extension))
+	}
+
+	sum := sha256.Sum256(cover.Original)
+	record := models.BookCover{
+		BookID:      book.ID,
+		ContentType: cover.ContentType,
+		Extension:   cover.Extension,
+		Width:       cover.Width,
+		Height:      cover.Height,
+		Size:        int64(len(cover.Original)),
+		Checksum:    hex.EncodeToString(sum[:]),
+		CreatedAt:   previous.CreatedAt, // zero no primeiro envio, preenchido pelo GORM
+	}
+
+	err = requestDB(c).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Save(&record).Error; err != nil {
+			return err
+		}
+		// O parâmetro v muda a cada envio, para o navegador não usar a capa antiga
+		url := fmt.Sprintf("/books/%d/cover?v=%s", book.ID, record.Checksum[:12])
+		return tx.Model(&book).Update("cover_url", url).Error
+	})
+	if err != nil {
+		errorJSON(c, http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	c.JSON(http.StatusOK, record)
+}
+
+// GetCover godoc
+// @Summary Devolve a capa de um livro
+// @Description Serve a imagem enviada (size=original, o padrão) ou uma miniatura em JPEG. Responde com ETag e Cache-Control e devolve 304 quando If-None-Match confere. Livros sem capa enviada, mas com cover_url externa, são redirecionados para ela.
+// @Tags books
+// @Produce image/jpeg,image/png,image/webp
+// @Param id path int true "Book ID"
+// @Param size query string false "original, small, medium ou large"
+// @Success 200 {file} file
+// @Success 304 {string} string "Not Modified"
+// @Failure 400 {object} map[string]string
+// @Failure 404 {object} map[string]string
+// @Router /books/{id}/cover [get]
+func GetCover(c *gin.Context) {
+	id, _ := strconv.Atoi(c.Param("id"))
+	var book models.Book
+
+	if err := requestDB(c).First(&book, id).Error; err != nil {
+		errorJSON(c, http.StatusNotFound, "Book not found")
+		return
+	}
+
+	size := c.DefaultQuery("size", covers.Original)
+	if _, ok := covers.Sizes[size]; !ok && size != covers.Original {
+		errorJSON(c, http.StatusBadRequest, "size must be original, small, medium or large")
+		return
+	}
+
+	var cover models.BookCover
+	requestDB(c).Where("book_id = ?", book.ID).Limit(1).Find(&cover)
+	if cover.BookID == 0 {
+		if strings.HasPrefix(book.CoverURL, "http://") || strings.HasPrefix(book.CoverURL, "https://") {
+			c.Redirect(http.StatusFound, book.CoverURL)
+			return
+		}
+		errorJSON(c, http.StatusNotFound, "Cover not found")
+		return
+	}
+
+	ext := ".jpg"
+	if size == covers.Original {
+		ext = cover.Extension
+	}
+
+	etag := fmt.Sprintf(`"%s-%s"`, cover.Checksum[:16], size)
+	c.Header("ETag", etag)
+	c.Header("Cache-Control", "public, max-age=86400")
+	c.Header("Last-Modified", cover.UpdatedAt.UTC().Format(http.TimeFormat))
+	if match := c.GetHeader("If-None-Match"); match == etag || match == "*" {
+		c.Status(http.StatusNotModified)
+		return
+	}
+
+	object, err := fileStorage.Get(c.Request.Context(), covers.Key(book.ID, size, ext))
+	if errors.Is(err, storage.ErrNotFound) {
+		errorJSON(c, http.StatusNotFound, "Cover not found")
+		return
+	}
+	if err != nil {
+		errorJSON(c, http.StatusInternalServerError, err.Error())
+		return
+	}
+	defer object.Body.Close()
+
+	c.DataFromReader(http.StatusOK, object.Size, object.ContentType, object.Body, nil)
+}
@@ -0,0 +1,73 @@
+package handlers
+
+import (
+	"bytes"
+	"image"
+	"image/png"
+	"library-api/internal/covers"
+	"library-api/internal/database"
+	"library-api/internal/limits"
+	"library-api/internal/models"
+	"library-api/internal/storage"
+	"mime/multipart"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+)
+
+func uploadCover(r *gin.Engine, bookID uint, data []byte) *httptest.ResponseRecorder {
+	var body bytes.Buffer
+	form := multipart.NewWriter(&body)
+	part, _ := form.CreateFormFile("file", "capa.png")
+	part.Write(data)
+	form.Close()
+
+	req := httptest.NewRequest(http.MethodPut, "/books/"+strconv.FormatUint(uint64(bookID), 10)+"/cover", &body)
+	req.Header.Set("Content-Type", form.FormDataContentType())
+	w := httptest.NewRecorder()
+	r.ServeHTTP(w, req)
+	return w
+}
+
+func TestUploadCover(t *testing.T) {
+	connectTestDB(t)
+	files, err := storage.NewLocal(t.TempDir())
+	if err != nil {
+		t.Fatal(err)
+	}
+	SetStorage(files)
+	t.Cleanup(func() { SetStorage(nil) })
+
+	gin.SetMode(gin.TestMode)
+	r := gin.New()
+	// Mesma montagem de main.go: o limite do corpo é o da rota
+	r.Use(limits.MaxBodySize(1 << 20))
+	r.PUT("/books/:id/cover", limits.MaxBodySize(covers.MaxBytes+64<<10), UploadCover)
+
+	book := models.Book{Title: "Duna", Available: true}
+	database.DB.Create(&book)
+
+	var picture bytes.Buffer
+	png.Encode(&picture, image.NewGray(image.Rect(0, 0, 40, 60)))
+
+	tests := []struct {
+		name string
+		data []byte
+		want int
+	}{
+		{"oversized", make([]byte, covers.MaxBytes+1), http.StatusRequestEntityTooLarge},
+		{"over the route limit", make([]byte, covers.MaxBytes+64<<10), http.StatusRequestEntityTooLarge},
+		{"not an image", []byte("isto não é uma imagem"), http.StatusUnsupportedMediaType},
+		{"png", picture.Bytes(), http.StatusOK},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if w := uploadCover(r, book.ID, tt.data); w.Code != tt.want {
+				t.Errorf("status %d, want %d: %s", w.Code, tt.want, w.Body)
+			}
+		})
+	}
+}
@@ -0,0 +1,42 @@
+package handlers
+
+import (
+	"io"
+	"library-api/internal/database"
+	"library-api/internal/models"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+)
+
+func TestExportBooksOutlivesWriteTimeout(t *testing.T) {
+	connectTestDB(t)
+	database.DB.Create(&models.Book{Title: "Dom Casmurro", ISBN: "8508064586"})
+
+	gin.SetMode(gin.TestMode)
+	r := gin.New()
+	// Uma consulta lenta faz a exportação passar do limite de escrita
+	r.GET("/export/books", func(c *gin.Context) { time.Sleep(300 * time.Millisecond) }, ExportBooks)
+
+	srv := httptest.NewUnstartedServer(r)
+	srv.Config.WriteTimeout = 100 * time.Millisecond
+	srv.Start()
+	defer srv.Close()
+
+	resp, err := http.Get(srv.URL + "/export/books?format=csv")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer resp.Body.Close()
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if resp.StatusCode != http.StatusOK || !strings.Contains(string(body), "Dom Casmurro") {
+		t.Errorf("status %d, body %q", resp.StatusCode, body)
+	}
+}
@@ -0,0 +1,32 @@
+package handlers
+
+import (
+	"library-api/internal/graph"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+)
+
+// GraphQL godoc
+// @Summary Consulta GraphQL
+// @Description Executa uma query ou mutation GraphQL sobre livros, autores e empréstimos. As listas são paginadas (page, pageSize) e os campos aninhados (autores de um livro, livros de um autor, livro de um empréstimo) são carregados em lote, com uma consulta por nível. Erros de execução voltam com status 200 em errors, com o código em extensions.code.
+// @Tags graphql
+// @Accept json
+// @Produce json
+// @Param request body graph.Request true "Query, nome da operação e variáveis"
+// @Success 200 {object} map[string]interface{}
+// @Failure 400 {object} map[string]string
+// @Router /graphql [post]
+func GraphQL(c *gin.Context) {
+	var req graph.Request
+
+	if !bindJSON(c, &req) {
+		return
+	}
+	if req.Query == "" {
+		errorJSON(c, http.StatusBadRequest, "query is required")
+		return
+	}
+
+	c.JSON(http.StatusOK, graph.Execute(c.Request.Context(), requestDB(c), req))
+}
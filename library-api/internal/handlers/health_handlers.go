@@ -0,0 +1,64 @@
+package handlers
+
+import (
+	"context"
+	"library-api/internal/database"
+	"library-api/internal/logging"
+	"net/http"
+	"time"
+
+	"github.com/gin-gonic/gin"
+)
+
+// readinessTimeout limita o tempo das verificações de prontidão
+const readinessTimeout = 2 * time.Second
+
+// Readiness é o resultado de cada verificação de /readyz
+type Readiness struct {
+	Status string            `json:"status"` // ready ou unavailable
+	Checks map[string]string `json:"checks"` // ok ou a mensagem de erro
+}
+
+// Healthz godoc
+// @Summary Liveness
+// @Description Responde enquanto o processo está de pé; não consulta o banco
+// @Tags health
+// @Produce json
+// @Success 200 {object} map[string]string
+// @Router /healthz [get]
+func Healthz(c *gin.Context) {
+	c.JSON(http.StatusOK, gin.H{"status": "ok"})
+}
+
+// Readyz godoc
+// @Summary Readiness
+// @Description Verifica se o banco responde e se as migrações foram aplicadas
+// @Tags health
+// @Produce json
+// @Success 200 {object} Readiness
+// @Failure 503 {object} Readiness
+// @Router /readyz [get]
+func Readyz(c *gin.Context) {
+	ctx, cancel := context.WithTimeout(c.Request.Context(), readinessTimeout)
+	defer cancel()
+
+	result := Readiness{Status: "ready", Checks: map[string]string{}}
+	check := func(name string, err error) {
+		if err != nil {
+			logging.FromContext(ctx).Warn("readiness check failed", "check", name, "error", err)
+			result.Status = "unavailable"
+			result.Checks[name] = err.Error()
+			return
+		}
+		result.Checks[name] = "ok"
+	}
+
+	check("database", database.Ping(ctx))
+	check("migrations", database.CheckMigrations(ctx))
+
+	status := http.StatusOK
+	if result.Status != "ready" {
+		status = http.StatusServiceUnavailable
+	}
+	c.JSON(status, result)
+}
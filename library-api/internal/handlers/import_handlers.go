@@ -0,0 +1,157 @@
+package handlers
+
+import (
+	"io"
+	"library-api/internal/importer"
+	"library-api/internal/limits"
+	"net/http"
+	"path/filepath"
+	"strconv"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+)
+
+// ImportBooks godoc
+// @Summary Importa livros em lote
+// @Description Importa livros de um arquivo CSV (title, isbn, authors), JSON Lines, MARC21 binário ou MARCXML, criando autores pelo nome quando necessário. O arquivo pode ser enviado no corpo ou no campo multipart "file".
+// @Tags import
+// @Accept text/csv,application/x-ndjson,application/marc,application/marcxml+xml,mpfd
+// @Produce json
+// @Param format query string false "Formato do arquivo (csv, jsonl, marc21 ou marcxml)"
+// @Param dry_run query bool false "Simula a importação sem gravar nada"
+// @Param mode query string false "batch (padrão) ou atomic"
+// @Param batch_size query int false "Linhas por transação no modo batch"
+// @Param on_conflict query string false "skip (padrão) ou update para livros com ISBN existente"
+// @Param file formData file false "Arquivo a importar"
+// @Success 200 {object} importer.Report
+// @Failure 400 {object} map[string]string
+// @Failure 413 {object} map[string]string
+// @Failure 422 {object} importer.Report
+// @Router /import/books [post]
+func ImportBooks(c *gin.Context) {
+	opts := importer.Options{BatchSize: importer.DefaultBatchSize}
+
+	if v := c.Query("dry_run"); v != "" {
+		dryRun, err := strconv.ParseBool(v)
+		if err != nil {
+			errorJSON(c, http.StatusBadRequest, "Invalid dry_run value")
+			return
+		}
+		opts.DryRun = dryRun
+	}
+
+	switch c.DefaultQuery("mode", "batch") {
+	case "batch":
+	case "atomic":
+		opts.Atomic = true
+	default:
+		errorJSON(c, http.StatusBadRequest, "mode must be batch or atomic")
+		return
+	}
+
+	if v := c.Query("batch_size"); v != "" {
+		size, err := strconv.Atoi(v)
+		if err != nil || size <= 0 {
+			errorJSON(c, http.StatusBadRequest, "Invalid batch_size value")
+			return
+		}
+		opts.BatchSize = size
+	}
+
+	switch c.DefaultQuery("on_conflict", "skip") {
+	case "skip":
+	case "update":
+		opts.Update = true
+	default:
+		errorJSON(c, http.StatusBadRequest, "on_conflict must be skip or update")
+		return
+	}
+
+	// Aceita o arquivo via multipart ou direto no corpo
+	var body io.Reader = c.Request.Body
+	filename := ""
+	if c.ContentType() == "multipart/form-data" {
+		file, header, err := c.Request.FormFile("file")
+		if limits.IsTooLarge(err) {
+			errorJSON(c, http.StatusRequestEntityTooLarge, "Import file too large")
+			return
+		}
+		if err != nil {
+			errorJSON(c, http.StatusBadRequest, "Missing file field")
+			return
+		}
+		defer file.Close()
+		body = file
+		filename = header.Filename
+	}
+
+	format := importFormat(c.Query("format"), c.ContentType(), filename)
+	if format == "" {
+		errorJSON(c, http.StatusBadRequest, "Could not detect file format, use format=csv, jsonl, marc21 or marcxml")
+		return
+	}
+
+	rows, err := importer.Parse(body, format)
+	if limits.IsTooLarge(err) {
+		errorJSON(c, http.StatusRequestEntityTooLarge, "Import file too large")
+		return
+	}
+	if err != nil {
+		errorJSON(c, http.StatusBadRequest, err.Error())
+		return
+	}
+	if len(rows) == 0 {
+		errorJSON(c, http.StatusBadRequest, "No rows to import")
+		return
+	}
+
+	report := importer.Import(requestDB(c), rows, opts)
+	if opts.Atomic && report.Error != "" {
+		c.JSON(http.StatusUnprocessableEntity, report)
+		return
+	}
+
+	c.JSON(http.StatusOK, report)
+}
+
+// importFormat decide o formato pelo parâmetro, Content-Type ou extensão
+func importFormat(param, contentType, filename string) string {
+	switch strings.ToLower(param) {
+	case "csv":
+		return importer.FormatCSV
+	case "jsonl", "ndjson":
+		return importer.FormatJSONL
+	case "marc21", "marc", "mrc":
+		return importer.FormatMARC21
+	case "marcxml":
+		return importer.FormatMARCXML
+	case "":
+	default:
+		return ""
+	}
+
+	switch contentType {
+	case "text/csv":
+		return importer.FormatCSV
+	case "application/x-ndjson", "application/jsonl", "application/x-jsonlines":
+		return importer.FormatJSONL
+	case "application/marc":
+		return importer.FormatMARC21
+	case "application/marcxml+xml", "application/xml", "text/xml":
+		return importer.FormatMARCXML
+	}
+
+	switch strings.ToLower(filepath.Ext(filename)) {
+	case ".csv":
+		return importer.FormatCSV
+	case ".jsonl", ".ndjson":
+		return importer.FormatJSONL
+	case ".mrc", ".marc":
+		return importer.FormatMARC21
+	case ".xml":
+		return importer.FormatMARCXML
+	}
+
+	return ""
+}
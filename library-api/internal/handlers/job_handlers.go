@@ -0,0 +1,56 @@
+package handlers
+
+import (
+	"library-api/internal/jobs"
+	"library-api/internal/models"
+	"net/http"
+	"time"
+
+	"github.com/gin-gonic/gin"
+)
+
+// GetJobs godoc
+// @Summary Lista os jobs em segundo plano
+// @Description Mostra o agendamento de cada job, a próxima execução e o resultado da última: status, resumo, erro e duração. running indica que alguma instância está rodando o job agora (locked_by).
+// @Tags admin
+// @Produce json
+// @Success 200 {array} models.Job
+// @Router /admin/jobs [get]
+func GetJobs(c *gin.Context) {
+	var list []models.Job
+	requestDB(c).Order("name").Find(&list)
+
+	now := time.Now()
+	for i := range list {
+		list[i].Running = list[i].LockedUntil != nil && list[i].LockedUntil.After(now)
+		if !list[i].Running {
+			list[i].LockedBy = nil
+		}
+	}
+	c.JSON(http.StatusOK, list)
+}
+
+// RunJob godoc
+// @Summary Roda um job agora
+// @Description Antecipa a próxima execução do job para agora; ele roda na próxima verificação do agendador, em alguns segundos, numa das instâncias.
+// @Tags admin
+// @Produce json
+// @Param name path string true "Nome do job"
+// @Success 202 {object} models.Job
+// @Failure 404 {object} map[string]string
+// @Router /admin/jobs/{name}/run [post]
+func RunJob(c *gin.Context) {
+	found, err := jobs.Trigger(requestDB(c), c.Param("name"))
+	if err != nil {
+		errorJSON(c, http.StatusInternalServerError, err.Error())
+		return
+	}
+	if !found {
+		errorJSON(c, http.StatusNotFound, "Job not found")
+		return
+	}
+
+	var job models.Job
+	requestDB(c).Where("name = ?", c.Param("name")).First(&job)
+	c.JSON(http.StatusAccepted, job)
+}
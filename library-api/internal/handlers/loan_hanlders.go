@@ -1,8 +1,8 @@
 package handlers
 
 import (
-	"library-api/internal/database"
 	"library-api/internal/models"
+	"library-api/internal/service"
 	"net/http"
 	"strconv"
 	"time"
@@ -14,51 +14,48 @@ import (
 // @Summary Lista todos os empréstimos
 // @Tags loans
 // @Produce json
+// @Param user_name query string false "Nome do leitor (sem diferenciar maiúsculas)"
+// @Param status query string false "active (em aberto), returned ou overdue (em aberto e atrasados)"
 // @Success 200 {array} models.Loan
+// @Failure 400 {object} map[string]string
 // @Router /loans [get]
 func GetLoans(c *gin.Context) {
-	var loans []models.Loan
-	database.DB.Preload("Book.Authors").Find(&loans) // já traz o livro e autores
+	loans, err := service.ListLoans(requestDB(c), service.LoanFilter{
+		UserName: c.Query("user_name"),
+		Status:   c.Query("status"),
+	})
+	if err != nil {
+		serviceError(c, err)
+		return
+	}
 	c.JSON(http.StatusOK, loans)
 }
 
 // CreateLoan godoc
 // @Summary Cria um novo empréstimo
+// @Description due_date é opcional; o prazo padrão é de 14 dias
 // @Tags loans
 // @Accept json
 // @Produce json
 // @Param loan body models.Loan true "Dados do empréstimo"
 // @Success 201 {object} models.Loan
 // @Failure 400 {object} map[string]string
+// @Failure 404 {object} map[string]string
+// @Failure 409 {object} map[string]string
 // @Router /loans [post]
 func CreateLoan(c *gin.Context) {
 	var loan models.Loan
 
-	if err := c.ShouldBindJSON(&loan); err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
-		return
-	}
-
-	// Verifica se o livro existe e está disponível
-	var book models.Book
-	if err := database.DB.First(&book, loan.BookID).Error; err != nil {
-		c.JSON(http.StatusNotFound, gin.H{"error": "Book not found"})
+	if !bindJSON(c, &loan) {
 		return
 	}
 
-	if !book.Available {
-		c.JSON(http.StatusBadRequest, gin.H{"error": "Book is not available"})
+	loan, err := service.CreateLoan(requestDB(c), loan)
+	if err != nil {
+		serviceError(c, err)
 		return
 	}
 
-	// Marca livro como indisponível
-	book.Available = false
-	database.DB.Save(&book)
-
-	// Define data do empréstimo
-	loan.LoanDate = time.Now()
-
-	database.DB.Create(&loan)
 	c.JSON(http.StatusCreated, loan)
 }
 
@@ -72,13 +69,12 @@ func CreateLoan(c *gin.Context) {
 // @Router /loans/{id} [get]
 func GetLoan(c *gin.Context) {
 	id, _ := strconv.Atoi(c.Param("id"))
-	var loan models.Loan
 
-	if err := database.DB.Preload("Book.Authors").First(&loan, id).Error; err != nil {
-		c.JSON(http.StatusNotFound, gin.H{"error": "Loan not found"})
+	loan, err := service.GetLoan(requestDB(c), uint(id))
+	if err != nil {
+		serviceError(c, err)
 		return
 	}
-
 	c.JSON(http.StatusOK, loan)
 }
 
@@ -89,32 +85,19 @@ func GetLoan(c *gin.Context) {
 // @Produce json
 // @Param id path int true "Loan ID"
 // @Success 200 {object} models.Loan
+// @Failure 400 {object} map[string]string
 // @Failure 404 {object} map[string]string
+// @Failure 409 {object} map[string]string
 // @Router /loans/{id}/return [put]
 func ReturnLoan(c *gin.Context) {
 	id, _ := strconv.Atoi(c.Param("id"))
-	var loan models.Loan
 
-	if err := database.DB.Preload("Book").First(&loan, id).Error; err != nil {
-		c.JSON(http.StatusNotFound, gin.H{"error": "Loan not found"})
+	loan, err := service.ReturnLoan(requestDB(c), uint(id))
+	if err != nil {
+		serviceError(c, err)
 		return
 	}
 
-	// Se já foi devolvido
-	if loan.ReturnDate != nil {
-		c.JSON(http.StatusBadRequest, gin.H{"error": "Book already returned"})
-		return
-	}
-
-	// Atualiza data de devolução
-	now := time.Now()
-	loan.ReturnDate = &now
-	database.DB.Save(&loan)
-
-	// Marca livro como disponível novamente
-	loan.Book.Available = true
-	database.DB.Save(&loan.Book)
-
 	c.JSON(http.StatusOK, gin.H{"message": "Book returned successfully", "loan": loan})
 }
 
@@ -127,19 +110,19 @@ func ReturnLoan(c *gin.Context) {
 // @Router /loans/{id} [delete]
 func DeleteLoan(c *gin.Context) {
 	id, _ := strconv.Atoi(c.Param("id"))
-	var loan models.Loan
 
-	if err := database.DB.Preload("Book").First(&loan, id).Error; err != nil {
-		c.JSON(http.StatusNotFound, gin.H{"error": "Loan not found"})
+	if err := service.DeleteLoan(requestDB(c), uint(id)); err != nil {
+		serviceError(c, err)
 		return
 	}
 
-	// Se empréstimo ainda estava ativo, libera o livro
-	if loan.ReturnDate == nil {
-		loan.Book.Available = true
-		database.DB.Save(&loan.Book)
-	}
-
-	database.DB.Delete(&loan)
 	c.JSON(http.StatusOK, gin.H{"message": "Loan deleted"})
 }
+
+// flagOverdue marca os empréstimos em aberto com prazo vencido
+func flagOverdue(loans []models.Loan) {
+	now := time.Now()
+	for i := range loans {
+		loans[i].Overdue = loans[i].IsOverdue(now)
+	}
+}
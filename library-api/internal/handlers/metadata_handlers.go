@@ -0,0 +1,106 @@
+package handlers
+
+import (
+	"errors"
+	"library-api/internal/metadata"
+	"library-api/internal/models"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+)
+
+// metadataProvider busca os dados dos livros pelo ISBN; nil desativa a busca
+var metadataProvider metadata.Provider
+
+// SetMetadataProvider define o provedor usado em /books/lookup e no
+// preenchimento automático do CreateBook
+func SetMetadataProvider(provider metadata.Provider) {
+	metadataProvider = provider
+}
+
+// BookLookup são os dados encontrados para um ISBN
+type BookLookup struct {
+	metadata.Metadata
+	ExistingBookID *uint `json:"existing_book_id,omitempty"` // livro do acervo com o mesmo ISBN
+}
+
+// LookupBook godoc
+// @Summary Busca os dados de um livro pelo ISBN
+// @Description Consulta o provedor de metadados e devolve uma prévia, sem cadastrar nada
+// @Tags books
+// @Produce json
+// @Param isbn query string true "ISBN-10 ou ISBN-13, com ou sem hífens"
+// @Success 200 {object} BookLookup
+// @Failure 400 {object} map[string]string
+// @Failure 404 {object} map[string]string
+// @Failure 502 {object} map[string]string
+// @Failure 503 {object} map[string]string
+// @Router /books/lookup [post]
+func LookupBook(c *gin.Context) {
+	meta, status, err := lookupMetadata(c, c.Query("isbn"))
+	if err != nil {
+		errorJSON(c, status, err.Error())
+		return
+	}
+
+	result := BookLookup{Metadata: *meta}
+	var existing models.Book
+	requestDB(c).Select("id").Where("isbn IN ?", []string{c.Query("isbn"), meta.ISBN}).Limit(1).Find(&existing)
+	if existing.ID != 0 {
+		result.ExistingBookID = &existing.ID
+	}
+
+	c.JSON(http.StatusOK, result)
+}
+
+// lookupMetadata valida o ISBN e consulta o provedor, devolvendo o status
+// HTTP adequado em caso de erro
+func lookupMetadata(c *gin.Context, isbn string) (*metadata.Metadata, int, error) {
+	if metadataProvider == nil {
+		return nil, http.StatusServiceUnavailable, errors.New("Metadata lookup is disabled")
+	}
+
+	normalized, ok := metadata.NormalizeISBN(isbn)
+	if !ok {
+		return nil, http.StatusBadRequest, errors.New("Invalid ISBN")
+	}
+
+	meta, err := metadataProvider.Lookup(c.Request.Context(), normalized)
+	if errors.Is(err, metadata.ErrNotFound) {
+		return nil, http.StatusNotFound, err
+	}
+	if err != nil {
+		return nil, http.StatusBadGateway, errors.New("Metadata lookup failed: " + err.Error())
+	}
+	return meta, 0, nil
+}
+
+// fillBook completa os campos vazios do livro com os metadados
+func fillBook(book *models.Book, meta *metadata.Metadata) {
+	if book.Title == "" {
+		book.Title = meta.Title
+	}
+	if book.Publisher == "" {
+		book.Publisher = meta.Publisher
+	}
+	if book.PublicationYear == 0 {
+		book.PublicationYear = meta.PublicationYear
+	}
+	if book.Edition == "" {
+		book.Edition = meta.Edition
+	}
+	if book.Language == "" {
+		if language, ok := models.NormalizeLanguage(meta.Language); ok {
+			book.Language = language
+		}
+	}
+	if book.Pages == 0 {
+		book.Pages = meta.Pages
+	}
+	if book.Description == "" {
+		book.Description = meta.Description
+	}
+	if book.CoverURL == "" {
+		book.CoverURL = meta.CoverURL
+	}
+}
@@ -0,0 +1,147 @@
+package handlers
+
+import (
+	"encoding/json"
+	"library-api/internal/database"
+	"library-api/internal/metadata"
+	"library-api/internal/models"
+	"net/http"
+	"net/http/httptest"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+)
+
+// metadataRouter abre um banco novo no diretório temporário do teste e
+// usa o provedor de arquivos com os exemplos de samples/metadata, sem
+// acessar a Open Library
+func metadataRouter(t *testing.T) *gin.Engine {
+	t.Helper()
+
+	samples, err := filepath.Abs("../../samples/metadata")
+	if err != nil {
+		t.Fatal(err)
+	}
+	connectTestDB(t)
+
+	SetMetadataProvider(metadata.NewFileProvider(samples))
+	t.Cleanup(func() { SetMetadataProvider(nil) })
+
+	gin.SetMode(gin.TestMode)
+	r := gin.New()
+	r.POST("/books", CreateBook)
+	r.POST("/books/lookup", LookupBook)
+	return r
+}
+
+func serve(r *gin.Engine, method, path, body string) *httptest.ResponseRecorder {
+	req := httptest.NewRequest(method, path, strings.NewReader(body))
+	req.Header.Set("Content-Type", "application/json")
+	w := httptest.NewRecorder()
+	r.ServeHTTP(w, req)
+	return w
+}
+
+func TestLookupBook(t *testing.T) {
+	r := metadataRouter(t)
+
+	w := serve(r, http.MethodPost, "/books/lookup?isbn=978-0-261-10334-4", "")
+	if w.Code != http.StatusOK {
+		t.Fatalf("status = %d, want 200: %s", w.Code, w.Body)
+	}
+	var result BookLookup
+	if err := json.Unmarshal(w.Body.Bytes(), &result); err != nil {
+		t.Fatal(err)
+	}
+	if result.ISBN != "9780261103344" || result.Title != "The Hobbit" || result.Source != "file" {
+		t.Errorf("got isbn %q, title %q, source %q", result.ISBN, result.Title, result.Source)
+	}
+	if result.ExistingBookID != nil {
+		t.Errorf("existing_book_id = %d before the book was created", *result.ExistingBookID)
+	}
+
+	book := models.Book{Title: "O Hobbit", ISBN: "9780261103344"}
+	database.DB.Create(&book)
+
+	w = serve(r, http.MethodPost, "/books/lookup?isbn=9780261103344", "")
+	result = BookLookup{}
+	json.Unmarshal(w.Body.Bytes(), &result)
+	if result.ExistingBookID == nil || *result.ExistingBookID != book.ID {
+		t.Errorf("existing_book_id = %v, want %d", result.ExistingBookID, book.ID)
+	}
+
+	tests := []struct {
+		name string
+		isbn string
+		want int
+	}{
+		{"unknown isbn", "9780306406157", http.StatusNotFound},
+		{"bad check digit", "9780261103345", http.StatusBadRequest},
+		{"not an isbn", "hobbit", http.StatusBadRequest},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if w := serve(r, http.MethodPost, "/books/lookup?isbn="+tt.isbn, ""); w.Code != tt.want {
+				t.Errorf("status = %d, want %d: %s", w.Code, tt.want, w.Body)
+			}
+		})
+	}
+}
+
+func TestLookupBookDisabled(t *testing.T) {
+	r := metadataRouter(t)
+	SetMetadataProvider(nil)
+
+	if w := serve(r, http.MethodPost, "/books/lookup?isbn=9780261103344", ""); w.Code != http.StatusServiceUnavailable {
+		t.Errorf("status = %d, want 503", w.Code)
+	}
+}
+
+func TestCreateBookAutofill(t *testing.T) {
+	r := metadataRouter(t)
+
+	// O autor já cadastrado é encontrado pelo nome em vez de duplicado
+	herbert := models.Author{Name: "Frank Herbert"}
+	database.DB.Create(&herbert)
+
+	w := serve(r, http.MethodPost, "/books?autofill=true", `{"isbn": "9788576573135", "pages": 700}`)
+	if w.Code != http.StatusCreated {
+		t.Fatalf("status = %d, want 201: %s", w.Code, w.Body)
+	}
+	var book models.Book
+	if err := json.Unmarshal(w.Body.Bytes(), &book); err != nil {
+		t.Fatal(err)
+	}
+
+	// Os campos enviados prevalecem sobre os metadados
+	if book.Title != "Duna" || book.Publisher != "Aleph" || book.PublicationYear != 2017 || book.Edition != "1. ed." || book.Language != "por" || book.Pages != 700 {
+		t.Errorf("book not filled as expected: %+v", book)
+	}
+	if len(book.Authors) != 1 || book.Authors[0].ID != herbert.ID || book.Authors[0].Role != models.RoleAuthor {
+		t.Errorf("authors = %+v, want author %d as author", book.Authors, herbert.ID)
+	}
+	if len(book.Subjects) != 1 || book.Subjects[0].Name != "Ficção científica" {
+		t.Errorf("subjects = %+v, want Ficção científica", book.Subjects)
+	}
+}
+
+func TestCreateBookAutofillUnknownISBN(t *testing.T) {
+	r := metadataRouter(t)
+
+	// ISBN desconhecido pelo provedor não impede o cadastro
+	w := serve(r, http.MethodPost, "/books?autofill=true", `{"title": "Sem metadados", "isbn": "9780306406157"}`)
+	if w.Code != http.StatusCreated {
+		t.Fatalf("status = %d, want 201: %s", w.Code, w.Body)
+	}
+	var book models.Book
+	json.Unmarshal(w.Body.Bytes(), &book)
+	if book.Title != "Sem metadados" || len(book.Authors) != 0 {
+		t.Errorf("got %+v", book)
+	}
+
+	if w := serve(r, http.MethodPost, "/books?autofill=true", `{"title": "ISBN inválido", "isbn": "123"}`); w.Code != http.StatusBadRequest {
+		t.Errorf("invalid isbn: status = %d, want 400", w.Code)
+	}
+}
@@ -0,0 +1,48 @@
+package handlers
+
+import (
+	"errors"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+)
+
+// Limites de paginação
+const (
+	DefaultPageSize = 20
+	MaxPageSize     = 100
+)
+
+// Page descreve a página devolvida de uma listagem paginada
+type Page struct {
+	Page     int   `json:"page"`
+	PageSize int   `json:"page_size"`
+	Total    int64 `json:"total"`
+}
+
+// pagination lê page (a partir de 1) e page_size da query string
+func pagination(c *gin.Context) (Page, error) {
+	page := Page{Page: 1, PageSize: DefaultPageSize}
+
+	if v := c.Query("page"); v != "" {
+		n, err := strconv.Atoi(v)
+		if err != nil || n < 1 {
+			return page, errors.New("page must be a positive number")
+		}
+		page.Page = n
+	}
+	if v := c.Query("page_size"); v != "" {
+		n, err := strconv.Atoi(v)
+		if err != nil || n < 1 || n > MaxPageSize {
+			return page, errors.New("page_size must be between 1 and 100")
+		}
+		page.PageSize = n
+	}
+
+	return page, nil
+}
+
+// Offset é a quantidade de registros das páginas anteriores
+func (p Page) Offset() int {
+	return (p.Page - 1) * p.PageSize
+}
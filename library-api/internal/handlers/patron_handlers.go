@@ -0,0 +1,170 @@
+package handlers
+
+import (
+	"errors"
+	"library-api/internal/models"
+	"net/http"
+	"net/mail"
+	"strings"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"gorm.io/gorm"
+)
+
+// PatronInput são os dados de contato de um leitor
+type PatronInput struct {
+	Email    string `json:"email" example:"leitor@example.com"`
+	Language string `json:"language" example:"pt"` // pt ou en
+}
+
+// PatronLoanCounts resume os empréstimos de um leitor
+type PatronLoanCounts struct {
+	Total   int `json:"total"`
+	Current int `json:"current"`
+	Past    int `json:"past"`
+	Overdue int `json:"overdue"`
+}
+
+// PatronLoans são os empréstimos de um leitor, separados entre os que
+// estão com ele e os já devolvidos
+type PatronLoans struct {
+	UserName string           `json:"user_name"`
+	Counts   PatronLoanCounts `json:"counts"`
+	Current  []models.Loan    `json:"current"`
+	Past     []models.Loan    `json:"past"`
+}
+
+// GetPatronLoans godoc
+// @Summary Empréstimos de um leitor
+// @Description Enquanto não há cadastro de leitores, o identificador é o user_name usado nos empréstimos (sem diferenciar maiúsculas). Os empréstimos em aberto vêm em current, do prazo mais próximo para o mais distante; os devolvidos vêm em past, do mais recente para o mais antigo.
+// @Tags patrons
+// @Produce json
+// @Param id path string true "Identificador do leitor (user_name)"
+// @Success 200 {object} PatronLoans
+// @Router /patrons/{id}/loans [get]
+func GetPatronLoans(c *gin.Context) {
+	userName := strings.TrimSpace(c.Param("id"))
+	result := PatronLoans{UserName: userName, Current: []models.Loan{}, Past: []models.Loan{}}
+
+	patron := requestDB(c).Where("LOWER(user_name) = ?", strings.ToLower(userName))
+
+	requestDB(c).Preload("Book.Authors").Where(patron).Where("return_date IS NULL").
+		Order("due_date").Find(&result.Current)
+	requestDB(c).Preload("Book.Authors").Where(patron).Where("return_date IS NOT NULL").
+		Order("return_date DESC").Find(&result.Past)
+
+	books := make([]*models.Book, 0, len(result.Current)+len(result.Past))
+	for i := range result.Current {
+		books = append(books, &result.Current[i].Book)
+	}
+	for i := range result.Past {
+		books = append(books, &result.Past[i].Book)
+	}
+	models.FillAuthorRoles(requestDB(c), books...)
+	flagOverdue(result.Current)
+
+	// Devolve o nome como está gravado, não como veio na URL
+	if len(result.Current) > 0 {
+		result.UserName = result.Current[0].UserName
+	} else if len(result.Past) > 0 {
+		result.UserName = result.Past[0].UserName
+	}
+
+	now := time.Now()
+	result.Counts = PatronLoanCounts{
+		Total:   len(result.Current) + len(result.Past),
+		Current: len(result.Current),
+		Past:    len(result.Past),
+	}
+	for _, loan := range result.Current {
+		if loan.IsOverdue(now) {
+			result.Counts.Overdue++
+		}
+	}
+
+	c.JSON(http.StatusOK, result)
+}
+
+// GetPatron godoc
+// @Summary Contato de um leitor
+// @Description Retorna o e-mail e o idioma usados nos lembretes de devolução e avisos de atraso
+// @Tags patrons
+// @Produce json
+// @Param id path string true "Identificador do leitor (user_name)"
+// @Success 200 {object} models.Patron
+// @Failure 404 {object} map[string]string
+// @Router /patrons/{id} [get]
+func GetPatron(c *gin.Context) {
+	var patron models.Patron
+
+	err := requestDB(c).Where("LOWER(user_name) = ?", strings.ToLower(strings.TrimSpace(c.Param("id")))).
+		First(&patron).Error
+	if err != nil {
+		errorJSON(c, http.StatusNotFound, "Patron not found")
+		return
+	}
+
+	c.JSON(http.StatusOK, patron)
+}
+
+// UpdatePatron godoc
+// @Summary Cadastra ou atualiza o contato de um leitor
+// @Description Leitores com e-mail recebem lembretes antes do prazo de devolução e avisos de atraso, no idioma escolhido (pt ou en; padrão pt). E-mail vazio desativa os avisos.
+// @Tags patrons
+// @Accept json
+// @Produce json
+// @Param id path string true "Identificador do leitor (user_name)"
+// @Param patron body PatronInput true "Contato do leitor"
+// @Success 200 {object} models.Patron
+// @Failure 400 {object} map[string]string
+// @Router /patrons/{id} [put]
+func UpdatePatron(c *gin.Context) {
+	userName := strings.TrimSpace(c.Param("id"))
+	if userName == "" {
+		errorJSON(c, http.StatusBadRequest, "user_name is required")
+		return
+	}
+
+	var input PatronInput
+	if !bindJSON(c, &input) {
+		return
+	}
+
+	input.Email = strings.TrimSpace(input.Email)
+	if input.Email != "" {
+		addr, err := mail.ParseAddress(input.Email)
+		if err != nil || addr.Name != "" {
+			errorJSON(c, http.StatusBadRequest, "email must be a valid address")
+			return
+		}
+		input.Email = addr.Address
+	}
+	input.Language = strings.ToLower(strings.TrimSpace(input.Language))
+	switch input.Language {
+	case "":
+		input.Language = models.LanguagePortuguese
+	case models.LanguagePortuguese, models.LanguageEnglish:
+	default:
+		errorJSON(c, http.StatusBadRequest, "language must be pt or en")
+		return
+	}
+
+	var patron models.Patron
+	err := requestDB(c).Where("LOWER(user_name) = ?", strings.ToLower(userName)).First(&patron).Error
+	if err != nil && !errors.Is(err, gorm.ErrRecordNotFound) {
+		errorJSON(c, http.StatusInternalServerError, err.Error())
+		return
+	}
+	if patron.ID == 0 {
+		patron.UserName = userName
+	}
+	patron.Email = input.Email
+	patron.Language = input.Language
+
+	if err := requestDB(c).Save(&patron).Error; err != nil {
+		errorJSON(c, http.StatusInternalServerError, err.Error())
+		return
+	}
+	c.JSON(http.StatusOK, patron)
+}
@@ -0,0 +1,257 @@
+package handlers
+
+import (
+	"encoding/csv"
+	"errors"
+	"library-api/internal/logging"
+	"library-api/internal/reports"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/gin-gonic/gin"
+)
+
+// Limites do parâmetro limit dos rankings
+const (
+	defaultReportLimit = 10
+	maxReportLimit     = 100
+)
+
+// GetReportSummary godoc
+// @Summary Indicadores gerais de circulação
+// @Description Total de empréstimos, devolvidos, em aberto, atrasados, devolvidos com atraso, taxa de atraso, duração média em dias, leitores ativos e livros diferentes emprestados no intervalo
+// @Tags reports
+// @Produce json,text/csv
+// @Param from query string false "Empréstimos a partir desta data (AAAA-MM-DD)"
+// @Param to query string false "Empréstimos até esta data, inclusive (AAAA-MM-DD)"
+// @Param format query string false "json (padrão) ou csv"
+// @Success 200 {object} reports.Summary
+// @Failure 400 {object} map[string]string
+// @Router /reports/summary [get]
+func GetReportSummary(c *gin.Context) {
+	r, ok := reportRange(c)
+	if !ok {
+		return
+	}
+
+	summary, err := reports.Summarize(requestDB(c), r, time.Now())
+	if err != nil {
+		errorJSON(c, http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	if c.Query("format") == "csv" {
+		writeReportCSV(c, "summary", []reports.Summary{summary})
+		return
+	}
+	c.JSON(http.StatusOK, summary)
+}
+
+// GetLoansReport godoc
+// @Summary Empréstimos por período
+// @Description Quantidade de empréstimos por dia, semana (AAAA-Wnn, semanas começando na segunda) ou mês, e quantos deles já foram devolvidos
+// @Tags reports
+// @Produce json,text/csv
+// @Param period query string false "day, week ou month (padrão)"
+// @Param from query string false "Empréstimos a partir desta data (AAAA-MM-DD)"
+// @Param to query string false "Empréstimos até esta data, inclusive (AAAA-MM-DD)"
+// @Param format query string false "json (padrão) ou csv"
+// @Success 200 {array} reports.PeriodCount
+// @Failure 400 {object} map[string]string
+// @Router /reports/loans [get]
+func GetLoansReport(c *gin.Context) {
+	r, ok := reportRange(c)
+	if !ok {
+		return
+	}
+
+	period := c.DefaultQuery("period", "month")
+	if !reports.ValidPeriod(period) {
+		errorJSON(c, http.StatusBadRequest, "period must be day, week or month")
+		return
+	}
+
+	rows, err := reports.LoansPerPeriod(requestDB(c), r, period)
+	sendReport(c, "loans-per-"+period, rows, err)
+}
+
+// GetTopBooksReport godoc
+// @Summary Livros mais emprestados
+// @Tags reports
+// @Produce json,text/csv
+// @Param from query string false "Empréstimos a partir desta data (AAAA-MM-DD)"
+// @Param to query string false "Empréstimos até esta data, inclusive (AAAA-MM-DD)"
+// @Param limit query int false "Quantidade de livros (padrão 10, máximo 100)"
+// @Param format query string false "json (padrão) ou csv"
+// @Success 200 {array} reports.BookCount
+// @Failure 400 {object} map[string]string
+// @Router /reports/top-books [get]
+func GetTopBooksReport(c *gin.Context) {
+	r, ok := reportRange(c)
+	if !ok {
+		return
+	}
+	limit, ok := reportLimit(c)
+	if !ok {
+		return
+	}
+
+	rows, err := reports.TopBooks(requestDB(c), r, limit)
+	sendReport(c, "top-books", rows, err)
+}
+
+// GetTopAuthorsReport godoc
+// @Summary Autores mais emprestados
+// @Description Soma os empréstimos dos livros em que a pessoa aparece com o papel author
+// @Tags reports
+// @Produce json,text/csv
+// @Param from query string false "Empréstimos a partir desta data (AAAA-MM-DD)"
+// @Param to query string false "Empréstimos até esta data, inclusive (AAAA-MM-DD)"
+// @Param limit query int false "Quantidade de autores (padrão 10, máximo 100)"
+// @Param format query string false "json (padrão) ou csv"
+// @Success 200 {array} reports.AuthorCount
+// @Failure 400 {object} map[string]string
+// @Router /reports/top-authors [get]
+func GetTopAuthorsReport(c *gin.Context) {
+	r, ok := reportRange(c)
+	if !ok {
+		return
+	}
+	limit, ok := reportLimit(c)
+	if !ok {
+		return
+	}
+
+	rows, err := reports.TopAuthors(requestDB(c), r, limit)
+	sendReport(c, "top-authors", rows, err)
+}
+
+// GetNeverBorrowedReport godoc
+// @Summary Livros sem empréstimos
+// @Description Sem intervalo, lista os livros nunca emprestados; com from/to, os que não foram emprestados no intervalo
+// @Tags reports
+// @Produce json,text/csv
+// @Param from query string false "Empréstimos a partir desta data (AAAA-MM-DD)"
+// @Param to query string false "Empréstimos até esta data, inclusive (AAAA-MM-DD)"
+// @Param format query string false "json (padrão) ou csv"
+// @Success 200 {array} reports.IdleBook
+// @Failure 400 {object} map[string]string
+// @Router /reports/never-borrowed [get]
+func GetNeverBorrowedReport(c *gin.Context) {
+	r, ok := reportRange(c)
+	if !ok {
+		return
+	}
+
+	rows, err := reports.NeverBorrowed(requestDB(c), r)
+	sendReport(c, "never-borrowed", rows, err)
+}
+
+// GetActivePatronsReport godoc
+// @Summary Leitores mais ativos
+// @Tags reports
+// @Produce json,text/csv
+// @Param from query string false "Empréstimos a partir desta data (AAAA-MM-DD)"
+// @Param to query string false "Empréstimos até esta data, inclusive (AAAA-MM-DD)"
+// @Param limit query int false "Quantidade de leitores (padrão 10, máximo 100)"
+// @Param format query string false "json (padrão) ou csv"
+// @Success 200 {array} reports.PatronCount
+// @Failure 400 {object} map[string]string
+// @Router /reports/active-patrons [get]
+func GetActivePatronsReport(c *gin.Context) {
+	r, ok := reportRange(c)
+	if !ok {
+		return
+	}
+	limit, ok := reportLimit(c)
+	if !ok {
+		return
+	}
+
+	rows, err := reports.ActivePatrons(requestDB(c), r, limit)
+	sendReport(c, "active-patrons", rows, err)
+}
+
+// reportRange lê from e to (AAAA-MM-DD, to inclusivo) e responde 400 se
+// forem inválidos
+func reportRange(c *gin.Context) (reports.Range, bool) {
+	var r reports.Range
+
+	parse := func(name string) (*time.Time, error) {
+		v := c.Query(name)
+		if v == "" {
+			return nil, nil
+		}
+		t, err := time.ParseInLocation("2006-01-02", v, time.Local)
+		if err != nil {
+			return nil, errors.New(name + " must be a date in YYYY-MM-DD format")
+		}
+		return &t, nil
+	}
+
+	from, err := parse("from")
+	if err == nil {
+		r.From = from
+		r.To, err = parse("to")
+	}
+	if err != nil {
+		errorJSON(c, http.StatusBadRequest, err.Error())
+		return r, false
+	}
+
+	if r.To != nil {
+		end := r.To.AddDate(0, 0, 1)
+		r.To = &end
+	}
+	if r.From != nil && r.To != nil && !r.From.Before(*r.To) {
+		errorJSON(c, http.StatusBadRequest, "from must not be after to")
+		return r, false
+	}
+	return r, true
+}
+
+// reportLimit lê o tamanho dos rankings
+func reportLimit(c *gin.Context) (int, bool) {
+	v := c.Query("limit")
+	if v == "" {
+		return defaultReportLimit, true
+	}
+	limit, err := strconv.Atoi(v)
+	if err != nil || limit < 1 || limit > maxReportLimit {
+		errorJSON(c, http.StatusBadRequest, "limit must be between 1 and 100")
+		return 0, false
+	}
+	return limit, true
+}
+
+// sendReport responde com as linhas em JSON ou, com format=csv, em CSV
+func sendReport[T reports.Record](c *gin.Context, name string, rows []T, err error) {
+	if err != nil {
+		errorJSON(c, http.StatusInternalServerError, err.Error())
+		return
+	}
+	if c.Query("format") == "csv" {
+		writeReportCSV(c, name, rows)
+		return
+	}
+	c.JSON(http.StatusOK, rows)
+}
+
+// writeReportCSV escreve as linhas com cabeçalho, como anexo
+func writeReportCSV[T reports.Record](c *gin.Context, name string, rows []T) {
+	c.Header("Content-Type", "text/csv; charset=utf-8")
+	c.Header("Content-Disposition", `attachment; filename="`+name+`.csv"`)
+	c.Status(http.StatusOK)
+
+	var zero T
+	w := csv.NewWriter(c.Writer)
+	w.Write(zero.Header())
+	for _, row := range rows {
+		w.Write(row.Values())
+	}
+	w.Flush()
+	if err := w.Error(); err != nil {
+		logging.FromContext(c.Request.Context()).Error("failed to write report", "error", err)
+	}
+}
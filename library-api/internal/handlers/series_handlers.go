@@ -0,0 +1,258 @@
+package handlers
+
+import (
+	"library-api/internal/models"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+	"gorm.io/gorm"
+)
+
+// SeriesBooksInput define a ordem dos volumes de uma série
+type SeriesBooksInput struct {
+	BookIDs []uint `json:"book_ids"`
+}
+
+// GetSeriesList godoc
+// @Summary Lista todas as séries
+// @Tags series
+// @Produce json
+// @Success 200 {array} models.Series
+// @Router /series [get]
+func GetSeriesList(c *gin.Context) {
+	var series []models.Series
+	requestDB(c).Order("name").Find(&series)
+	c.JSON(http.StatusOK, series)
+}
+
+// CreateSeries godoc
+// @Summary Cria uma nova série
+// @Tags series
+// @Accept json
+// @Produce json
+// @Param series body models.Series true "Dados da série"
+// @Success 201 {object} models.Series
+// @Failure 400 {object} map[string]string
+// @Router /series [post]
+func CreateSeries(c *gin.Context) {
+	var series models.Series
+
+	if !bindJSON(c, &series) {
+		return
+	}
+
+	series.Name = strings.TrimSpace(series.Name)
+	if series.Name == "" {
+		errorJSON(c, http.StatusBadRequest, "name is required")
+		return
+	}
+	if series.TotalVolumes < 0 {
+		errorJSON(c, http.StatusBadRequest, "total_volumes must not be negative")
+		return
+	}
+
+	// Os volumes são vinculados pelos livros, não na criação da série
+	series.Books = nil
+
+	requestDB(c).Create(&series)
+	c.JSON(http.StatusCreated, series)
+}
+
+// GetSeries godoc
+// @Summary Busca uma série pelo ID
+// @Description Retorna a série com os livros ordenados por volume
+// @Tags series
+// @Produce json
+// @Param id path int true "Series ID"
+// @Success 200 {object} models.Series
+// @Failure 404 {object} map[string]string
+// @Router /series/{id} [get]
+func GetSeries(c *gin.Context) {
+	id, _ := strconv.Atoi(c.Param("id"))
+	var series models.Series
+
+	err := requestDB(c).Preload("Books", func(db *gorm.DB) *gorm.DB {
+		return db.Order("series_volume")
+	}).First(&series, id).Error
+	if err != nil {
+		errorJSON(c, http.StatusNotFound, "Series not found")
+		return
+	}
+
+	c.JSON(http.StatusOK, series)
+}
+
+// UpdateSeries godoc
+// @Summary Atualiza uma série
+// @Tags series
+// @Accept json
+// @Produce json
+// @Param id path int true "Series ID"
+// @Param series body models.Series true "Dados atualizados"
+// @Success 200 {object} models.Series
+// @Failure 400 {object} map[string]string
+// @Failure 404 {object} map[string]string
+// @Router /series/{id} [put]
+func UpdateSeries(c *gin.Context) {
+	id, _ := strconv.Atoi(c.Param("id"))
+	var series models.Series
+
+	if err := requestDB(c).First(&series, id).Error; err != nil {
+		errorJSON(c, http.StatusNotFound, "Series not found")
+		return
+	}
+
+	var input models.Series
+	if !bindJSON(c, &input) {
+		return
+	}
+	if input.TotalVolumes < 0 {
+		errorJSON(c, http.StatusBadRequest, "total_volumes must not be negative")
+		return
+	}
+
+	requestDB(c).Model(&series).Updates(models.Series{
+		Name:         strings.TrimSpace(input.Name),
+		Description:  input.Description,
+		TotalVolumes: input.TotalVolumes,
+	})
+
+	c.JSON(http.StatusOK, series)
+}
+
+// DeleteSeries godoc
+// @Summary Remove uma série
+// @Description Remove a série; os livros continuam no acervo, sem série
+// @Tags series
+// @Param id path int true "Series ID"
+// @Success 204 {string} string "No Content"
+// @Failure 404 {object} map[string]string
+// @Router /series/{id} [delete]
+func DeleteSeries(c *gin.Context) {
+	id, _ := strconv.Atoi(c.Param("id"))
+	var series models.Series
+
+	if err := requestDB(c).First(&series, id).Error; err != nil {
+		errorJSON(c, http.StatusNotFound, "Series not found")
+		return
+	}
+
+	requestDB(c).Model(&models.Book{}).Where("series_id = ?", series.ID).
+		Updates(map[string]interface{}{"series_id": nil, "series_volume": 0})
+	requestDB(c).Delete(&series)
+	c.JSON(http.StatusOK, gin.H{"message": "Series deleted"})
+}
+
+// SetSeriesBooks godoc
+// @Summary Define os volumes de uma série
+// @Description Recebe os IDs dos livros na ordem de leitura; o primeiro vira o volume 1. Livros que não estão na lista saem da série.
+// @Tags series
+// @Accept json
+// @Produce json
+// @Param id path int true "Series ID"
+// @Param books body SeriesBooksInput true "IDs dos livros em ordem"
+// @Success 200 {object} models.Series
+// @Failure 400 {object} map[string]string
+// @Failure 404 {object} map[string]string
+// @Router /series/{id}/books [put]
+func SetSeriesBooks(c *gin.Context) {
+	id, _ := strconv.Atoi(c.Param("id"))
+	var series models.Series
+
+	if err := requestDB(c).First(&series, id).Error; err != nil {
+		errorJSON(c, http.StatusNotFound, "Series not found")
+		return
+	}
+
+	var input SeriesBooksInput
+	if !bindJSON(c, &input) {
+		return
+	}
+
+	seen := map[uint]bool{}
+	for _, bookID := range input.BookIDs {
+		if seen[bookID] {
+			errorJSON(c, http.StatusBadRequest, "Duplicated book in book_ids")
+			return
+		}
+		seen[bookID] = true
+	}
+
+	var count int64
+	requestDB(c).Model(&models.Book{}).Where("id IN ?", input.BookIDs).Count(&count)
+	if int(count) != len(input.BookIDs) {
+		errorJSON(c, http.StatusBadRequest, "Some books were not found")
+		return
+	}
+
+	err := requestDB(c).Transaction(func(tx *gorm.DB) error {
+		err := tx.Model(&models.Book{}).Where("series_id = ?", series.ID).
+			Updates(map[string]interface{}{"series_id": nil, "series_volume": 0}).Error
+		if err != nil {
+			return err
+		}
+
+		for i, bookID := range input.BookIDs {
+			err := tx.Model(&models.Book{}).Where("id = ?", bookID).
+				Updates(map[string]interface{}{"series_id": series.ID, "series_volume": i + 1}).Error
+			if err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		errorJSON(c, http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	requestDB(c).Preload("Books", func(db *gorm.DB) *gorm.DB {
+		return db.Order("series_volume")
+	}).First(&series, series.ID)
+	c.JSON(http.StatusOK, series)
+}
+
+// GetNextInSeries godoc
+// @Summary Próximo volume da série
+// @Description Retorna o primeiro volume seguinte disponível para empréstimo. Com user_name, ignora os volumes que o leitor já pegou emprestado.
+// @Tags books
+// @Produce json
+// @Param id path int true "Book ID"
+// @Param user_name query string false "Nome do leitor"
+// @Success 200 {object} models.Book
+// @Failure 404 {object} map[string]string
+// @Router /books/{id}/next [get]
+func GetNextInSeries(c *gin.Context) {
+	id, _ := strconv.Atoi(c.Param("id"))
+	var book models.Book
+
+	if err := requestDB(c).First(&book, id).Error; err != nil {
+		errorJSON(c, http.StatusNotFound, "Book not found")
+		return
+	}
+
+	if book.SeriesID == nil {
+		errorJSON(c, http.StatusNotFound, "Book is not part of a series")
+		return
+	}
+
+	query := requestDB(c).Preload("Series").
+		Where("series_id = ? AND series_volume > ? AND available = ?", *book.SeriesID, book.SeriesVolume, true)
+
+	// Volumes já emprestados pelo leitor contam como lidos; o nome é
+	// comparado sem diferenciar maiúsculas, como no filtro de empréstimos
+	if userName := strings.TrimSpace(c.Query("user_name")); userName != "" {
+		query = query.Where("id NOT IN (?)", requestDB(c).Model(&models.Loan{}).
+			Select("book_id").Where("LOWER(user_name) = LOWER(?)", userName))
+	}
+
+	var next models.Book
+	if err := query.Order("series_volume").First(&next).Error; err != nil {
+		errorJSON(c, http.StatusNotFound, "No next volume available")
+		return
+	}
+
+	c.JSON(http.StatusOK, next)
+}
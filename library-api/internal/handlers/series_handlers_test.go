@@ -0,0 +1,55 @@
+package handlers
+
+import (
+	"encoding/json"
+	"library-api/internal/database"
+	"library-api/internal/models"
+	"net/http"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+)
+
+func TestGetNextInSeriesSkipsBorrowedVolumes(t *testing.T) {
+	connectTestDB(t)
+	gin.SetMode(gin.TestMode)
+	r := gin.New()
+	r.GET("/books/:id/next", GetNextInSeries)
+
+	series := models.Series{Name: "O Senhor dos Anéis"}
+	database.DB.Create(&series)
+	var volumes []models.Book
+	for i, isbn := range []string{"9788533613379", "9788533613386", "9788533613393"} {
+		book := models.Book{Title: "Volume " + strconv.Itoa(i+1), ISBN: isbn, Available: true, SeriesID: &series.ID, SeriesVolume: i + 1}
+		database.DB.Create(&book)
+		volumes = append(volumes, book)
+	}
+	// O leitor já leu o segundo volume, com o nome grafado de outro jeito
+	returned := time.Now()
+	database.DB.Create(&models.Loan{BookID: volumes[1].ID, UserName: "Ana Souza", ReturnDate: &returned})
+
+	tests := []struct {
+		query string
+		want  uint
+	}{
+		{"", volumes[1].ID},
+		{"?user_name=Ana%20Souza", volumes[2].ID},
+		{"?user_name=ana%20souza", volumes[2].ID},
+		{"?user_name=%20ANA%20SOUZA%20", volumes[2].ID},
+		{"?user_name=bia", volumes[1].ID},
+	}
+	for _, tt := range tests {
+		path := "/books/" + strconv.FormatUint(uint64(volumes[0].ID), 10) + "/next" + tt.query
+		w := serve(r, http.MethodGet, path, "")
+		if w.Code != http.StatusOK {
+			t.Fatalf("GET %s: status %d: %s", path, w.Code, w.Body)
+		}
+		var next models.Book
+		json.Unmarshal(w.Body.Bytes(), &next)
+		if next.ID != tt.want {
+			t.Errorf("GET %s: next = %d, want %d", path, next.ID, tt.want)
+		}
+	}
+}
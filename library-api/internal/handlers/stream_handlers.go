@@ -0,0 +1,102 @@
+package handlers
+
+import (
+	"fmt"
+	"io"
+	"library-api/internal/stream"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/gin-contrib/sse"
+	"github.com/gin-gonic/gin"
+)
+
+// Parâmetros do stream: o heartbeat mantém a conexão viva em proxies que
+// fecham conexões ociosas; retry é a espera do navegador para reconectar
+const (
+	heartbeatInterval = 15 * time.Second
+	streamRetry       = 3 * time.Second
+)
+
+// eventStream distribui os eventos de GET /events
+var eventStream *stream.Broker
+
+// SetEventStream define o broker usado em GET /events
+func SetEventStream(b *stream.Broker) {
+	eventStream = b
+}
+
+// StreamEvents godoc
+// @Summary Eventos em tempo real (Server-Sent Events)
+// @Description Mantém a conexão aberta e envia book.availability (um livro ficou disponível ou indisponível), loan.created e loan.returned assim que acontecem. Cada evento tem um id; ao reconectar, o navegador manda o último em Last-Event-ID e recebe o que perdeu, enquanto ainda estiver no buffer. Se não estiver, chega um evento reset e o cliente deve recarregar o estado. Um comentário de heartbeat é enviado a cada 15 segundos.
+// @Tags events
+// @Produce text/event-stream
+// @Param types query string false "Tipos separados por vírgula (padrão: todos)"
+// @Param Last-Event-ID header string false "Último evento recebido"
+// @Param last_event_id query string false "O mesmo que Last-Event-ID, para clientes que não enviam cabeçalhos"
+// @Success 200 {string} string "Stream de eventos"
+// @Failure 400 {object} map[string]string
+// @Router /events [get]
+func StreamEvents(c *gin.Context) {
+	var types []string
+	if v := c.Query("types"); v != "" {
+		for _, t := range strings.Split(v, ",") {
+			t = strings.TrimSpace(t)
+			if !stream.ValidType(t) {
+				errorJSON(c, http.StatusBadRequest, "types must be "+strings.Join(stream.Types, ", "))
+				return
+			}
+			types = append(types, t)
+		}
+	}
+
+	if eventStream == nil {
+		errorJSON(c, http.StatusServiceUnavailable, "Event stream is not available")
+		return
+	}
+
+	lastEventID := c.GetHeader("Last-Event-ID")
+	if lastEventID == "" {
+		lastEventID = c.Query("last_event_id")
+	}
+
+	// Os limites de leitura e escrita do servidor encerrariam o stream
+	rc := http.NewResponseController(c.Writer)
+	rc.SetReadDeadline(time.Time{})
+	rc.SetWriteDeadline(time.Time{})
+
+	client, missed := eventStream.Subscribe(lastEventID, types...)
+	defer eventStream.Unsubscribe(client)
+
+	c.Header("Content-Type", "text/event-stream")
+	c.Header("Cache-Control", "no-cache")
+	c.Header("Connection", "keep-alive")
+	c.Header("X-Accel-Buffering", "no")
+	c.Status(http.StatusOK)
+
+	fmt.Fprintf(c.Writer, "retry: %d\n\n", streamRetry.Milliseconds())
+	for _, event := range missed {
+		c.Render(-1, sse.Event{Id: event.ID, Event: event.Type, Data: event.Data})
+	}
+	c.Writer.Flush()
+
+	heartbeat := time.NewTicker(heartbeatInterval)
+	defer heartbeat.Stop()
+	ctx := c.Request.Context()
+
+	c.Stream(func(w io.Writer) bool {
+		select {
+		case <-ctx.Done():
+			return false
+		case event, ok := <-client.C:
+			if !ok {
+				return false
+			}
+			c.Render(-1, sse.Event{Id: event.ID, Event: event.Type, Data: event.Data})
+		case <-heartbeat.C:
+			fmt.Fprint(w, ": heartbeat\n\n")
+		}
+		return true
+	})
+}
@@ -0,0 +1,142 @@
+package handlers
+
+import (
+	"library-api/internal/database"
+	"library-api/internal/models"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+)
+
+// GetSubjects godoc
+// @Summary Lista todos os assuntos
+// @Tags subjects
+// @Produce json
+// @Success 200 {array} models.Subject
+// @Router /subjects [get]
+func GetSubjects(c *gin.Context) {
+	var subjects []models.Subject
+	requestDB(c).Order("name").Find(&subjects)
+	c.JSON(http.StatusOK, subjects)
+}
+
+// CreateSubject godoc
+// @Summary Cria um novo assunto
+// @Tags subjects
+// @Accept json
+// @Produce json
+// @Param subject body models.Subject true "Dados do assunto"
+// @Success 201 {object} models.Subject
+// @Failure 400 {object} map[string]string
+// @Failure 409 {object} map[string]string
+// @Router /subjects [post]
+func CreateSubject(c *gin.Context) {
+	var subject models.Subject
+
+	if !bindJSON(c, &subject) {
+		return
+	}
+
+	subject.Name = strings.TrimSpace(subject.Name)
+	if subject.Name == "" {
+		errorJSON(c, http.StatusBadRequest, "name is required")
+		return
+	}
+
+	if subjectExists(subject.Name, 0) {
+		errorJSON(c, http.StatusConflict, "Subject already exists")
+		return
+	}
+
+	requestDB(c).Create(&subject)
+	c.JSON(http.StatusCreated, subject)
+}
+
+// GetSubject godoc
+// @Summary Busca um assunto pelo ID
+// @Tags subjects
+// @Produce json
+// @Param id path int true "Subject ID"
+// @Success 200 {object} models.Subject
+// @Failure 404 {object} map[string]string
+// @Router /subjects/{id} [get]
+func GetSubject(c *gin.Context) {
+	id, _ := strconv.Atoi(c.Param("id"))
+	var subject models.Subject
+
+	if err := requestDB(c).Preload("Books").First(&subject, id).Error; err != nil {
+		errorJSON(c, http.StatusNotFound, "Subject not found")
+		return
+	}
+
+	c.JSON(http.StatusOK, subject)
+}
+
+// UpdateSubject godoc
+// @Summary Atualiza um assunto
+// @Tags subjects
+// @Accept json
+// @Produce json
+// @Param id path int true "Subject ID"
+// @Param subject body models.Subject true "Dados atualizados"
+// @Success 200 {object} models.Subject
+// @Failure 400 {object} map[string]string
+// @Failure 404 {object} map[string]string
+// @Failure 409 {object} map[string]string
+// @Router /subjects/{id} [put]
+func UpdateSubject(c *gin.Context) {
+	id, _ := strconv.Atoi(c.Param("id"))
+	var subject models.Subject
+
+	if err := requestDB(c).First(&subject, id).Error; err != nil {
+		errorJSON(c, http.StatusNotFound, "Subject not found")
+		return
+	}
+
+	var input models.Subject
+	if !bindJSON(c, &input) {
+		return
+	}
+
+	input.Name = strings.TrimSpace(input.Name)
+	if input.Name != "" && subjectExists(input.Name, subject.ID) {
+		errorJSON(c, http.StatusConflict, "Subject already exists")
+		return
+	}
+
+	requestDB(c).Model(&subject).Updates(models.Subject{Name: input.Name})
+	c.JSON(http.StatusOK, subject)
+}
+
+// DeleteSubject godoc
+// @Summary Remove um assunto
+// @Description Remove o assunto e o desvincula dos livros
+// @Tags subjects
+// @Param id path int true "Subject ID"
+// @Success 204 {string} string "No Content"
+// @Failure 404 {object} map[string]string
+// @Router /subjects/{id} [delete]
+func DeleteSubject(c *gin.Context) {
+	id, _ := strconv.Atoi(c.Param("id"))
+	var subject models.Subject
+
+	if err := requestDB(c).First(&subject, id).Error; err != nil {
+		errorJSON(c, http.StatusNotFound, "Subject not found")
+		return
+	}
+
+	requestDB(c).Model(&subject).Association("Books").Clear()
+	requestDB(c).Delete(&subject)
+	c.JSON(http.StatusOK, gin.H{"message": "Subject deleted"})
+}
+
+// subjectExists verifica se já há outro assunto com o mesmo nome
+func subjectExists(name string, exceptID uint) bool {
+	var count int64
+	database.DB.Model(&models.Subject{}).
+		Where("LOWER(name) = LOWER(?) AND id <> ?", name, exceptID).
+		Count(&count)
+	return count > 0
+}
@@ -0,0 +1,274 @@
+package handlers
+
+import (
+	"errors"
+	"library-api/internal/models"
+	"library-api/internal/webhooks"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"gorm.io/gorm"
+)
+
+// WebhookDeliveries é uma página do histórico de entregas de um webhook
+type WebhookDeliveries struct {
+	Page
+	Deliveries []models.WebhookDelivery `json:"deliveries"`
+}
+
+// GetWebhooks godoc
+// @Summary Lista os webhooks
+// @Tags webhooks
+// @Produce json
+// @Success 200 {array} models.Webhook
+// @Router /webhooks [get]
+func GetWebhooks(c *gin.Context) {
+	var hooks []models.Webhook
+	requestDB(c).Order("id").Find(&hooks)
+	for i := range hooks {
+		hooks[i].Secret = ""
+	}
+	c.JSON(http.StatusOK, hooks)
+}
+
+// CreateWebhook godoc
+// @Summary Cria um webhook
+// @Description Assina eventos de empréstimos e do catálogo. events aceita tipos (loan.created), grupos (loan.*) ou "*"; vazio recebe todos. Sem secret, uma chave é gerada. A chave só é devolvida nesta resposta. URLs em loopback, link-local ou redes privadas são recusadas, salvo com WEBHOOK_ALLOW_PRIVATE=true.
+// @Tags webhooks
+// @Accept json
+// @Produce json
+// @Param webhook body models.Webhook true "Dados do webhook"
+// @Success 201 {object} models.Webhook
+// @Failure 400 {object} map[string]string
+// @Router /webhooks [post]
+func CreateWebhook(c *gin.Context) {
+	var hook models.Webhook
+
+	if !bindJSON(c, &hook) {
+		return
+	}
+
+	if err := validateWebhook(&hook); err != nil {
+		errorJSON(c, http.StatusBadRequest, err.Error())
+		return
+	}
+	if hook.Secret == "" {
+		hook.Secret = webhooks.NewSecret()
+	}
+	if hook.Active == nil {
+		active := true
+		hook.Active = &active
+	}
+
+	if err := requestDB(c).Create(&hook).Error; err != nil {
+		errorJSON(c, http.StatusInternalServerError, err.Error())
+		return
+	}
+	c.JSON(http.StatusCreated, hook)
+}
+
+// GetWebhook godoc
+// @Summary Busca um webhook pelo ID
+// @Tags webhooks
+// @Produce json
+// @Param id path int true "Webhook ID"
+// @Success 200 {object} models.Webhook
+// @Failure 404 {object} map[string]string
+// @Router /webhooks/{id} [get]
+func GetWebhook(c *gin.Context) {
+	id, _ := strconv.Atoi(c.Param("id"))
+	var hook models.Webhook
+
+	if err := requestDB(c).First(&hook, id).Error; err != nil {
+		errorJSON(c, http.StatusNotFound, "Webhook not found")
+		return
+	}
+
+	hook.Secret = ""
+	c.JSON(http.StatusOK, hook)
+}
+
+// UpdateWebhook godoc
+// @Summary Atualiza um webhook
+// @Description Só os campos enviados mudam; events vazio ([]) passa a receber todos os eventos. Enviar secret troca a chave de assinatura.
+// @Tags webhooks
+// @Accept json
+// @Produce json
+// @Param id path int true "Webhook ID"
+// @Param webhook body models.Webhook true "Dados atualizados"
+// @Success 200 {object} models.Webhook
+// @Failure 400 {object} map[string]string
+// @Failure 404 {object} map[string]string
+// @Router /webhooks/{id} [put]
+func UpdateWebhook(c *gin.Context) {
+	id, _ := strconv.Atoi(c.Param("id"))
+	var hook models.Webhook
+
+	if err := requestDB(c).First(&hook, id).Error; err != nil {
+		errorJSON(c, http.StatusNotFound, "Webhook not found")
+		return
+	}
+
+	var input models.Webhook
+	if !bindJSON(c, &input) {
+		return
+	}
+
+	if input.URL == "" {
+		input.URL = hook.URL
+	}
+	if err := validateWebhook(&input); err != nil {
+		errorJSON(c, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	hook.URL = input.URL
+	if input.Description != "" {
+		hook.Description = input.Description
+	}
+	if input.Events != nil {
+		hook.Events = input.Events
+	}
+	if input.Secret != "" {
+		hook.Secret = input.Secret
+	}
+	if input.Active != nil {
+		hook.Active = input.Active
+	}
+	if err := requestDB(c).Save(&hook).Error; err != nil {
+		errorJSON(c, http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	hook.Secret = ""
+	c.JSON(http.StatusOK, hook)
+}
+
+// DeleteWebhook godoc
+// @Summary Remove um webhook
+// @Description Remove o webhook com as entregas pendentes e o histórico
+// @Tags webhooks
+// @Param id path int true "Webhook ID"
+// @Success 204 {string} string "No Content"
+// @Failure 404 {object} map[string]string
+// @Router /webhooks/{id} [delete]
+func DeleteWebhook(c *gin.Context) {
+	id, _ := strconv.Atoi(c.Param("id"))
+	var hook models.Webhook
+
+	if err := requestDB(c).First(&hook, id).Error; err != nil {
+		errorJSON(c, http.StatusNotFound, "Webhook not found")
+		return
+	}
+
+	err := requestDB(c).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Where("webhook_id = ?", hook.ID).Delete(&models.WebhookDelivery{}).Error; err != nil {
+			return err
+		}
+		return tx.Delete(&hook).Error
+	})
+	if err != nil {
+		errorJSON(c, http.StatusInternalServerError, err.Error())
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{"message": "Webhook deleted"})
+}
+
+// GetWebhookDeliveries godoc
+// @Summary Histórico de entregas de um webhook
+// @Description Lista as entregas, da mais recente para a mais antiga, com tentativas, status HTTP da última resposta e erro
+// @Tags webhooks
+// @Produce json
+// @Param id path int true "Webhook ID"
+// @Param status query string false "pending, processing, succeeded ou failed"
+// @Param page query int false "Página, a partir de 1"
+// @Param page_size query int false "Itens por página (padrão 20, máximo 100)"
+// @Success 200 {object} WebhookDeliveries
+// @Failure 400 {object} map[string]string
+// @Failure 404 {object} map[string]string
+// @Router /webhooks/{id}/deliveries [get]
+func GetWebhookDeliveries(c *gin.Context) {
+	id, _ := strconv.Atoi(c.Param("id"))
+	var hook models.Webhook
+
+	if err := requestDB(c).First(&hook, id).Error; err != nil {
+		errorJSON(c, http.StatusNotFound, "Webhook not found")
+		return
+	}
+
+	page, err := pagination(c)
+	if err != nil {
+		errorJSON(c, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	query := requestDB(c).Model(&models.WebhookDelivery{}).Where("webhook_id = ?", hook.ID)
+	switch status := c.Query("status"); status {
+	case "":
+	case models.DeliveryPending, models.DeliveryProcessing, models.DeliverySucceeded, models.DeliveryFailed:
+		query = query.Where("status = ?", status)
+	default:
+		errorJSON(c, http.StatusBadRequest, "status must be pending, processing, succeeded or failed")
+		return
+	}
+
+	result := WebhookDeliveries{Page: page, Deliveries: []models.WebhookDelivery{}}
+	query.Count(&result.Total)
+	query.Order("id DESC").Offset(page.Offset()).Limit(page.PageSize).Find(&result.Deliveries)
+
+	c.JSON(http.StatusOK, result)
+}
+
+// RetryWebhookDelivery godoc
+// @Summary Reenvia uma entrega
+// @Description Devolve a entrega à fila, com as tentativas zeradas, para ser enviada de novo assim que possível
+// @Tags webhooks
+// @Produce json
+// @Param id path int true "Webhook ID"
+// @Param delivery_id path int true "Delivery ID"
+// @Success 200 {object} models.WebhookDelivery
+// @Failure 404 {object} map[string]string
+// @Router /webhooks/{id}/deliveries/{delivery_id}/retry [post]
+func RetryWebhookDelivery(c *gin.Context) {
+	id, _ := strconv.Atoi(c.Param("id"))
+	deliveryID, _ := strconv.Atoi(c.Param("delivery_id"))
+	var delivery models.WebhookDelivery
+
+	if err := requestDB(c).Where("webhook_id = ?", id).First(&delivery, deliveryID).Error; err != nil {
+		errorJSON(c, http.StatusNotFound, "Delivery not found")
+		return
+	}
+
+	requestDB(c).Model(&delivery).Updates(map[string]interface{}{
+		"status":          models.DeliveryPending,
+		"attempts":        0,
+		"next_attempt_at": time.Now(),
+	})
+
+	requestDB(c).First(&delivery, delivery.ID)
+	c.JSON(http.StatusOK, delivery)
+}
+
+// validateWebhook confere a URL e os filtros de evento
+func validateWebhook(hook *models.Webhook) error {
+	hook.URL = strings.TrimSpace(hook.URL)
+	u, err := url.Parse(hook.URL)
+	if err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+		return errors.New("url must be an absolute http or https URL")
+	}
+	if err := webhooks.CheckHost(u.Hostname()); err != nil {
+		return err
+	}
+
+	for i, filter := range hook.Events {
+		hook.Events[i] = strings.TrimSpace(filter)
+		if !webhooks.ValidFilter(hook.Events[i]) {
+			return errors.New("unknown event " + strconv.Quote(filter))
+		}
+	}
+	return nil
+}
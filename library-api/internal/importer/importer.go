@@ -0,0 +1,355 @@
+package importer
+
+import (
+	"errors"
+	"fmt"
+	"library-api/internal/events"
+	"library-api/internal/models"
+	"strings"
+
+	"gorm.io/gorm"
+)
+
+// Status possíveis de cada linha no relatório
+const (
+	StatusCreated = "created"
+	StatusUpdated = "updated"
+	StatusSkipped = "skipped"
+	StatusFailed  = "failed"
+)
+
+// DefaultBatchSize é usado quando nenhum tamanho de lote é informado
+const DefaultBatchSize = 500
+
+// Credit é um autor da linha, pelo nome, com seu papel no livro
+type Credit struct {
+	Name string
+	Role string // vazio vale como autor
+}
+
+// Row é uma linha lida do arquivo de importação
+type Row struct {
+	Line        int // linha do arquivo, ou posição do registro em MARC
+	Title       string
+	ISBN        string
+	Authors     []Credit
+	Publisher   string
+	Year        int
+	Edition     string
+	Language    string
+	Pages       int
+	Description string
+	Subjects    []string
+	Err         error // erro de leitura da linha, se houver
+}
+
+// Options controla como a importação é executada
+type Options struct {
+	DryRun    bool // executa tudo e desfaz no final
+	Atomic    bool // tudo ou nada em uma única transação
+	BatchSize int  // linhas por transação quando não é atômica
+	Update    bool // atualiza livros já existentes (mesmo ISBN)
+}
+
+// RowResult é o resultado de uma linha no relatório
+type RowResult struct {
+	Line   int    `json:"line"`
+	Title  string `json:"title,omitempty"`
+	ISBN   string `json:"isbn,omitempty"`
+	Status string `json:"status"`
+	Reason string `json:"reason,omitempty"`
+	BookID uint   `json:"book_id,omitempty"`
+}
+
+// Report resume a importação linha a linha
+type Report struct {
+	DryRun    bool        `json:"dry_run"`
+	Atomic    bool        `json:"atomic"`
+	Committed bool        `json:"committed"`
+	Error     string      `json:"error,omitempty"`
+	Total     int         `json:"total"`
+	Created   int         `json:"created"`
+	Updated   int         `json:"updated"`
+	Skipped   int         `json:"skipped"`
+	Failed    int         `json:"failed"`
+	Rows      []RowResult `json:"rows"`
+}
+
+// sentinelas usadas para forçar o rollback da transação
+var (
+	errDryRun  = errors.New("dry run")
+	errAborted = errors.New("import aborted")
+)
+
+// Import grava as linhas no banco e devolve o relatório
+func Import(db *gorm.DB, rows []Row, opts Options) Report {
+	report := Report{DryRun: opts.DryRun, Atomic: opts.Atomic, Total: len(rows)}
+	report.Rows = make([]RowResult, len(rows))
+
+	size := opts.BatchSize
+	if size <= 0 {
+		size = DefaultBatchSize
+	}
+	// Dry-run e modo atômico rodam tudo em uma única transação
+	if opts.DryRun || opts.Atomic {
+		size = len(rows)
+	}
+
+	committed := true
+	for start := 0; start < len(rows); start += size {
+		end := min(start+size, len(rows))
+
+		err := db.Transaction(func(tx *gorm.DB) error {
+			for i := start; i < end; i++ {
+				report.Rows[i] = importRow(tx, rows[i], opts)
+				if opts.Atomic && report.Rows[i].Status == StatusFailed {
+					return errAborted
+				}
+			}
+			if opts.DryRun {
+				return errDryRun
+			}
+			return nil
+		})
+
+		switch {
+		case err == nil:
+		case errors.Is(err, errDryRun):
+			// Os IDs dos livros criados foram desfeitos junto com a transação
+			committed = false
+			for i := start; i < end; i++ {
+				if report.Rows[i].Status == StatusCreated {
+					report.Rows[i].BookID = 0
+				}
+			}
+		case errors.Is(err, errAborted):
+			committed = false
+			report.Error = fmt.Sprintf("line %d failed, no rows were imported", failedLine(report.Rows[start:end]))
+		default:
+			// Falha no commit: nada do lote foi gravado
+			committed = false
+			for i := start; i < end; i++ {
+				report.Rows[i].Status = StatusFailed
+				report.Rows[i].Reason = err.Error()
+				report.Rows[i].BookID = 0
+			}
+		}
+
+		if errors.Is(err, errAborted) {
+			// Linhas não processadas não aparecem no relatório
+			report.Rows = report.Rows[:failedIndex(report.Rows)+1]
+			break
+		}
+	}
+
+	report.Committed = committed && !opts.DryRun
+	for _, r := range report.Rows {
+		switch r.Status {
+		case StatusCreated:
+			report.Created++
+		case StatusUpdated:
+			report.Updated++
+		case StatusSkipped:
+			report.Skipped++
+		case StatusFailed:
+			report.Failed++
+		}
+	}
+
+	return report
+}
+
+// importRow processa uma linha dentro de um savepoint, para que uma falha
+// não desfaça as outras linhas do mesmo lote
+func importRow(tx *gorm.DB, row Row, opts Options) RowResult {
+	result := RowResult{Line: row.Line, Title: row.Title, ISBN: row.ISBN}
+
+	if row.Err != nil {
+		return failed(result, row.Err.Error())
+	}
+	if row.Title == "" {
+		return failed(result, "title is required")
+	}
+	if row.Language != "" {
+		language, ok := models.NormalizeLanguage(row.Language)
+		if !ok {
+			return failed(result, "language must be an ISO 639 code")
+		}
+		row.Language = language
+	}
+
+	err := tx.Transaction(func(rtx *gorm.DB) error {
+		var existing models.Book
+		if row.ISBN != "" {
+			err := rtx.Unscoped().Where("isbn = ?", row.ISBN).Limit(1).Find(&existing).Error
+			if err != nil {
+				return err
+			}
+		}
+
+		if existing.ID != 0 {
+			if existing.DeletedAt.Valid {
+				result.Status = StatusFailed
+				result.Reason = "ISBN belongs to a deleted book"
+				return nil
+			}
+			if !opts.Update {
+				result.Status = StatusSkipped
+				result.Reason = "book with this ISBN already exists"
+				result.BookID = existing.ID
+				return nil
+			}
+		}
+
+		credits, err := ResolveAuthors(rtx, row.Authors)
+		if err != nil {
+			return err
+		}
+
+		subjects, err := ResolveSubjects(rtx, row.Subjects)
+		if err != nil {
+			return err
+		}
+
+		book := models.Book{
+			Title:           row.Title,
+			ISBN:            row.ISBN,
+			Publisher:       row.Publisher,
+			PublicationYear: row.Year,
+			Edition:         row.Edition,
+			Language:        row.Language,
+			Pages:           row.Pages,
+			Description:     row.Description,
+		}
+
+		if existing.ID != 0 {
+			// Campos vazios na linha mantêm o valor atual
+			if err := rtx.Model(&existing).Updates(book).Error; err != nil {
+				return err
+			}
+			if len(credits) > 0 {
+				if err := models.SetBookAuthors(rtx, existing.ID, credits); err != nil {
+					return err
+				}
+			}
+			if len(subjects) > 0 {
+				if err := rtx.Model(&existing).Association("Subjects").Replace(&subjects); err != nil {
+					return err
+				}
+			}
+			result.Status = StatusUpdated
+			result.BookID = existing.ID
+
+			updated, err := models.LoadBook(rtx, existing.ID)
+			if err != nil {
+				return err
+			}
+			return events.Publish(rtx, events.BookUpdated{Book: updated})
+		}
+
+		book.Available = true
+		book.Subjects = subjects
+		if err := rtx.Create(&book).Error; err != nil {
+			return err
+		}
+		if err := models.SetBookAuthors(rtx, book.ID, credits); err != nil {
+			return err
+		}
+		result.Status = StatusCreated
+		result.BookID = book.ID
+
+		created, err := models.LoadBook(rtx, book.ID)
+		if err != nil {
+			return err
+		}
+		return events.Publish(rtx, events.BookCreated{Book: created})
+	})
+	if err != nil {
+		return failed(result, err.Error())
+	}
+
+	return result
+}
+
+// ResolveAuthors busca os autores pelo nome ou por um nome alternativo
+// (sem diferenciar maiúsculas), cria os que ainda não existem e devolve os créditos na ordem da linha
+func ResolveAuthors(tx *gorm.DB, names []Credit) ([]models.AuthorCredit, error) {
+	var credits []models.AuthorCredit
+	seen := map[string]bool{}
+
+	for _, credit := range names {
+		name := strings.TrimSpace(credit.Name)
+		key := strings.ToLower(name)
+		if name == "" || seen[key] {
+			continue
+		}
+		seen[key] = true
+
+		var author models.Author
+		err := tx.Where("LOWER(name) = ? OR id IN (?)", key,
+			tx.Model(&models.AuthorAlias{}).Select("author_id").Where("LOWER(name) = ?", key)).
+			Order("id").Limit(1).Find(&author).Error
+		if err != nil {
+			return nil, err
+		}
+		if author.ID == 0 {
+			author.Name = name
+			author.SortName = models.SortName(name)
+			if err := tx.Create(&author).Error; err != nil {
+				return nil, err
+			}
+		}
+		credits = append(credits, models.AuthorCredit{AuthorID: author.ID, Role: credit.Role})
+	}
+
+	return credits, nil
+}
+
+// ResolveSubjects busca os assuntos pelo nome e cria os que faltam
+func ResolveSubjects(tx *gorm.DB, names []string) ([]models.Subject, error) {
+	var subjects []models.Subject
+	seen := map[string]bool{}
+
+	for _, name := range names {
+		name = strings.TrimSpace(name)
+		key := strings.ToLower(name)
+		if name == "" || seen[key] {
+			continue
+		}
+		seen[key] = true
+
+		var subject models.Subject
+		if err := tx.Where("LOWER(name) = ?", key).Limit(1).Find(&subject).Error; err != nil {
+			return nil, err
+		}
+		if subject.ID == 0 {
+			subject.Name = name
+			if err := tx.Create(&subject).Error; err != nil {
+				return nil, err
+			}
+		}
+		subjects = append(subjects, subject)
+	}
+
+	return subjects, nil
+}
+
+func failed(result RowResult, reason string) RowResult {
+	result.Status = StatusFailed
+	result.Reason = reason
+	result.BookID = 0
+	return result
+}
+
+func failedIndex(rows []RowResult) int {
+	for i, r := range rows {
+		if r.Status == StatusFailed {
+			return i
+		}
+	}
+	return len(rows) - 1
+}
+
+func failedLine(rows []RowResult) int {
+	return rows[failedIndex(rows)].Line
+}
@@ -0,0 +1,186 @@
+package importer
+
+import (
+	"library-api/internal/models"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+
+	"gorm.io/driver/sqlite"
+	"gorm.io/gorm"
+	"gorm.io/gorm/logger"
+)
+
+func openImporter(t *testing.T) *gorm.DB {
+	t.Helper()
+	db, err := gorm.Open(sqlite.Open(filepath.Join(t.TempDir(), "import.db")), &gorm.Config{Logger: logger.Discard})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := db.SetupJoinTable(&models.Book{}, "Authors", &models.BookAuthor{}); err != nil {
+		t.Fatal(err)
+	}
+	if err := db.SetupJoinTable(&models.Author{}, "Books", &models.BookAuthor{}); err != nil {
+		t.Fatal(err)
+	}
+	if err := db.AutoMigrate(&models.Book{}, &models.Author{}, &models.AuthorAlias{}, &models.Subject{},
+		&models.Series{}, &models.BookAuthor{}, &models.OutboxEvent{}); err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() {
+		sqlDB, _ := db.DB()
+		sqlDB.Close()
+	})
+	return db
+}
+
+func count(t *testing.T, db *gorm.DB, model interface{}) int64 {
+	t.Helper()
+	var n int64
+	if err := db.Model(model).Count(&n).Error; err != nil {
+		t.Fatal(err)
+	}
+	return n
+}
+
+func TestImport(t *testing.T) {
+	tests := []struct {
+		name  string
+		setup func(t *testing.T, db *gorm.DB)
+		rows  []Row
+		opts  Options
+
+		statuses  []string
+		committed bool
+		reportErr string
+		books     int64
+		authors   int64
+		subjects  int64
+		events    int64
+	}{
+		{
+			name: "atomic rolls back on a bad row",
+			rows: []Row{
+				{Line: 2, Title: "Duna", ISBN: "9788576573135", Authors: []Credit{{Name: "Frank Herbert"}}, Subjects: []string{"Ficção científica"}},
+				{Line: 3, Title: "", ISBN: "9780000000019", Authors: []Credit{{Name: "Ninguém"}}},
+				{Line: 4, Title: "O Hobbit"},
+			},
+			opts:      Options{Atomic: true},
+			statuses:  []string{StatusCreated, StatusFailed},
+			reportErr: "line 3 failed",
+		},
+		{
+			// A linha que falha no banco desfaz só o próprio savepoint,
+			// inclusive o autor que ela criou
+			name: "batch keeps the other rows when one fails",
+			setup: func(t *testing.T, db *gorm.DB) {
+				err := db.Exec(`CREATE TRIGGER reject_book BEFORE INSERT ON books
+					WHEN NEW.title = 'Rejeitado' BEGIN SELECT RAISE(ABORT, 'rejected'); END`).Error
+				if err != nil {
+					t.Fatal(err)
+				}
+			},
+			rows: []Row{
+				{Line: 2, Title: "Duna", ISBN: "9788576573135", Authors: []Credit{{Name: "Frank Herbert"}}},
+				{Line: 3, Title: "Rejeitado", ISBN: "9780000000002", Authors: []Credit{{Name: "Autor Rejeitado"}}, Subjects: []string{"Rejeitado"}},
+				{Line: 4, Title: "O Hobbit", ISBN: "9780261103344", Language: "12"},
+				{Line: 5, Title: "Dom Casmurro", ISBN: "8508064581", Authors: []Credit{{Name: "Machado de Assis"}}},
+			},
+			opts:      Options{BatchSize: 2},
+			statuses:  []string{StatusCreated, StatusFailed, StatusFailed, StatusCreated},
+			committed: true,
+			books:     2,
+			authors:   2,
+			events:    2,
+		},
+		{
+			name: "dry run writes nothing",
+			rows: []Row{
+				{Line: 2, Title: "Duna", ISBN: "9788576573135", Authors: []Credit{{Name: "Frank Herbert"}}, Subjects: []string{"Ficção científica"}},
+				{Line: 3, Title: "Dom Casmurro", ISBN: "8508064581", Authors: []Credit{{Name: "Machado de Assis"}}},
+			},
+			opts:     Options{DryRun: true},
+			statuses: []string{StatusCreated, StatusCreated},
+		},
+		{
+			name: "reuses existing authors and subjects",
+			setup: func(t *testing.T, db *gorm.DB) {
+				author := models.Author{Name: "Machado de Assis", SortName: "Assis, Machado de"}
+				db.Create(&author)
+				db.Create(&models.AuthorAlias{AuthorID: author.ID, Name: "Joaquim Maria Machado de Assis"})
+				db.Create(&models.Subject{Name: "Romance brasileiro"})
+			},
+			rows: []Row{
+				{Line: 2, Title: "Dom Casmurro", ISBN: "8508064581", Authors: []Credit{{Name: "MACHADO DE ASSIS"}}, Subjects: []string{"romance brasileiro"}},
+				{Line: 3, Title: "Quincas Borba", ISBN: "9788525406316", Authors: []Credit{{Name: "Joaquim Maria Machado de Assis"}}, Subjects: []string{"Romance Brasileiro"}},
+			},
+			statuses:  []string{StatusCreated, StatusCreated},
+			committed: true,
+			books:     2,
+			authors:   1,
+			subjects:  1,
+			events:    2,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			db := openImporter(t)
+			if tt.setup != nil {
+				tt.setup(t, db)
+			}
+
+			report := Import(db, tt.rows, tt.opts)
+
+			var statuses []string
+			for _, r := range report.Rows {
+				statuses = append(statuses, r.Status)
+				if tt.opts.DryRun && r.BookID != 0 {
+					t.Errorf("line %d: book_id = %d in a dry run", r.Line, r.BookID)
+				}
+			}
+			if !reflect.DeepEqual(statuses, tt.statuses) {
+				t.Errorf("statuses = %v, want %v", statuses, tt.statuses)
+			}
+			if report.Committed != tt.committed {
+				t.Errorf("committed = %v, want %v", report.Committed, tt.committed)
+			}
+			if !strings.Contains(report.Error, tt.reportErr) || (tt.reportErr == "") != (report.Error == "") {
+				t.Errorf("error = %q, want %q", report.Error, tt.reportErr)
+			}
+
+			if got := count(t, db, &models.Book{}); got != tt.books {
+				t.Errorf("%d books stored, want %d", got, tt.books)
+			}
+			if got := count(t, db, &models.Author{}); got != tt.authors {
+				t.Errorf("%d authors stored, want %d", got, tt.authors)
+			}
+			if got := count(t, db, &models.Subject{}); got != tt.subjects {
+				t.Errorf("%d subjects stored, want %d", got, tt.subjects)
+			}
+			if got := count(t, db, &models.OutboxEvent{}); got != tt.events {
+				t.Errorf("%d events published, want %d", got, tt.events)
+			}
+		})
+	}
+}
+
+func TestImportReusedAuthorIsCredited(t *testing.T) {
+	db := openImporter(t)
+	author := models.Author{Name: "Machado de Assis", SortName: "Assis, Machado de"}
+	db.Create(&author)
+
+	report := Import(db, []Row{{Line: 2, Title: "Dom Casmurro", ISBN: "8508064581", Authors: []Credit{{Name: " machado de assis "}}}}, Options{})
+	if report.Created != 1 {
+		t.Fatalf("report = %+v, want one created row", report)
+	}
+
+	book, err := models.LoadBook(db, report.Rows[0].BookID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(book.Authors) != 1 || book.Authors[0].ID != author.ID {
+		t.Errorf("authors = %+v, want author %d", book.Authors, author.ID)
+	}
+}
@@ -0,0 +1,241 @@
+package importer
+
+import (
+	"bufio"
+	"encoding/csv"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"library-api/internal/marc"
+	"strconv"
+	"strings"
+)
+
+// Formatos de arquivo aceitos
+const (
+	FormatCSV     = "csv"
+	FormatJSONL   = "jsonl"
+	FormatMARC21  = "marc21"
+	FormatMARCXML = "marcxml"
+)
+
+// jsonlRow é o formato de cada linha JSONL
+type jsonlRow struct {
+	Title           string   `json:"title"`
+	ISBN            string   `json:"isbn"`
+	Authors         []string `json:"authors"`
+	Publisher       string   `json:"publisher"`
+	PublicationYear int      `json:"publication_year"`
+	Edition         string   `json:"edition"`
+	Language        string   `json:"language"`
+	Pages           int      `json:"pages"`
+	Description     string   `json:"description"`
+	Subjects        []string `json:"subjects"`
+}
+
+// Parse lê as linhas no formato informado
+func Parse(r io.Reader, format string) ([]Row, error) {
+	switch format {
+	case FormatCSV:
+		return ParseCSV(r)
+	case FormatJSONL:
+		return ParseJSONL(r)
+	case FormatMARC21:
+		return parseMARC(marc.NewReader(r))
+	case FormatMARCXML:
+		return parseMARC(marc.NewXMLReader(r))
+	default:
+		return nil, fmt.Errorf("unsupported format %q", format)
+	}
+}
+
+// ParseCSV lê um CSV com cabeçalho contendo title, isbn e authors, e
+// opcionalmente publisher, publication_year, edition, language, pages,
+// description e subjects. Autores e assuntos são separados por ";" ou "|".
+func ParseCSV(r io.Reader) ([]Row, error) {
+	reader := csv.NewReader(r)
+	reader.FieldsPerRecord = -1
+	reader.TrimLeadingSpace = true
+
+	header, err := reader.Read()
+	if err == io.EOF {
+		return nil, errors.New("empty CSV file")
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	columns := map[string]int{}
+	for i, name := range header {
+		name = strings.ToLower(strings.TrimSpace(strings.TrimPrefix(name, "\ufeff")))
+		columns[name] = i
+	}
+	if _, ok := columns["title"]; !ok {
+		return nil, errors.New("CSV header must contain a title column")
+	}
+	// Aceita também os nomes de coluna no singular
+	for column, alias := range map[string]string{"authors": "author", "subjects": "subject", "publication_year": "year"} {
+		if _, ok := columns[column]; !ok {
+			if i, ok := columns[alias]; ok {
+				columns[column] = i
+			}
+		}
+	}
+
+	field := func(record []string, name string) string {
+		i, ok := columns[name]
+		if !ok || i >= len(record) {
+			return ""
+		}
+		return strings.TrimSpace(record[i])
+	}
+
+	var rows []Row
+	for {
+		record, err := reader.Read()
+		if err == io.EOF {
+			break
+		}
+
+		var parseErr *csv.ParseError
+		if errors.As(err, &parseErr) {
+			rows = append(rows, Row{Line: parseErr.StartLine, Err: parseErr.Err})
+			continue
+		}
+		if err != nil {
+			return nil, err
+		}
+
+		line, _ := reader.FieldPos(0)
+		row := Row{
+			Line:        line,
+			Title:       field(record, "title"),
+			ISBN:        field(record, "isbn"),
+			Authors:     credits(splitList(field(record, "authors"))),
+			Publisher:   field(record, "publisher"),
+			Edition:     field(record, "edition"),
+			Language:    field(record, "language"),
+			Description: field(record, "description"),
+			Subjects:    splitList(field(record, "subjects")),
+		}
+		if row.Year, err = number(field(record, "publication_year")); err != nil {
+			row.Err = errors.New("invalid publication_year")
+		}
+		if row.Pages, err = number(field(record, "pages")); err != nil {
+			row.Err = errors.New("invalid pages")
+		}
+		rows = append(rows, row)
+	}
+
+	return rows, nil
+}
+
+// ParseJSONL lê um objeto JSON por linha; linhas em branco são ignoradas
+func ParseJSONL(r io.Reader) ([]Row, error) {
+	scanner := bufio.NewScanner(r)
+	scanner.Buffer(make([]byte, 64*1024), 1024*1024)
+
+	var rows []Row
+	line := 0
+	for scanner.Scan() {
+		line++
+		text := strings.TrimSpace(scanner.Text())
+		if text == "" {
+			continue
+		}
+
+		var item jsonlRow
+		if err := json.Unmarshal([]byte(text), &item); err != nil {
+			rows = append(rows, Row{Line: line, Err: fmt.Errorf("invalid JSON: %w", err)})
+			continue
+		}
+
+		rows = append(rows, Row{
+			Line:        line,
+			Title:       strings.TrimSpace(item.Title),
+			ISBN:        strings.TrimSpace(item.ISBN),
+			Authors:     credits(item.Authors),
+			Publisher:   strings.TrimSpace(item.Publisher),
+			Year:        item.PublicationYear,
+			Edition:     strings.TrimSpace(item.Edition),
+			Language:    item.Language,
+			Pages:       item.Pages,
+			Description: strings.TrimSpace(item.Description),
+			Subjects:    item.Subjects,
+		})
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+
+	return rows, nil
+}
+
+// marcReader é implementado pelos leitores binário e XML
+type marcReader interface {
+	Read() (marc.Record, error)
+}
+
+// parseMARC converte cada registro MARC em uma linha; Line passa a ser a
+// posição do registro no arquivo
+func parseMARC(reader marcReader) ([]Row, error) {
+	var rows []Row
+	for n := 1; ; n++ {
+		rec, err := reader.Read()
+		if err == io.EOF {
+			break
+		}
+		if errors.Is(err, marc.ErrMalformed) {
+			rows = append(rows, Row{Line: n, Err: err})
+			continue
+		}
+		if err != nil {
+			return nil, fmt.Errorf("record %d: %w", n, err)
+		}
+
+		bib := rec.Bibliographic()
+		authors := make([]Credit, 0, len(bib.Authors))
+		for _, a := range bib.Authors {
+			authors = append(authors, Credit{Name: a.Name, Role: a.Role})
+		}
+		rows = append(rows, Row{
+			Line:        n,
+			Title:       bib.Title,
+			ISBN:        bib.ISBN,
+			Authors:     authors,
+			Publisher:   bib.Publisher,
+			Year:        bib.Year,
+			Edition:     bib.Edition,
+			Language:    bib.Language,
+			Pages:       bib.Pages,
+			Description: bib.Description,
+			Subjects:    bib.Subjects,
+		})
+	}
+
+	return rows, nil
+}
+
+func splitList(value string) []string {
+	return strings.FieldsFunc(value, func(r rune) bool {
+		return r == ';' || r == '|'
+	})
+}
+
+// credits transforma nomes em créditos de autor
+func credits(names []string) []Credit {
+	list := make([]Credit, 0, len(names))
+	for _, name := range names {
+		list = append(list, Credit{Name: name})
+	}
+	return list
+}
+
+// number converte um campo numérico opcional
+func number(value string) (int, error) {
+	if value == "" {
+		return 0, nil
+	}
+	return strconv.Atoi(value)
+}
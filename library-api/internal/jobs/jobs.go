@@ -0,0 +1,75 @@
+package jobs
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"os"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/robfig/cron/v3"
+	"gorm.io/gorm"
+)
+
+// DefaultTimeout é quanto um job pode rodar se não definir o seu
+const DefaultTimeout = 10 * time.Minute
+
+// Func é o trabalho de um job. O texto devolvido resume o que foi feito
+// ("3 loans marked overdue") e aparece em GET /admin/jobs.
+type Func func(ctx context.Context, db *gorm.DB) (string, error)
+
+// Job é um trabalho periódico registrado no processo
+type Job struct {
+	Name     string
+	Schedule string        // expressão cron de 5 campos ou @hourly, @every 10m...
+	Timeout  time.Duration // zero usa DefaultTimeout
+	Run      Func
+
+	schedule cron.Schedule
+}
+
+var (
+	mu       sync.Mutex
+	registry []*Job
+)
+
+// Register adiciona um job ao agendador. A variável JOB_SCHEDULE_<NOME>
+// (com "-" trocado por "_") substitui o agendamento; "off" desativa o job.
+func Register(job Job) error {
+	variable := "JOB_SCHEDULE_" + strings.ToUpper(strings.ReplaceAll(job.Name, "-", "_"))
+	if value := strings.TrimSpace(os.Getenv(variable)); value != "" {
+		if strings.EqualFold(value, "off") {
+			slog.Info("Job disabled", "job", job.Name, "variable", variable)
+			return nil
+		}
+		job.Schedule = value
+	}
+
+	schedule, err := cron.ParseStandard(job.Schedule)
+	if err != nil {
+		return fmt.Errorf("job %s: invalid schedule %q: %w", job.Name, job.Schedule, err)
+	}
+	job.schedule = schedule
+	if job.Timeout <= 0 {
+		job.Timeout = DefaultTimeout
+	}
+
+	mu.Lock()
+	defer mu.Unlock()
+	for _, other := range registry {
+		if other.Name == job.Name {
+			return fmt.Errorf("job %s already registered", job.Name)
+		}
+	}
+	registry = append(registry, &job)
+	return nil
+}
+
+// registered devolve uma cópia da lista de jobs
+func registered() []*Job {
+	mu.Lock()
+	defer mu.Unlock()
+	return append([]*Job(nil), registry...)
+}
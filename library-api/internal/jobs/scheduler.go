@@ -0,0 +1,177 @@
+package jobs
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"library-api/internal/models"
+	"log/slog"
+	"os"
+	"sync"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// pollInterval é de quanto em quanto tempo o agendador procura jobs
+// vencidos; lockMargin é a folga da trava além do timeout do job
+const (
+	pollInterval = 5 * time.Second
+	lockMargin   = time.Minute
+)
+
+// Instance identifica este processo nas travas dos jobs
+var Instance = instanceID()
+
+func instanceID() string {
+	host, err := os.Hostname()
+	if err != nil {
+		host = "unknown"
+	}
+	return fmt.Sprintf("%s-%d", host, os.Getpid())
+}
+
+// Start sincroniza os jobs registrados com a tabela jobs e passa a rodá-los
+// em segundo plano quando vencem. Cada execução pega antes a trava do job
+// no banco, de forma que várias réplicas não rodem o mesmo job ao mesmo
+// tempo. A função devolvida para o agendador e espera os jobs em andamento.
+func Start(db *gorm.DB) func() {
+	jobs := registered()
+	now := time.Now()
+	for _, job := range jobs {
+		if err := save(db, job, now); err != nil {
+			slog.Error("Failed to register job", "job", job.Name, "error", err)
+		}
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	var running sync.WaitGroup
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		ticker := time.NewTicker(pollInterval)
+		defer ticker.Stop()
+		for {
+			for _, job := range jobs {
+				if !claim(db, job, time.Now()) {
+					continue
+				}
+				running.Add(1)
+				go func() {
+					defer running.Done()
+					execute(ctx, db, job)
+				}()
+			}
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+			}
+		}
+	}()
+
+	return func() {
+		cancel()
+		<-done
+		running.Wait()
+	}
+}
+
+// save grava o job na tabela ou atualiza o agendamento. A próxima
+// execução só é recalculada se o agendamento mudou; assim um job que
+// venceu com o servidor parado roda logo na subida.
+func save(db *gorm.DB, job *Job, now time.Time) error {
+	var row models.Job
+	err := db.Where("name = ?", job.Name).Limit(1).Find(&row).Error
+	if err != nil {
+		return err
+	}
+
+	next := job.schedule.Next(now)
+	if row.Name == "" {
+		return db.Create(&models.Job{Name: job.Name, Schedule: job.Schedule, NextRunAt: &next}).Error
+	}
+	if row.Schedule != job.Schedule || row.NextRunAt == nil {
+		return db.Model(&models.Job{}).Where("name = ?", job.Name).
+			Updates(map[string]interface{}{"schedule": job.Schedule, "next_run_at": next}).Error
+	}
+	return nil
+}
+
+// claim pega a trava do job se ele venceu e ninguém o está rodando. As
+// datas passam por julianday para comparar instantes, não o texto com o
+// deslocamento do fuso de quem as gravou.
+func claim(db *gorm.DB, job *Job, now time.Time) bool {
+	result := db.Model(&models.Job{}).
+		Where("name = ? AND julianday(next_run_at) <= julianday(?) AND (locked_until IS NULL OR julianday(locked_until) < julianday(?))", job.Name, now, now).
+		Updates(map[string]interface{}{
+			"locked_by":       Instance,
+			"locked_until":    now.Add(job.Timeout + lockMargin),
+			"last_started_at": now,
+		})
+	if result.Error != nil {
+		slog.Error("Failed to claim job", "job", job.Name, "error", result.Error)
+		return false
+	}
+	return result.RowsAffected == 1
+}
+
+// execute roda o job, grava o resultado e solta a trava. Um job
+// interrompido pelo desligamento não conta como execução e fica vencido
+// para a próxima instância.
+func execute(ctx context.Context, db *gorm.DB, job *Job) {
+	ctx, cancel := context.WithTimeout(ctx, job.Timeout)
+	defer cancel()
+
+	started := time.Now()
+	result, err := run(ctx, db, job)
+	finished := time.Now()
+	release := db.Model(&models.Job{}).Where("name = ? AND locked_by = ?", job.Name, Instance)
+
+	if errors.Is(err, context.Canceled) {
+		if err := release.Updates(map[string]interface{}{"locked_by": nil, "locked_until": nil}).Error; err != nil {
+			slog.Error("Failed to release job", "job", job.Name, "error", err)
+		}
+		return
+	}
+
+	update := map[string]interface{}{
+		"last_finished_at": finished,
+		"last_status":      models.JobSucceeded,
+		"last_result":      result,
+		"last_error":       "",
+		"last_duration_ms": finished.Sub(started).Milliseconds(),
+		"runs":             gorm.Expr("runs + 1"),
+		"next_run_at":      job.schedule.Next(finished),
+		"locked_by":        nil,
+		"locked_until":     nil,
+	}
+	if err != nil {
+		update["last_status"] = models.JobFailed
+		update["last_error"] = err.Error()
+		update["failures"] = gorm.Expr("failures + 1")
+		slog.Error("Job failed", "job", job.Name, "duration_ms", finished.Sub(started).Milliseconds(), "error", err)
+	} else {
+		slog.Info("Job finished", "job", job.Name, "duration_ms", finished.Sub(started).Milliseconds(), "result", result)
+	}
+
+	if err := release.Updates(update).Error; err != nil {
+		slog.Error("Failed to record job result", "job", job.Name, "error", err)
+	}
+}
+
+// run chama o job tratando um panic como erro
+func run(ctx context.Context, db *gorm.DB, job *Job) (result string, err error) {
+	defer func() {
+		if r := recover(); r != nil {
+			err = fmt.Errorf("panic: %v", r)
+		}
+	}()
+	return job.Run(ctx, db.WithContext(ctx))
+}
+
+// Trigger antecipa a próxima execução do job para agora
+func Trigger(db *gorm.DB, name string) (bool, error) {
+	result := db.Model(&models.Job{}).Where("name = ?", name).Update("next_run_at", time.Now())
+	return result.RowsAffected == 1, result.Error
+}
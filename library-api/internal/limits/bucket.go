@@ -0,0 +1,154 @@
+package limits
+
+import (
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+// Limit é uma cota de Requests requisições a cada Per. O balde começa
+// cheio, então até Requests requisições podem chegar de uma vez.
+type Limit struct {
+	Requests int
+	Per      time.Duration
+}
+
+// ParseLimit lê limites como "120/m", "10/s" ou "1000/h"; "off" devolve
+// um limite zero, que desativa o controle
+func ParseLimit(s string) (Limit, error) {
+	s = strings.TrimSpace(strings.ToLower(s))
+	if s == "off" || s == "0" {
+		return Limit{}, nil
+	}
+
+	n, unit, ok := strings.Cut(s, "/")
+	requests, err := strconv.Atoi(n)
+	if !ok || err != nil || requests < 0 {
+		return Limit{}, fmt.Errorf("invalid rate limit %q", s)
+	}
+
+	var per time.Duration
+	switch unit {
+	case "s":
+		per = time.Second
+	case "m":
+		per = time.Minute
+	case "h":
+		per = time.Hour
+	default:
+		return Limit{}, fmt.Errorf("invalid rate limit unit in %q (use s, m or h)", s)
+	}
+	return Limit{Requests: requests, Per: per}, nil
+}
+
+// Enabled indica se o limite deve ser aplicado
+func (l Limit) Enabled() bool {
+	return l.Requests > 0 && l.Per > 0
+}
+
+// perToken é o tempo para repor uma requisição no balde
+func (l Limit) perToken() time.Duration {
+	return l.Per / time.Duration(l.Requests)
+}
+
+// Result é a decisão para uma requisição
+type Result struct {
+	Allowed    bool
+	Remaining  int           // requisições que ainda cabem agora
+	Reset      time.Duration // até o balde encher de novo
+	RetryAfter time.Duration // até caber a próxima requisição, se negada
+}
+
+type bucket struct {
+	tokens float64
+	last   time.Time
+}
+
+// MaxBuckets é quantos baldes um limitador guarda; com o mapa cheio, os
+// baldes que já estariam cheios são descartados e, se ainda faltar
+// espaço, um balde qualquer
+const MaxBuckets = 100000
+
+// Limiter guarda um balde de fichas por chave (API key ou IP)
+type Limiter struct {
+	limit Limit
+	now   func() time.Time
+
+	mu        sync.Mutex
+	buckets   map[string]*bucket
+	lastSweep time.Time
+}
+
+// NewLimiter cria um limitador com a cota informada
+func NewLimiter(limit Limit) *Limiter {
+	return &Limiter{limit: limit, now: time.Now, buckets: map[string]*bucket{}}
+}
+
+// Allow consome uma ficha do balde da chave, se houver
+func (l *Limiter) Allow(key string) Result {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	now := l.now()
+	l.sweep(now)
+
+	capacity := float64(l.limit.Requests)
+	perToken := l.limit.perToken()
+
+	b, ok := l.buckets[key]
+	if !ok {
+		if len(l.buckets) >= MaxBuckets {
+			l.evict(now)
+		}
+		b = &bucket{tokens: capacity, last: now}
+		l.buckets[key] = b
+	}
+	b.tokens = math.Min(capacity, b.tokens+float64(now.Sub(b.last))/float64(perToken))
+	b.last = now
+
+	result := Result{Allowed: b.tokens >= 1}
+	if result.Allowed {
+		b.tokens--
+	} else {
+		result.RetryAfter = time.Duration((1 - b.tokens) * float64(perToken))
+	}
+	result.Remaining = int(b.tokens)
+	result.Reset = time.Duration((capacity - b.tokens) * float64(perToken))
+	return result
+}
+
+// sweep descarta, no máximo uma vez por período, os baldes que já
+// estariam cheios; recriá-los dá no mesmo
+func (l *Limiter) sweep(now time.Time) {
+	if now.Sub(l.lastSweep) < l.limit.Per {
+		return
+	}
+	l.lastSweep = now
+	l.prune(now)
+}
+
+// prune descarta os baldes que já estariam cheios
+func (l *Limiter) prune(now time.Time) {
+	for key, b := range l.buckets {
+		if now.Sub(b.last) >= l.limit.Per {
+			delete(l.buckets, key)
+		}
+	}
+}
+
+// evict abre espaço no mapa cheio: primeiro descarta os baldes que já
+// estariam cheios; se não bastar, descarta 1% dos baldes, ao acaso, para
+// que as próximas chaves novas não precisem varrer o mapa de novo. Os
+// clientes descartados voltam a ter a cota inteira.
+func (l *Limiter) evict(now time.Time) {
+	l.prune(now)
+	for key := range l.buckets {
+		if len(l.buckets) < MaxBuckets-MaxBuckets/100 {
+			return
+		}
+		delete(l.buckets, key)
+	}
+}
@@ -0,0 +1,41 @@
+package limits
+
+import (
+	"strconv"
+	"testing"
+	"time"
+)
+
+func TestLimiterAllow(t *testing.T) {
+	now := time.Date(2025, 1, 1, 12, 0, 0, 0, time.UTC)
+	l := NewLimiter(Limit{Requests: 2, Per: time.Minute})
+	l.now = func() time.Time { return now }
+
+	for i, want := range []bool{true, true, false} {
+		if got := l.Allow("ip:1").Allowed; got != want {
+			t.Errorf("request %d: allowed = %v, want %v", i, got, want)
+		}
+	}
+
+	// Uma ficha é reposta a cada 30 segundos
+	now = now.Add(30 * time.Second)
+	if !l.Allow("ip:1").Allowed {
+		t.Error("request after refill was denied")
+	}
+	if l.Allow("ip:1").Allowed {
+		t.Error("second request after refill was allowed")
+	}
+}
+
+func TestLimiterCapsBuckets(t *testing.T) {
+	now := time.Date(2025, 1, 1, 12, 0, 0, 0, time.UTC)
+	l := NewLimiter(Limit{Requests: 10, Per: time.Minute})
+	l.now = func() time.Time { return now }
+
+	for i := 0; i < MaxBuckets+5000; i++ {
+		l.Allow("ip:" + strconv.Itoa(i))
+	}
+	if n := len(l.buckets); n > MaxBuckets {
+		t.Errorf("limiter kept %d buckets, want at most %d", n, MaxBuckets)
+	}
+}
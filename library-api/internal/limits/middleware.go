@@ -0,0 +1,163 @@
+package limits
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"library-api/internal/logging"
+	"log/slog"
+	"math"
+	"net/http"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/gin-gonic/gin"
+)
+
+// APIKeyHeader identifica o cliente pela API key, se ela estiver entre as
+// chaves conhecidas (SetAPIKeys)
+const APIKeyHeader = "X-API-Key"
+
+// apiKeys são as chaves que têm cota própria; qualquer outro valor no
+// cabeçalho é ignorado, senão bastaria trocá-lo a cada requisição para
+// ganhar um balde novo
+var apiKeys = map[string]bool{}
+
+// SetAPIKeys define as chaves aceitas em X-API-Key. Deve ser chamada na
+// inicialização, antes de o servidor receber requisições.
+func SetAPIKeys(keys []string) {
+	apiKeys = make(map[string]bool, len(keys))
+	for _, key := range keys {
+		apiKeys[key] = true
+	}
+}
+
+// APIKeysFromEnv lê as chaves conhecidas de API_KEYS, separadas por vírgula
+func APIKeysFromEnv() []string {
+	var keys []string
+	for _, key := range strings.Split(os.Getenv("API_KEYS"), ",") {
+		if key = strings.TrimSpace(key); key != "" {
+			keys = append(keys, key)
+		}
+	}
+	return keys
+}
+
+// Cabeçalhos das respostas, conforme o rascunho "RateLimit header fields
+// for HTTP" do IETF
+const (
+	HeaderLimit     = "RateLimit-Limit"
+	HeaderRemaining = "RateLimit-Remaining"
+	HeaderReset     = "RateLimit-Reset"
+	HeaderPolicy    = "RateLimit-Policy"
+)
+
+// FromEnv lê o limite de um grupo de rotas em RATE_LIMIT_<NOME> (por
+// exemplo RATE_LIMIT_REPORTS=30/m), ou usa o padrão informado
+func FromEnv(name string, fallback Limit) Limit {
+	variable := "RATE_LIMIT_" + strings.ToUpper(name)
+	value := os.Getenv(variable)
+	if value == "" {
+		return fallback
+	}
+	limit, err := ParseLimit(value)
+	if err != nil {
+		slog.Warn("Ignoring invalid rate limit", "variable", variable, "error", err)
+		return fallback
+	}
+	return limit
+}
+
+// RateLimit aplica a cota a cada cliente. Cada chamada cria baldes
+// próprios, então grupos de rotas diferentes têm cotas independentes.
+func RateLimit(limit Limit) gin.HandlerFunc {
+	if !limit.Enabled() {
+		return func(c *gin.Context) { c.Next() }
+	}
+
+	limiter := NewLimiter(limit)
+	policy := fmt.Sprintf("%d;w=%d", limit.Requests, int(limit.Per.Seconds()))
+
+	return func(c *gin.Context) {
+		result := limiter.Allow(clientKey(c))
+
+		h := c.Writer.Header()
+		h.Set(HeaderLimit, strconv.Itoa(limit.Requests))
+		h.Set(HeaderRemaining, strconv.Itoa(result.Remaining))
+		h.Set(HeaderReset, strconv.Itoa(seconds(result.Reset)))
+		h.Set(HeaderPolicy, policy)
+
+		if !result.Allowed {
+			h.Set("Retry-After", strconv.Itoa(seconds(result.RetryAfter)))
+			c.AbortWithStatusJSON(http.StatusTooManyRequests, gin.H{
+				"error":      "Too many requests",
+				"request_id": logging.RequestIDFrom(c.Request.Context()),
+			})
+			return
+		}
+		c.Next()
+	}
+}
+
+// clientKey identifica o cliente pela API key, se for conhecida, ou pelo IP
+func clientKey(c *gin.Context) string {
+	if key := c.GetHeader(APIKeyHeader); key != "" && apiKeys[key] {
+		return "key:" + key
+	}
+	return "ip:" + c.ClientIP()
+}
+
+// seconds arredonda para cima, para o cliente não voltar cedo demais
+func seconds(d time.Duration) int {
+	return int(math.Ceil(d.Seconds()))
+}
+
+// limitKey guarda no contexto do Gin o limite do corpo da rota
+const limitKey = "limits:body"
+
+// MaxBodySize limita o corpo da requisição a n bytes. Aplicado de novo num
+// grupo ou rota, o limite mais interno vale: cada chamada só anota o
+// limite, e o corpo é conferido na primeira leitura, quando todos os
+// middlewares da rota já rodaram. Corpos com Content-Length maior falham
+// já nessa leitura, sem que nada seja lido; os demais param de ser lidos
+// ao passar do limite. Em ambos os casos o erro é um *http.MaxBytesError
+// (IsTooLarge), que os handlers respondem com 413.
+func MaxBodySize(n int64) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		if _, ok := c.Get(limitKey); !ok && c.Request.Body != nil && c.Request.Body != http.NoBody {
+			c.Request.Body = &limitedBody{c: c, body: c.Request.Body}
+		}
+		c.Set(limitKey, n)
+		c.Next()
+	}
+}
+
+// limitedBody aplica ao corpo o limite em vigor na primeira leitura
+type limitedBody struct {
+	c      *gin.Context
+	body   io.ReadCloser
+	reader io.ReadCloser
+}
+
+func (b *limitedBody) Read(p []byte) (int, error) {
+	if b.reader == nil {
+		n := b.c.GetInt64(limitKey)
+		if b.c.Request.ContentLength > n {
+			return 0, &http.MaxBytesError{Limit: n}
+		}
+		b.reader = http.MaxBytesReader(b.c.Writer, b.body, n)
+	}
+	return b.reader.Read(p)
+}
+
+func (b *limitedBody) Close() error {
+	return b.body.Close()
+}
+
+// IsTooLarge indica se o erro veio de um corpo maior que o permitido
+func IsTooLarge(err error) bool {
+	var tooLarge *http.MaxBytesError
+	return errors.As(err, &tooLarge)
+}
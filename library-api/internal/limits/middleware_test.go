@@ -0,0 +1,115 @@
+package limits
+
+import (
+	"bytes"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+)
+
+// bodyRouter monta as rotas como em cmd/server: 1 MB por padrão, 32 MB no
+// grupo de importação e 5 MB na rota da capa
+func bodyRouter() *gin.Engine {
+	gin.SetMode(gin.TestMode)
+	r := gin.New()
+	r.Use(MaxBodySize(1 << 20))
+
+	read := func(c *gin.Context) {
+		data, err := io.ReadAll(c.Request.Body)
+		if IsTooLarge(err) {
+			c.Status(http.StatusRequestEntityTooLarge)
+			return
+		}
+		if err != nil {
+			c.Status(http.StatusBadRequest)
+			return
+		}
+		c.String(http.StatusOK, strconv.Itoa(len(data)))
+	}
+
+	r.POST("/books", read)
+	r.PUT("/books/:id/cover", MaxBodySize(5<<20), read)
+	r.Group("/import", MaxBodySize(32<<20)).POST("/books", read)
+	return r
+}
+
+func TestMaxBodySize(t *testing.T) {
+	r := bodyRouter()
+	twoMB := bytes.Repeat([]byte("x"), 2<<20)
+
+	tests := []struct {
+		name    string
+		method  string
+		path    string
+		body    []byte
+		chunked bool // sem Content-Length
+		want    int
+	}{
+		{"import over the default limit", http.MethodPost, "/import/books", twoMB, false, http.StatusOK},
+		{"cover over the default limit", http.MethodPut, "/books/1/cover", twoMB, false, http.StatusOK},
+		{"json over the default limit", http.MethodPost, "/books", twoMB, false, http.StatusRequestEntityTooLarge},
+		{"json over the default limit without length", http.MethodPost, "/books", twoMB, true, http.StatusRequestEntityTooLarge},
+		{"json within the default limit", http.MethodPost, "/books", []byte(`{"title":"Dom Casmurro"}`), false, http.StatusOK},
+		{"cover over its own limit", http.MethodPut, "/books/1/cover", bytes.Repeat([]byte("x"), 6<<20), false, http.StatusRequestEntityTooLarge},
+		{"cover over its own limit without length", http.MethodPut, "/books/1/cover", bytes.Repeat([]byte("x"), 6<<20), true, http.StatusRequestEntityTooLarge},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var body io.Reader = bytes.NewReader(tt.body)
+			if tt.chunked {
+				body = io.MultiReader(body) // esconde o tamanho do httptest
+			}
+			req := httptest.NewRequest(tt.method, tt.path, body)
+			if tt.chunked {
+				req.ContentLength = -1
+			}
+			w := httptest.NewRecorder()
+			r.ServeHTTP(w, req)
+
+			if w.Code != tt.want {
+				t.Fatalf("status = %d, want %d", w.Code, tt.want)
+			}
+			if tt.want == http.StatusOK && w.Body.String() != strconv.Itoa(len(tt.body)) {
+				t.Errorf("read %s bytes, want %d", w.Body.String(), len(tt.body))
+			}
+		})
+	}
+}
+
+func TestRateLimitIgnoresUnknownKeys(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	SetAPIKeys([]string{"known"})
+	defer SetAPIKeys(nil)
+
+	r := gin.New()
+	r.Use(RateLimit(Limit{Requests: 2, Per: time.Minute}))
+	r.GET("/books", func(c *gin.Context) { c.Status(http.StatusOK) })
+
+	get := func(key string) int {
+		req := httptest.NewRequest(http.MethodGet, "/books", nil)
+		if key != "" {
+			req.Header.Set(APIKeyHeader, key)
+		}
+		w := httptest.NewRecorder()
+		r.ServeHTTP(w, req)
+		return w.Code
+	}
+
+	// Trocar a chave a cada requisição não dá um balde novo
+	for i, want := range []int{http.StatusOK, http.StatusOK, http.StatusTooManyRequests} {
+		if code := get("random-" + strconv.Itoa(i)); code != want {
+			t.Errorf("request %d with an unknown key: status = %d, want %d", i, code, want)
+		}
+	}
+
+	// Uma chave conhecida tem cota própria
+	if code := get("known"); code != http.StatusOK {
+		t.Errorf("known key: status = %d, want %d", code, http.StatusOK)
+	}
+}
@@ -0,0 +1,58 @@
+package logging
+
+import (
+	"context"
+	"log/slog"
+	"strings"
+	"time"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+)
+
+// UnaryInterceptor faz no gRPC o que RequestID, AccessLog e Recovery fazem
+// nas rotas HTTP: aceita ou gera o x-request-id (devolvido no cabeçalho
+// da resposta), registra cada chamada e transforma um panic em Internal
+func UnaryInterceptor() grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req any, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (resp any, err error) {
+		start := time.Now()
+
+		var id string
+		if md, ok := metadata.FromIncomingContext(ctx); ok {
+			if values := md.Get(strings.ToLower(RequestIDHeader)); len(values) > 0 {
+				id = values[0]
+			}
+		}
+		if !validRequestID.MatchString(id) {
+			id = newRequestID()
+		}
+		ctx = WithRequestID(ctx, id)
+		grpc.SetHeader(ctx, metadata.Pairs(strings.ToLower(RequestIDHeader), id))
+
+		defer func() {
+			if p := recover(); p != nil {
+				FromContext(ctx).Error("panic recovered", "error", p, "method", info.FullMethod)
+				err = status.Error(codes.Internal, "Internal server error")
+			}
+
+			code := status.Code(err)
+			level := slog.LevelInfo
+			switch code {
+			case codes.OK:
+			case codes.Internal, codes.Unknown, codes.Unavailable, codes.DataLoss:
+				level = slog.LevelError
+			default:
+				level = slog.LevelWarn
+			}
+			FromContext(ctx).Log(ctx, level, "rpc",
+				"method", info.FullMethod,
+				"code", code.String(),
+				"duration_ms", float64(time.Since(start).Microseconds())/1000,
+			)
+		}()
+
+		return handler(ctx, req)
+	}
+}
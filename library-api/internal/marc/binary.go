@@ -0,0 +1,190 @@
+package marc
+
+import (
+	"bufio"
+	"bytes"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+)
+
+// Reader lê registros MARC21 no formato binário ISO 2709.
+// Registros em MARC-8 (posição 09 do líder em branco) são lidos sem
+// conversão, o que só é confiável para texto ASCII.
+type Reader struct {
+	r *bufio.Reader
+}
+
+// NewReader cria o leitor de MARC21 binário
+func NewReader(r io.Reader) *Reader {
+	return &Reader{r: bufio.NewReader(r)}
+}
+
+// Read devolve o próximo registro ou io.EOF no fim do arquivo. Erros que
+// envolvem ErrMalformed afetam só o registro atual.
+func (r *Reader) Read() (Record, error) {
+	// Alguns arquivos trazem quebras de linha entre os registros
+	for {
+		b, err := r.r.ReadByte()
+		if err != nil {
+			return Record{}, err
+		}
+		if b != '\n' && b != '\r' {
+			r.r.UnreadByte()
+			break
+		}
+	}
+
+	prefix := make([]byte, 5)
+	if _, err := io.ReadFull(r.r, prefix); err != nil {
+		if err == io.ErrUnexpectedEOF {
+			return Record{}, fmt.Errorf("truncated MARC record")
+		}
+		return Record{}, err
+	}
+
+	length, err := strconv.Atoi(string(prefix))
+	if err != nil || length < 25 {
+		return Record{}, fmt.Errorf("invalid MARC record length %q", prefix)
+	}
+
+	data := make([]byte, length)
+	copy(data, prefix)
+	if _, err := io.ReadFull(r.r, data[5:]); err != nil {
+		return Record{}, fmt.Errorf("truncated MARC record")
+	}
+
+	return Unmarshal(data)
+}
+
+// Unmarshal decodifica um único registro ISO 2709
+func Unmarshal(data []byte) (Record, error) {
+	if len(data) < 25 {
+		return Record{}, fmt.Errorf("%w: record too short", ErrMalformed)
+	}
+
+	rec := Record{Leader: text(data[:24])}
+
+	base, err := strconv.Atoi(string(data[12:17]))
+	if err != nil || base < 25 || base > len(data) || data[base-1] != fieldTerminator {
+		return Record{}, fmt.Errorf("%w: invalid base address", ErrMalformed)
+	}
+
+	directory := data[24 : base-1]
+	if len(directory)%12 != 0 {
+		return Record{}, fmt.Errorf("%w: invalid directory", ErrMalformed)
+	}
+
+	for i := 0; i < len(directory); i += 12 {
+		entry := directory[i : i+12]
+		tag := string(entry[:3])
+		length, err1 := strconv.Atoi(string(entry[3:7]))
+		start, err2 := strconv.Atoi(string(entry[7:12]))
+		// Atoi aceita sinal: "-001" passaria pelo limite superior e
+		// estouraria o slice
+		if err1 != nil || err2 != nil || length < 1 || start < 0 || base+start+length > len(data) {
+			return Record{}, fmt.Errorf("%w: invalid directory entry for tag %s", ErrMalformed, tag)
+		}
+
+		value := bytes.TrimSuffix(data[base+start:base+start+length], []byte{fieldTerminator})
+
+		if isControlTag(tag) {
+			rec.ControlFields = append(rec.ControlFields, ControlField{Tag: tag, Value: text(value)})
+			continue
+		}
+
+		if len(value) < 2 {
+			return Record{}, fmt.Errorf("%w: field %s without indicators", ErrMalformed, tag)
+		}
+
+		field := DataField{Tag: tag, Ind1: string(value[0]), Ind2: string(value[1])}
+		for _, chunk := range bytes.Split(value[2:], []byte{subfieldDelimiter}) {
+			if len(chunk) == 0 {
+				continue
+			}
+			field.Subfields = append(field.Subfields, Subfield{Code: string(chunk[0]), Value: text(chunk[1:])})
+		}
+		rec.DataFields = append(rec.DataFields, field)
+	}
+
+	return rec, nil
+}
+
+// Marshal codifica o registro no formato ISO 2709, recalculando o
+// tamanho e o endereço base no líder
+func Marshal(rec Record) []byte {
+	var directory, data bytes.Buffer
+
+	addField := func(tag string, value []byte) {
+		fmt.Fprintf(&directory, "%3s%04d%05d", tag, len(value), data.Len())
+		data.Write(value)
+	}
+
+	for _, f := range rec.ControlFields {
+		addField(f.Tag, append([]byte(f.Value), fieldTerminator))
+	}
+
+	for _, f := range rec.DataFields {
+		var value bytes.Buffer
+		value.WriteString(indicator(f.Ind1))
+		value.WriteString(indicator(f.Ind2))
+		for _, sf := range f.Subfields {
+			value.WriteByte(subfieldDelimiter)
+			value.WriteString(sf.Code)
+			value.WriteString(sf.Value)
+		}
+		value.WriteByte(fieldTerminator)
+		addField(f.Tag, value.Bytes())
+	}
+
+	base := 24 + directory.Len() + 1
+	length := base + data.Len() + 1
+
+	leader := []byte(fmt.Sprintf("%-24s", rec.Leader))[:24]
+	copy(leader[0:5], fmt.Sprintf("%05d", length))
+	copy(leader[10:12], "22")
+	copy(leader[12:17], fmt.Sprintf("%05d", base))
+	copy(leader[20:24], "4500")
+
+	out := make([]byte, 0, length)
+	out = append(out, leader...)
+	out = append(out, directory.Bytes()...)
+	out = append(out, fieldTerminator)
+	out = append(out, data.Bytes()...)
+	out = append(out, recordTerminator)
+	return out
+}
+
+// Writer grava registros em MARC21 binário
+type Writer struct {
+	w io.Writer
+}
+
+// NewWriter cria o writer de MARC21 binário
+func NewWriter(w io.Writer) *Writer {
+	return &Writer{w: w}
+}
+
+// Write grava um registro
+func (w *Writer) Write(rec Record) error {
+	_, err := w.w.Write(Marshal(rec))
+	return err
+}
+
+// isControlTag indica as tags 001 a 009, que não têm indicadores
+func isControlTag(tag string) bool {
+	return strings.HasPrefix(tag, "00")
+}
+
+func indicator(ind string) string {
+	if ind == "" {
+		return " "
+	}
+	return ind[:1]
+}
+
+// text converte bytes em string garantindo UTF-8 válido
+func text(b []byte) string {
+	return strings.ToValidUTF8(string(b), "�")
+}
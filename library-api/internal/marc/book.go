@@ -0,0 +1,306 @@
+package marc
+
+import (
+	"library-api/internal/models"
+	"strconv"
+	"strings"
+	"unicode"
+)
+
+// Contributor é um nome de pessoa do registro com o papel no livro
+type Contributor struct {
+	Name string
+	Role string // um dos papéis de models (author, editor, ...)
+}
+
+// Bibliographic reúne os dados do registro usados no catálogo
+type Bibliographic struct {
+	Title       string
+	ISBN        string
+	Authors     []Contributor
+	Publisher   string
+	Year        int
+	Edition     string
+	Language    string
+	Pages       int
+	Description string
+	Subjects    []string
+}
+
+// FromBook monta o registro MARC de um livro. A disponibilidade vai no
+// campo local 999 $a ("available" ou "on loan").
+func FromBook(book models.Book) Record {
+	rec := Record{Leader: defaultLeader}
+	rec.ControlFields = append(rec.ControlFields, ControlField{Tag: "001", Value: strconv.FormatUint(uint64(book.ID), 10)})
+
+	if book.ISBN != "" {
+		rec.DataFields = append(rec.DataFields, field("020", " ", " ", Subfield{Code: "a", Value: book.ISBN}))
+	}
+
+	if book.Language != "" {
+		rec.DataFields = append(rec.DataFields, field("041", " ", " ", Subfield{Code: "a", Value: book.Language}))
+	}
+
+	// O primeiro autor (papel author) é a entrada principal no 100; os
+	// demais nomes vão no 700 com o papel em $e
+	main := -1
+	for i, author := range book.Authors {
+		if author.Role == "" || author.Role == models.RoleAuthor {
+			main = i
+			break
+		}
+	}
+	if main >= 0 {
+		author := book.Authors[main]
+		rec.DataFields = append(rec.DataFields, field("100", nameIndicator(author.Name), " ",
+			Subfield{Code: "a", Value: author.Name}, Subfield{Code: "e", Value: models.RoleAuthor}))
+	}
+	var added []DataField
+	for i, author := range book.Authors {
+		if i == main {
+			continue
+		}
+		role := author.Role
+		if role == "" {
+			role = models.RoleAuthor
+		}
+		added = append(added, field("700", nameIndicator(author.Name), " ",
+			Subfield{Code: "a", Value: author.Name}, Subfield{Code: "e", Value: role}))
+	}
+
+	// 245 ind1 = 1 quando há entrada principal de autor
+	ind1 := "0"
+	if main >= 0 {
+		ind1 = "1"
+	}
+	rec.DataFields = append(rec.DataFields, field("245", ind1, "0", Subfield{Code: "a", Value: book.Title}))
+
+	if book.Edition != "" {
+		rec.DataFields = append(rec.DataFields, field("250", " ", " ", Subfield{Code: "a", Value: book.Edition}))
+	}
+
+	var imprint []Subfield
+	if book.Publisher != "" {
+		imprint = append(imprint, Subfield{Code: "b", Value: book.Publisher})
+	}
+	if book.PublicationYear != 0 {
+		imprint = append(imprint, Subfield{Code: "c", Value: strconv.Itoa(book.PublicationYear)})
+	}
+	if len(imprint) > 0 {
+		rec.DataFields = append(rec.DataFields, field("264", " ", "1", imprint...))
+	}
+
+	if book.Pages > 0 {
+		rec.DataFields = append(rec.DataFields, field("300", " ", " ", Subfield{Code: "a", Value: strconv.Itoa(book.Pages) + " p."}))
+	}
+
+	if book.Description != "" {
+		rec.DataFields = append(rec.DataFields, field("520", " ", " ", Subfield{Code: "a", Value: book.Description}))
+	}
+
+	for _, subject := range book.Subjects {
+		rec.DataFields = append(rec.DataFields, field("650", " ", "4", Subfield{Code: "a", Value: subject.Name}))
+	}
+
+	rec.DataFields = append(rec.DataFields, added...)
+
+	status := "available"
+	if !book.Available {
+		status = "on loan"
+	}
+	rec.DataFields = append(rec.DataFields, field("999", " ", " ", Subfield{Code: "a", Value: status}))
+
+	return rec
+}
+
+// nameIndicator usa ind1 = 1 (sobrenome primeiro) para nomes invertidos
+// e ind1 = 0 (ordem direta) para os demais
+func nameIndicator(name string) string {
+	if strings.Contains(name, ",") {
+		return "1"
+	}
+	return "0"
+}
+
+func field(tag, ind1, ind2 string, subfields ...Subfield) DataField {
+	return DataField{Tag: tag, Ind1: ind1, Ind2: ind2, Subfields: subfields}
+}
+
+// Bibliographic extrai título (245), ISBN (020), autores (100/700),
+// editora e ano (264 ou 260), edição (250), idioma (041 ou 008),
+// páginas (300), resumo (520) e assuntos (650)
+func (r Record) Bibliographic() Bibliographic {
+	var bib Bibliographic
+
+	for _, f := range r.Fields("245") {
+		parts := []string{clean(f.Subfield("a"))}
+		if sub := clean(f.Subfield("b")); sub != "" {
+			parts = append(parts, sub)
+		}
+		bib.Title = strings.Join(parts, ": ")
+		break
+	}
+
+	for _, f := range r.Fields("020") {
+		// "9780261103344 (pbk.)" vira "9780261103344"
+		if fields := strings.Fields(f.Subfield("a")); len(fields) > 0 {
+			bib.ISBN = fields[0]
+			break
+		}
+	}
+
+	for _, tag := range []string{"100", "700"} {
+		for _, f := range r.Fields(tag) {
+			if name := personalName(f); name != "" {
+				bib.Authors = append(bib.Authors, Contributor{Name: name, Role: relatorRole(f)})
+			}
+		}
+	}
+
+	// 264 com ind2 = 1 é a publicação; 260 é a forma anterior ao RDA
+	var imprint []DataField
+	for _, f := range r.Fields("264") {
+		if f.Ind2 == "1" {
+			imprint = append(imprint, f)
+		}
+	}
+	imprint = append(imprint, r.Fields("260")...)
+	for _, f := range imprint {
+		if bib.Publisher == "" {
+			bib.Publisher = clean(f.Subfield("b"))
+		}
+		if bib.Year == 0 {
+			bib.Year = year(f.Subfield("c"))
+		}
+	}
+
+	// Data 1 do campo 008 (posições 07-10) quando não há ano no 260/264
+	fixed := r.Control("008")
+	if bib.Year == 0 && len(fixed) >= 11 {
+		bib.Year = year(fixed[7:11])
+	}
+
+	for _, f := range r.Fields("250") {
+		bib.Edition = clean(f.Subfield("a"))
+		break
+	}
+
+	// Idioma: 041 $a ou posições 35-37 do 008
+	for _, f := range r.Fields("041") {
+		bib.Language = strings.TrimSpace(f.Subfield("a"))
+		break
+	}
+	if bib.Language == "" && len(fixed) >= 38 {
+		bib.Language = strings.TrimSpace(fixed[35:38])
+	}
+
+	for _, f := range r.Fields("300") {
+		bib.Pages = pages(f.Subfield("a"))
+		break
+	}
+
+	for _, f := range r.Fields("520") {
+		bib.Description = strings.TrimSpace(f.Subfield("a"))
+		break
+	}
+
+	for _, f := range r.Fields("650") {
+		parts := []string{clean(f.Subfield("a"))}
+		for _, code := range []string{"x", "y", "z", "v"} {
+			for _, v := range f.SubfieldValues(code) {
+				parts = append(parts, clean(v))
+			}
+		}
+		if parts[0] != "" {
+			bib.Subjects = append(bib.Subjects, strings.Join(parts, " -- "))
+		}
+	}
+
+	return bib
+}
+
+// personalName devolve o nome em ordem direta: "Tolkien, J. R. R." vira
+// "J. R. R. Tolkien" quando ind1 = 1 (sobrenome primeiro)
+func personalName(f DataField) string {
+	name := clean(f.Subfield("a"))
+	if f.Ind1 != "1" {
+		return name
+	}
+
+	surname, forename, ok := strings.Cut(name, ",")
+	if !ok {
+		return name
+	}
+	return strings.TrimSpace(forename) + " " + strings.TrimSpace(surname)
+}
+
+// relatorRole traduz o código ($4) ou termo ($e) de relação para um dos
+// papéis aceitos; sem relação conhecida, o nome é tratado como autor
+func relatorRole(f DataField) string {
+	terms := append(f.SubfieldValues("4"), f.SubfieldValues("e")...)
+	for _, term := range terms {
+		switch strings.ToLower(clean(term)) {
+		case "edt", "editor", "editora", "org", "organizador", "organizadora":
+			return models.RoleEditor
+		case "trl", "translator", "tradutor", "tradutora", "tradução":
+			return models.RoleTranslator
+		case "ill", "illustrator", "ilustrador", "ilustradora":
+			return models.RoleIllustrator
+		case "aut", "author", "autor", "autora":
+			return models.RoleAuthor
+		}
+	}
+	return models.RoleAuthor
+}
+
+// clean remove a pontuação ISBD do fim do subcampo (" /", " :", ",", ".")
+func clean(value string) string {
+	value = strings.TrimSpace(value)
+	for {
+		trimmed := strings.TrimRight(value, " /:;,=")
+		// Mantém o ponto de abreviações como "J. R. R."
+		if strings.HasSuffix(trimmed, ".") && !isInitial(trimmed) {
+			trimmed = strings.TrimSuffix(trimmed, ".")
+		}
+		trimmed = strings.TrimSpace(trimmed)
+		if trimmed == value {
+			return value
+		}
+		value = trimmed
+	}
+}
+
+// isInitial indica se o texto termina com uma inicial, como "R."
+func isInitial(value string) bool {
+	runes := []rune(value)
+	n := len(runes)
+	return n >= 2 && unicode.IsUpper(runes[n-2]) && (n == 2 || runes[n-3] == ' ' || runes[n-3] == '.')
+}
+
+// year extrai o primeiro ano de quatro dígitos, como em "c1937." ou "[1954]"
+func year(value string) int {
+	digits := 0
+	for i, r := range value {
+		if r >= '0' && r <= '9' {
+			digits++
+			if digits == 4 {
+				y, _ := strconv.Atoi(value[i-3 : i+1])
+				return y
+			}
+			continue
+		}
+		digits = 0
+	}
+	return 0
+}
+
+// pages extrai o número de páginas de "310 p. :" ou "xii, 310 pages"
+func pages(value string) int {
+	for _, token := range strings.Fields(value) {
+		n, err := strconv.Atoi(strings.TrimRight(token, ",;:"))
+		if err == nil {
+			return n
+		}
+	}
+	return 0
+}
@@ -0,0 +1,94 @@
+package marc
+
+import (
+	"encoding/xml"
+	"errors"
+)
+
+// Namespace do MARCXML (MARC21 slim)
+const Namespace = "http://www.loc.gov/MARC21/slim"
+
+// Delimitadores do formato binário ISO 2709
+const (
+	subfieldDelimiter = 0x1F
+	fieldTerminator   = 0x1E
+	recordTerminator  = 0x1D
+)
+
+// ErrMalformed indica um registro inválido; a leitura pode continuar
+// no registro seguinte
+var ErrMalformed = errors.New("malformed MARC record")
+
+// defaultLeader é usado nos registros gerados a partir de livros:
+// registro novo (n), material textual (a), monografia (m), Unicode (a)
+const defaultLeader = "00000nam a2200000 a 4500"
+
+// Record é um registro bibliográfico MARC21
+type Record struct {
+	XMLName       xml.Name       `xml:"record"`
+	Leader        string         `xml:"leader"`
+	ControlFields []ControlField `xml:"controlfield"`
+	DataFields    []DataField    `xml:"datafield"`
+}
+
+// ControlField é um campo de controle (tags 001 a 009)
+type ControlField struct {
+	Tag   string `xml:"tag,attr"`
+	Value string `xml:",chardata"`
+}
+
+// DataField é um campo de dados com indicadores e subcampos
+type DataField struct {
+	Tag       string     `xml:"tag,attr"`
+	Ind1      string     `xml:"ind1,attr"`
+	Ind2      string     `xml:"ind2,attr"`
+	Subfields []Subfield `xml:"subfield"`
+}
+
+// Subfield é um subcampo identificado por um código de uma letra
+type Subfield struct {
+	Code  string `xml:"code,attr"`
+	Value string `xml:",chardata"`
+}
+
+// Fields devolve os campos de dados com a tag informada
+func (r Record) Fields(tag string) []DataField {
+	var fields []DataField
+	for _, f := range r.DataFields {
+		if f.Tag == tag {
+			fields = append(fields, f)
+		}
+	}
+	return fields
+}
+
+// Control devolve o valor do campo de controle com a tag informada
+func (r Record) Control(tag string) string {
+	for _, f := range r.ControlFields {
+		if f.Tag == tag {
+			return f.Value
+		}
+	}
+	return ""
+}
+
+// Subfield devolve o primeiro subcampo com o código informado
+func (f DataField) Subfield(code string) string {
+	for _, sf := range f.Subfields {
+		if sf.Code == code {
+			return sf.Value
+		}
+	}
+	return ""
+}
+
+// SubfieldValues devolve todos os subcampos com o código informado
+func (f DataField) SubfieldValues(code string) []string {
+	var values []string
+	for _, sf := range f.Subfields {
+		if sf.Code == code {
+			values = append(values, sf.Value)
+		}
+	}
+	return values
+}
@@ -0,0 +1,234 @@
+package marc
+
+import (
+	"bytes"
+	"errors"
+	"io"
+	"library-api/internal/models"
+	"os"
+	"reflect"
+	"testing"
+)
+
+// sampleBooks é o que os registros de samples/marc devem produzir
+var sampleBooks = []Bibliographic{
+	{
+		Title:     "The hobbit, or, There and back again",
+		ISBN:      "9780261103344",
+		Authors:   []Contributor{{Name: "J. R. R. Tolkien", Role: models.RoleAuthor}},
+		Publisher: "HarperCollins",
+		Year:      2012,
+		Language:  "eng",
+		Subjects:  []string{"Fantasy fiction", "Middle Earth (Imaginary place) -- Fiction"},
+	},
+	{
+		Title:     "Dom Casmurro: romance",
+		ISBN:      "8508064581",
+		Authors:   []Contributor{{Name: "Machado de Assis", Role: models.RoleAuthor}},
+		Publisher: "Ática",
+		Year:      1997,
+		Language:  "por",
+		Subjects:  []string{"Romance brasileiro -- Século XIX"},
+	},
+	{
+		Title: "Duna",
+		ISBN:  "9788576573135",
+		Authors: []Contributor{
+			{Name: "Frank Herbert", Role: models.RoleAuthor},
+			{Name: "Maria do Carmo Zanini", Role: models.RoleTranslator},
+		},
+		Publisher: "Aleph",
+		Year:      2017,
+		Language:  "por",
+		Subjects:  []string{"Science fiction", "Dune (Imaginary place) -- Fiction"},
+	},
+}
+
+type reader interface {
+	Read() (Record, error)
+}
+
+func readAll(t *testing.T, r reader) []Record {
+	t.Helper()
+	var records []Record
+	for {
+		rec, err := r.Read()
+		if errors.Is(err, io.EOF) {
+			return records
+		}
+		if err != nil {
+			t.Fatalf("read record %d: %v", len(records)+1, err)
+		}
+		records = append(records, rec)
+	}
+}
+
+func readSample(t *testing.T, name string) []Record {
+	t.Helper()
+	f, err := os.Open("../../samples/marc/" + name)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer f.Close()
+
+	if name == "books.xml" {
+		return readAll(t, NewXMLReader(f))
+	}
+	return readAll(t, NewReader(f))
+}
+
+// comparable zera o que não sobrevive à troca de formato: o nome do
+// elemento XML e as posições recalculadas no líder
+func comparable(rec Record) Record {
+	rec.XMLName.Space, rec.XMLName.Local = "", ""
+	if len(rec.Leader) == 24 {
+		rec.Leader = "00000" + rec.Leader[5:12] + "00000" + rec.Leader[17:]
+	}
+	return rec
+}
+
+func TestSamplesMapping(t *testing.T) {
+	for _, name := range []string{"books.mrc", "books.xml"} {
+		t.Run(name, func(t *testing.T) {
+			records := readSample(t, name)
+			if len(records) != len(sampleBooks) {
+				t.Fatalf("got %d records, want %d", len(records), len(sampleBooks))
+			}
+			for i, rec := range records {
+				if got := rec.Bibliographic(); !reflect.DeepEqual(got, sampleBooks[i]) {
+					t.Errorf("record %d:\n got %+v\nwant %+v", i+1, got, sampleBooks[i])
+				}
+			}
+		})
+	}
+}
+
+func TestSamplesAgree(t *testing.T) {
+	binary := readSample(t, "books.mrc")
+	xml := readSample(t, "books.xml")
+	if len(binary) != len(xml) {
+		t.Fatalf("books.mrc has %d records, books.xml has %d", len(binary), len(xml))
+	}
+	for i := range binary {
+		if got, want := comparable(binary[i]), comparable(xml[i]); !reflect.DeepEqual(got, want) {
+			t.Errorf("record %d differs:\n mrc %+v\n xml %+v", i+1, got, want)
+		}
+	}
+}
+
+func TestBinaryRoundTrip(t *testing.T) {
+	records := readSample(t, "books.mrc")
+
+	var buf bytes.Buffer
+	w := NewWriter(&buf)
+	for _, rec := range records {
+		if err := w.Write(rec); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	got := readAll(t, NewReader(&buf))
+	if len(got) != len(records) {
+		t.Fatalf("got %d records back, want %d", len(got), len(records))
+	}
+	for i := range records {
+		if !reflect.DeepEqual(comparable(got[i]), comparable(records[i])) {
+			t.Errorf("record %d changed:\n got %+v\nwant %+v", i+1, got[i], records[i])
+		}
+	}
+}
+
+func TestXMLRoundTrip(t *testing.T) {
+	records := readSample(t, "books.xml")
+
+	var buf bytes.Buffer
+	w, err := NewXMLWriter(&buf)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, rec := range records {
+		if err := w.Write(rec); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err := w.Close(); err != nil {
+		t.Fatal(err)
+	}
+
+	got := readAll(t, NewXMLReader(&buf))
+	if len(got) != len(records) {
+		t.Fatalf("got %d records back, want %d", len(got), len(records))
+	}
+	for i := range records {
+		if !reflect.DeepEqual(comparable(got[i]), comparable(records[i])) {
+			t.Errorf("record %d changed:\n got %+v\nwant %+v", i+1, got[i], records[i])
+		}
+	}
+}
+
+func TestUnmarshalMalformedDirectory(t *testing.T) {
+	valid := Marshal(FromBook(models.Book{ID: 7, Title: "Duna"}))
+	// A primeira entrada do diretório começa logo depois do líder
+	tests := []struct {
+		name  string
+		entry string
+	}{
+		{"negative length", "001-00100000"},
+		{"negative start", "0010002-0005"},
+		{"zero length", "001000000000"},
+		{"past the end", "001999900000"},
+		{"not a number", "001abcd00000"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			data := bytes.Clone(valid)
+			copy(data[24:36], tt.entry)
+			if _, err := Unmarshal(data); !errors.Is(err, ErrMalformed) {
+				t.Errorf("err = %v, want ErrMalformed", err)
+			}
+		})
+	}
+}
+
+func TestFromBookRoundTrip(t *testing.T) {
+	book := models.Book{
+		ID:              7,
+		Title:           "Duna",
+		ISBN:            "9788576573135",
+		Publisher:       "Aleph",
+		PublicationYear: 2017,
+		Edition:         "Edição revista",
+		Language:        "por",
+		Pages:           680,
+		Description:     "Clássico da ficção científica",
+		Authors: []models.Author{
+			{Name: "Frank Herbert", Role: models.RoleAuthor},
+			{Name: "Maria do Carmo Zanini", Role: models.RoleTranslator},
+		},
+		Subjects: []models.Subject{{Name: "Science fiction"}},
+	}
+
+	want := Bibliographic{
+		Title:       book.Title,
+		ISBN:        book.ISBN,
+		Authors:     []Contributor{{Name: "Frank Herbert", Role: models.RoleAuthor}, {Name: "Maria do Carmo Zanini", Role: models.RoleTranslator}},
+		Publisher:   book.Publisher,
+		Year:        book.PublicationYear,
+		Edition:     book.Edition,
+		Language:    book.Language,
+		Pages:       book.Pages,
+		Description: book.Description,
+		Subjects:    []string{"Science fiction"},
+	}
+
+	rec, err := Unmarshal(Marshal(FromBook(book)))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got := rec.Bibliographic(); !reflect.DeepEqual(got, want) {
+		t.Errorf("got %+v\nwant %+v", got, want)
+	}
+	if got := rec.Control("001"); got != "7" {
+		t.Errorf("001 = %q, want %q", got, "7")
+	}
+}
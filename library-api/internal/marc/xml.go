@@ -0,0 +1,69 @@
+package marc
+
+import (
+	"encoding/xml"
+	"io"
+)
+
+// XMLWriter grava registros em uma coleção MARCXML, um de cada vez
+type XMLWriter struct {
+	w   io.Writer
+	enc *xml.Encoder
+}
+
+// NewXMLWriter escreve o cabeçalho da coleção e devolve o writer
+func NewXMLWriter(w io.Writer) (*XMLWriter, error) {
+	if _, err := io.WriteString(w, xml.Header+`<collection xmlns="`+Namespace+`">`+"\n"); err != nil {
+		return nil, err
+	}
+	enc := xml.NewEncoder(w)
+	enc.Indent("  ", "  ")
+	return &XMLWriter{w: w, enc: enc}, nil
+}
+
+// Write grava um registro
+func (x *XMLWriter) Write(rec Record) error {
+	if err := x.enc.Encode(rec); err != nil {
+		return err
+	}
+	_, err := io.WriteString(x.w, "\n")
+	return err
+}
+
+// Close fecha a coleção
+func (x *XMLWriter) Close() error {
+	_, err := io.WriteString(x.w, "</collection>\n")
+	return err
+}
+
+// XMLReader lê registros de um documento MARCXML, seja uma <collection>
+// ou um único <record>
+type XMLReader struct {
+	dec *xml.Decoder
+}
+
+// NewXMLReader cria o leitor de MARCXML
+func NewXMLReader(r io.Reader) *XMLReader {
+	return &XMLReader{dec: xml.NewDecoder(r)}
+}
+
+// Read devolve o próximo registro ou io.EOF no fim do documento
+func (x *XMLReader) Read() (Record, error) {
+	for {
+		tok, err := x.dec.Token()
+		if err != nil {
+			return Record{}, err
+		}
+
+		start, ok := tok.(xml.StartElement)
+		if !ok || start.Name.Local != "record" {
+			continue
+		}
+
+		var rec Record
+		if err := x.dec.DecodeElement(&rec, &start); err != nil {
+			return Record{}, err
+		}
+		return rec, nil
+	}
+}
@@ -0,0 +1,42 @@
+package metadata
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+)
+
+// FileProvider lê os dados de arquivos <isbn>.json em um diretório, no
+// mesmo formato de Metadata. Serve para testes e uso sem rede.
+type FileProvider struct {
+	Dir string
+}
+
+// NewFileProvider cria o provedor para o diretório informado
+func NewFileProvider(dir string) *FileProvider {
+	return &FileProvider{Dir: dir}
+}
+
+// Lookup lê o arquivo do ISBN
+func (f *FileProvider) Lookup(ctx context.Context, isbn string) (*Metadata, error) {
+	data, err := os.ReadFile(filepath.Join(f.Dir, isbn+".json"))
+	if errors.Is(err, os.ErrNotExist) {
+		return nil, ErrNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	var meta Metadata
+	if err := json.Unmarshal(data, &meta); err != nil {
+		return nil, fmt.Errorf("reading %s.json: %w", isbn, err)
+	}
+	meta.ISBN = isbn
+	if meta.Source == "" {
+		meta.Source = "file"
+	}
+	return &meta, nil
+}
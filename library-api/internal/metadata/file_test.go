@@ -0,0 +1,63 @@
+package metadata
+
+import (
+	"context"
+	"errors"
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+)
+
+func TestFileProviderLookup(t *testing.T) {
+	p := NewFileProvider("../../samples/metadata")
+
+	meta, err := p.Lookup(context.Background(), "9780261103344")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	want := Metadata{
+		ISBN:            "9780261103344",
+		Title:           "The Hobbit",
+		Authors:         []string{"J. R. R. Tolkien"},
+		Publisher:       "HarperCollins",
+		PublicationYear: 1995,
+		Language:        "eng",
+		Pages:           310,
+		Description:     "Bilbo Baggins is swept into a quest to reclaim the lost Dwarf Kingdom of Erebor from the dragon Smaug.",
+		Subjects:        []string{"Fantasy fiction", "Middle Earth (Imaginary place)"},
+		Source:          "file",
+	}
+	if !reflect.DeepEqual(*meta, want) {
+		t.Errorf("got %+v\nwant %+v", *meta, want)
+	}
+}
+
+func TestFileProviderErrors(t *testing.T) {
+	dir := t.TempDir()
+	if err := os.WriteFile(filepath.Join(dir, "9788576573135.json"), []byte(`{"title":`), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	p := NewFileProvider(dir)
+
+	if _, err := p.Lookup(context.Background(), "9780261103344"); !errors.Is(err, ErrNotFound) {
+		t.Errorf("missing file: err = %v, want ErrNotFound", err)
+	}
+	if _, err := p.Lookup(context.Background(), "9788576573135"); err == nil || errors.Is(err, ErrNotFound) {
+		t.Errorf("invalid JSON: err = %v, want a decoding error", err)
+	}
+}
+
+func TestFromEnvFile(t *testing.T) {
+	t.Setenv("METADATA_PROVIDER", "file")
+	t.Setenv("METADATA_DIR", "/srv/metadata")
+
+	p, ok := FromEnv().(*FileProvider)
+	if !ok {
+		t.Fatalf("FromEnv() = %T, want *FileProvider", FromEnv())
+	}
+	if p.Dir != "/srv/metadata" {
+		t.Errorf("Dir = %q, want %q", p.Dir, "/srv/metadata")
+	}
+}
@@ -0,0 +1,87 @@
+package metadata
+
+import (
+	"context"
+	"errors"
+	"os"
+	"strings"
+	"time"
+)
+
+// ErrNotFound indica que o provedor não conhece o ISBN
+var ErrNotFound = errors.New("ISBN not found")
+
+// Metadata são os dados bibliográficos de uma edição
+type Metadata struct {
+	ISBN            string   `json:"isbn"`
+	Title           string   `json:"title"`
+	Authors         []string `json:"authors"`
+	Publisher       string   `json:"publisher"`
+	PublicationYear int      `json:"publication_year"`
+	Edition         string   `json:"edition"`
+	Language        string   `json:"language"`
+	Pages           int      `json:"pages"`
+	Description     string   `json:"description"`
+	Subjects        []string `json:"subjects"`
+	CoverURL        string   `json:"cover_url"`
+	Source          string   `json:"source"` // provedor que respondeu
+}
+
+// Provider busca os dados de uma edição pelo ISBN (já normalizado)
+type Provider interface {
+	Lookup(ctx context.Context, isbn string) (*Metadata, error)
+}
+
+// FromEnv escolhe o provedor pela variável METADATA_PROVIDER:
+//   - "openlibrary" (padrão): Open Library, em OPENLIBRARY_URL se definida
+//   - "file": arquivos JSON no diretório METADATA_DIR
+//   - "none": desativa a busca, e FromEnv devolve nil
+func FromEnv() Provider {
+	switch strings.ToLower(os.Getenv("METADATA_PROVIDER")) {
+	case "none":
+		return nil
+	case "file":
+		dir := os.Getenv("METADATA_DIR")
+		if dir == "" {
+			dir = "samples/metadata"
+		}
+		return NewFileProvider(dir)
+	default:
+		return NewOpenLibrary(os.Getenv("OPENLIBRARY_URL"), 10*time.Second)
+	}
+}
+
+// NormalizeISBN remove hífens e espaços e confere o dígito verificador
+// do ISBN-10 ou ISBN-13
+func NormalizeISBN(value string) (string, bool) {
+	value = strings.ToUpper(strings.NewReplacer("-", "", " ", "").Replace(strings.TrimSpace(value)))
+
+	switch len(value) {
+	case 10:
+		sum := 0
+		for i, r := range value {
+			digit := int(r - '0')
+			if r == 'X' && i == 9 {
+				digit = 10
+			} else if r < '0' || r > '9' {
+				return "", false
+			}
+			sum += digit * (10 - i)
+		}
+		return value, sum%11 == 0
+	case 13:
+		sum := 0
+		for i, r := range value {
+			if r < '0' || r > '9' {
+				return "", false
+			}
+			weight := 1
+			if i%2 == 1 {
+				weight = 3
+			}
+			sum += int(r-'0') * weight
+		}
+		return value, sum%10 == 0
+	}
+	return "", false
+}
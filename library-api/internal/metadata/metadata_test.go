@@ -0,0 +1,30 @@
+package metadata
+
+import "testing"
+
+func TestNormalizeISBN(t *testing.T) {
+	tests := []struct {
+		value string
+		want  string
+		ok    bool
+	}{
+		{"9780261103344", "9780261103344", true},
+		{"978-0-261-10334-4", "9780261103344", true},
+		{" 978 0261 103344 ", "9780261103344", true},
+		{"0261103342", "0261103342", true},
+		{"0-261-10334-2", "0261103342", true},
+		{"080442957x", "080442957X", true},
+		{"9780261103345", "9780261103345", false},
+		{"0261103343", "0261103343", false},
+		{"02611X3342", "", false},
+		{"978026110334A", "", false},
+		{"026110334", "", false},
+		{"", "", false},
+	}
+	for _, tt := range tests {
+		got, ok := NormalizeISBN(tt.value)
+		if got != tt.want || ok != tt.ok {
+			t.Errorf("NormalizeISBN(%q) = %q, %v; want %q, %v", tt.value, got, ok, tt.want, tt.ok)
+		}
+	}
+}
@@ -0,0 +1,122 @@
+package metadata
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"regexp"
+	"strings"
+	"time"
+)
+
+// DefaultOpenLibraryURL é a API pública da Open Library
+const DefaultOpenLibraryURL = "https://openlibrary.org"
+
+// OpenLibrary busca edições na API de livros da Open Library
+// (/api/books com jscmd=data) ou em um serviço compatível
+type OpenLibrary struct {
+	BaseURL string
+	Client  *http.Client
+}
+
+// NewOpenLibrary cria o provedor; baseURL vazio usa a Open Library
+func NewOpenLibrary(baseURL string, timeout time.Duration) *OpenLibrary {
+	if baseURL == "" {
+		baseURL = DefaultOpenLibraryURL
+	}
+	return &OpenLibrary{
+		BaseURL: strings.TrimSuffix(baseURL, "/"),
+		Client:  &http.Client{Timeout: timeout},
+	}
+}
+
+type openLibraryName struct {
+	Name string `json:"name"`
+}
+
+type openLibraryBook struct {
+	Title         string            `json:"title"`
+	Subtitle      string            `json:"subtitle"`
+	Authors       []openLibraryName `json:"authors"`
+	Publishers    []openLibraryName `json:"publishers"`
+	PublishDate   string            `json:"publish_date"`
+	NumberOfPages int               `json:"number_of_pages"`
+	Subjects      []openLibraryName `json:"subjects"`
+	Notes         json.RawMessage   `json:"notes"`
+	Cover         struct {
+		Medium string `json:"medium"`
+		Large  string `json:"large"`
+	} `json:"cover"`
+}
+
+var yearPattern = regexp.MustCompile(`\b(1[0-9]{3}|20[0-9]{2})\b`)
+
+// Lookup consulta o ISBN na Open Library
+func (o *OpenLibrary) Lookup(ctx context.Context, isbn string) (*Metadata, error) {
+	key := "ISBN:" + isbn
+	query := url.Values{"bibkeys": {key}, "format": {"json"}, "jscmd": {"data"}}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, o.BaseURL+"/api/books?"+query.Encode(), nil)
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Accept", "application/json")
+
+	resp, err := o.Client.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("open library returned %s", resp.Status)
+	}
+
+	var result map[string]openLibraryBook
+	if err := json.NewDecoder(resp.Body).Decode(&result); err != nil {
+		return nil, fmt.Errorf("decoding open library response: %w", err)
+	}
+
+	book, ok := result[key]
+	if !ok {
+		return nil, ErrNotFound
+	}
+
+	meta := &Metadata{
+		ISBN:     isbn,
+		Title:    book.Title,
+		Pages:    book.NumberOfPages,
+		CoverURL: book.Cover.Large,
+		Source:   "openlibrary",
+	}
+	if book.Subtitle != "" {
+		meta.Title += ": " + book.Subtitle
+	}
+	if meta.CoverURL == "" {
+		meta.CoverURL = book.Cover.Medium
+	}
+	for _, author := range book.Authors {
+		meta.Authors = append(meta.Authors, author.Name)
+	}
+	if len(book.Publishers) > 0 {
+		meta.Publisher = book.Publishers[0].Name
+	}
+	if year := yearPattern.FindString(book.PublishDate); year != "" {
+		fmt.Sscan(year, &meta.PublicationYear)
+	}
+	for _, subject := range book.Subjects {
+		meta.Subjects = append(meta.Subjects, subject.Name)
+	}
+
+	// notes vem como texto ou como {"type": ..., "value": ...}
+	var notes struct {
+		Value string `json:"value"`
+	}
+	if json.Unmarshal(book.Notes, &meta.Description) != nil && json.Unmarshal(book.Notes, &notes) == nil {
+		meta.Description = notes.Value
+	}
+
+	return meta, nil
+}
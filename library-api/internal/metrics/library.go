@@ -0,0 +1,63 @@
+package metrics
+
+import (
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"gorm.io/gorm"
+)
+
+var (
+	booksDesc = prometheus.NewDesc("library_books",
+		"Livros no acervo, por disponibilidade.", []string{"available"}, nil)
+	openLoansDesc = prometheus.NewDesc("library_loans_open",
+		"Empréstimos ainda não devolvidos.", nil, nil)
+	overdueLoansDesc = prometheus.NewDesc("library_loans_overdue",
+		"Empréstimos não devolvidos com prazo vencido.", nil, nil)
+)
+
+// libraryCollector conta livros e empréstimos a cada coleta, para que os
+// valores venham sempre do banco
+type libraryCollector struct {
+	db *gorm.DB
+}
+
+func newLibraryCollector(db *gorm.DB) *libraryCollector {
+	return &libraryCollector{db: db}
+}
+
+func (c *libraryCollector) Describe(ch chan<- *prometheus.Desc) {
+	ch <- booksDesc
+	ch <- openLoansDesc
+	ch <- overdueLoansDesc
+}
+
+func (c *libraryCollector) Collect(ch chan<- prometheus.Metric) {
+	var books struct {
+		Total     int64
+		Available int64
+	}
+	err := c.db.Table("books").Where("deleted_at IS NULL").
+		Select("COUNT(*) AS total, COALESCE(SUM(CASE WHEN available THEN 1 ELSE 0 END), 0) AS available").
+		Scan(&books).Error
+	if err != nil {
+		ch <- prometheus.NewInvalidMetric(booksDesc, err)
+	} else {
+		ch <- prometheus.MustNewConstMetric(booksDesc, prometheus.GaugeValue, float64(books.Available), "true")
+		ch <- prometheus.MustNewConstMetric(booksDesc, prometheus.GaugeValue, float64(books.Total-books.Available), "false")
+	}
+
+	var loans struct {
+		Open    int64
+		Overdue int64
+	}
+	err = c.db.Table("loans").Where("return_date IS NULL").
+		Select("COUNT(*) AS open, COALESCE(SUM(CASE WHEN julianday(due_date) < julianday(?) THEN 1 ELSE 0 END), 0) AS overdue", time.Now()).
+		Scan(&loans).Error
+	if err != nil {
+		ch <- prometheus.NewInvalidMetric(openLoansDesc, err)
+		return
+	}
+	ch <- prometheus.MustNewConstMetric(openLoansDesc, prometheus.GaugeValue, float64(loans.Open))
+	ch <- prometheus.MustNewConstMetric(overdueLoansDesc, prometheus.GaugeValue, float64(loans.Overdue))
+}
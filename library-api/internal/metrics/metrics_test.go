@@ -0,0 +1,25 @@
+package metrics
+
+import (
+	"library-api/internal/logging"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+)
+
+func TestMiddlewareCountsPanics(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	r := gin.New()
+	r.Use(Middleware(), logging.Recovery())
+	r.GET("/panic", func(c *gin.Context) { panic("boom") })
+
+	before := testutil.ToFloat64(httpRequests.WithLabelValues(http.MethodGet, "/panic", "500"))
+	r.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(http.MethodGet, "/panic", nil))
+
+	if got := testutil.ToFloat64(httpRequests.WithLabelValues(http.MethodGet, "/panic", "500")) - before; got != 1 {
+		t.Errorf("500 responses counted = %v, want 1", got)
+	}
+}
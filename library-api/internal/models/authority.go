@@ -0,0 +1,105 @@
+package models
+
+import (
+	"regexp"
+	"strings"
+)
+
+// Sufixos que ficam depois do prenome na forma de ordenação
+var nameSuffixes = map[string]bool{"jr.": true, "jr": true, "sr.": true, "sr": true, "ii": true, "iii": true, "iv": true}
+
+// Termos que fazem parte do sobrenome em nomes em português
+var compoundSurnames = map[string]bool{"filho": true, "neto": true, "júnior": true, "junior": true, "sobrinho": true}
+
+// SortName gera a forma de ordenação de um nome em ordem direta:
+// "J. R. R. Tolkien" vira "Tolkien, J. R. R." e "Paulo Coelho Neto" vira
+// "Coelho Neto, Paulo". Nomes que já têm vírgula são mantidos.
+func SortName(name string) string {
+	name = strings.TrimSpace(name)
+	if name == "" || strings.Contains(name, ",") {
+		return name
+	}
+
+	tokens := strings.Fields(name)
+	suffix := ""
+	if len(tokens) > 1 && nameSuffixes[strings.ToLower(tokens[len(tokens)-1])] {
+		suffix = tokens[len(tokens)-1]
+		tokens = tokens[:len(tokens)-1]
+	}
+	if len(tokens) < 2 {
+		return name
+	}
+
+	cut := len(tokens) - 1
+	if len(tokens) > 2 && compoundSurnames[strings.ToLower(tokens[cut])] {
+		cut--
+	}
+
+	sort := strings.Join(tokens[cut:], " ") + ", " + strings.Join(tokens[:cut], " ")
+	if suffix != "" {
+		sort += ", " + suffix
+	}
+	return sort
+}
+
+var viafPattern = regexp.MustCompile(`^[0-9]{1,22}$`)
+
+// NormalizeORCID aceita o ORCID com ou sem a URL e devolve no formato
+// 0000-0002-1825-0097
+func NormalizeORCID(value string) (string, bool) {
+	value = strings.TrimPrefix(strings.TrimSpace(value), "https://orcid.org/")
+	value = strings.TrimPrefix(value, "http://orcid.org/")
+	digits, ok := isniDigits(value)
+	if !ok {
+		return "", false
+	}
+	return digits[0:4] + "-" + digits[4:8] + "-" + digits[8:12] + "-" + digits[12:16], true
+}
+
+// NormalizeISNI aceita o ISNI com espaços ou a URL e devolve os 16
+// caracteres sem separadores
+func NormalizeISNI(value string) (string, bool) {
+	value = strings.TrimPrefix(strings.TrimSpace(value), "https://isni.org/isni/")
+	value = strings.TrimPrefix(value, "http://isni.org/isni/")
+	return isniDigits(value)
+}
+
+// NormalizeVIAF aceita o número VIAF ou a URL do registro
+func NormalizeVIAF(value string) (string, bool) {
+	value = strings.TrimSpace(value)
+	for _, prefix := range []string{"https://viaf.org/viaf/", "http://viaf.org/viaf/"} {
+		value = strings.TrimPrefix(value, prefix)
+	}
+	value = strings.TrimSuffix(value, "/")
+	if !viafPattern.MatchString(value) {
+		return "", false
+	}
+	return value, true
+}
+
+// isniDigits remove separadores e confere o dígito verificador
+// (ISO 7064 Mod 11-2), usado pelo ISNI e pelo ORCID
+func isniDigits(value string) (string, bool) {
+	value = strings.ToUpper(strings.NewReplacer("-", "", " ", "").Replace(value))
+	if len(value) != 16 {
+		return "", false
+	}
+
+	total := 0
+	for _, r := range value[:15] {
+		if r < '0' || r > '9' {
+			return "", false
+		}
+		total = (total + int(r-'0')) * 2
+	}
+	check := (12 - total%11) % 11
+
+	expected := byte('0' + check)
+	if check == 10 {
+		expected = 'X'
+	}
+	if value[15] != expected {
+		return "", false
+	}
+	return value, true
+}
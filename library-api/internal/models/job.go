@@ -0,0 +1,30 @@
+package models
+
+import "time"
+
+// Resultado da última execução de um job
+const (
+	JobSucceeded = "succeeded"
+	JobFailed    = "failed"
+)
+
+// Job guarda o agendamento e a última execução de um job em segundo
+// plano. A trava (LockedBy/LockedUntil) garante que, com várias réplicas
+// no mesmo banco, só uma rode o job de cada vez.
+type Job struct {
+	Name           string     `json:"name" gorm:"primaryKey"`
+	Schedule       string     `json:"schedule"` // expressão cron ou @every
+	NextRunAt      *time.Time `json:"next_run_at" gorm:"index"`
+	LastStartedAt  *time.Time `json:"last_started_at"`
+	LastFinishedAt *time.Time `json:"last_finished_at"`
+	LastStatus     string     `json:"last_status,omitempty"` // succeeded ou failed
+	LastResult     string     `json:"last_result,omitempty"` // resumo devolvido pelo job
+	LastError      string     `json:"last_error,omitempty"`
+	LastDurationMs int64      `json:"last_duration_ms"`
+	Runs           int        `json:"runs"`
+	Failures       int        `json:"failures"`
+	Running        bool       `json:"running" gorm:"-"`
+	LockedBy       *string    `json:"locked_by,omitempty"` // instância que está rodando o job
+	LockedUntil    *time.Time `json:"-"`
+	UpdatedAt      time.Time  `json:"updated_at"`
+}
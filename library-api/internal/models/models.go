@@ -1,40 +1,266 @@
 package models
 
 import (
+	"sort"
+	"strings"
 	"time"
 
 	"gorm.io/gorm"
 )
 
 type Book struct {
-	ID        uint           `json:"id" gorm:"primaryKey"`
-	Title     string         `json:"title" gorm:"not null"`
-	ISBN      string         `json:"isbn" gorm:"unique"`
-	Available bool           `json:"available" gorm:"default:true"`
-	Authors   []Author       `json:"authors,omitempty" gorm:"many2many:book_authors;"`
-	AuthorIDs []uint         `json:"author_ids,omitempty" gorm:"-"`
-	CreatedAt time.Time      `json:"created_at"`
-	UpdatedAt time.Time      `json:"updated_at"`
-	DeletedAt gorm.DeletedAt `json:"-" gorm:"index"`
+	ID              uint           `json:"id" gorm:"primaryKey"`
+	Title           string         `json:"title" gorm:"not null"`
+	ISBN            string         `json:"isbn" gorm:"unique"`
+	Available       bool           `json:"available" gorm:"default:true"`
+	Publisher       string         `json:"publisher" gorm:"index"`
+	PublicationYear int            `json:"publication_year" gorm:"index"`
+	Edition         string         `json:"edition"`
+	Language        string         `json:"language" gorm:"size:3;index"` // código ISO 639
+	Pages           int            `json:"pages"`
+	Description     string         `json:"description"`
+	CoverURL        string         `json:"cover_url"`
+	Authors         []Author       `json:"authors,omitempty" gorm:"many2many:book_authors;"`
+	AuthorIDs       []uint         `json:"author_ids,omitempty" gorm:"-"`
+	Credits         []AuthorCredit `json:"credits,omitempty" gorm:"-"`
+	Subjects        []Subject      `json:"subjects,omitempty" gorm:"many2many:book_subjects;"`
+	SubjectIDs      []uint         `json:"subject_ids,omitempty" gorm:"-"`
+	SeriesID        *uint          `json:"series_id,omitempty" gorm:"index"`
+	SeriesVolume    int            `json:"series_volume,omitempty"`
+	Series          *Series        `json:"series,omitempty" gorm:"foreignKey:SeriesID"`
+	Role            string         `json:"role,omitempty" gorm:"-"`     // papel do autor, em Author.Books
+	Position        int            `json:"position,omitempty" gorm:"-"` // ordem do autor, em Author.Books
+	CreatedAt       time.Time      `json:"created_at"`
+	UpdatedAt       time.Time      `json:"updated_at"`
+	DeletedAt       gorm.DeletedAt `json:"-" gorm:"index"`
 }
 
 type Author struct {
-	ID        uint           `json:"id" gorm:"primaryKey"`
-	Name      string         `json:"name" gorm:"not null"`
-	Bio       string         `json:"bio"`
-	Books     []Book         `json:"books,omitempty" gorm:"many2many:book_authors;"`
-	CreatedAt time.Time      `json:"created_at"`
-	UpdatedAt time.Time      `json:"updated_at"`
-	DeletedAt gorm.DeletedAt `json:"-" gorm:"index"`
+	ID          uint           `json:"id" gorm:"primaryKey"`
+	Name        string         `json:"name" gorm:"not null"`
+	SortName    string         `json:"sort_name" gorm:"index"` // forma de ordenação, como "Tolkien, J. R. R."
+	Bio         string         `json:"bio"`
+	BirthYear   *int           `json:"birth_year,omitempty"`
+	DeathYear   *int           `json:"death_year,omitempty"`
+	Nationality string         `json:"nationality"`
+	ORCID       *string        `json:"orcid,omitempty" gorm:"column:orcid;uniqueIndex"`
+	VIAF        *string        `json:"viaf,omitempty" gorm:"column:viaf;uniqueIndex"`
+	ISNI        *string        `json:"isni,omitempty" gorm:"column:isni;uniqueIndex"`
+	Books       []Book         `json:"books,omitempty" gorm:"many2many:book_authors;"`
+	Aliases     []AuthorAlias  `json:"aliases,omitempty" gorm:"foreignKey:AuthorID"`
+	Role        string         `json:"role,omitempty" gorm:"-"`     // papel no livro, em Book.Authors
+	Position    int            `json:"position,omitempty" gorm:"-"` // ordem de exibição, em Book.Authors
+	CreatedAt   time.Time      `json:"created_at"`
+	UpdatedAt   time.Time      `json:"updated_at"`
+	DeletedAt   gorm.DeletedAt `json:"-" gorm:"index"`
+}
+
+// Tipos de nome alternativo
+const (
+	AliasVariant   = "alias"     // outra grafia, como os nomes unidos em um merge
+	AliasPseudonym = "pseudonym" // pseudônimo
+)
+
+// AuthorAlias é outro nome pelo qual o autor é conhecido
+type AuthorAlias struct {
+	ID        uint      `json:"id" gorm:"primaryKey"`
+	AuthorID  uint      `json:"author_id" gorm:"not null;index"`
+	Name      string    `json:"name" gorm:"not null;index"`
+	Kind      string    `json:"kind" gorm:"not null;default:alias"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+// Papéis de um autor em um livro
+const (
+	RoleAuthor      = "author"
+	RoleEditor      = "editor"
+	RoleTranslator  = "translator"
+	RoleIllustrator = "illustrator"
+)
+
+// BookAuthor é a tabela de ligação book_authors, com o papel do autor no
+// livro e a ordem em que aparece nos créditos
+type BookAuthor struct {
+	BookID   uint   `json:"book_id" gorm:"primaryKey"`
+	AuthorID uint   `json:"author_id" gorm:"primaryKey"`
+	Role     string `json:"role" gorm:"not null;default:author"`
+	Position int    `json:"position" gorm:"not null;default:0"`
+}
+
+// AuthorCredit é um autor com seu papel, como enviado em CreateBook e
+// UpdateBook no lugar de author_ids
+type AuthorCredit struct {
+	AuthorID uint   `json:"author_id"`
+	Role     string `json:"role"`
+}
+
+// ValidRole indica se o papel é um dos aceitos
+func ValidRole(role string) bool {
+	switch role {
+	case RoleAuthor, RoleEditor, RoleTranslator, RoleIllustrator:
+		return true
+	}
+	return false
+}
+
+// SetBookAuthors substitui os créditos do livro; a ordem da lista define a
+// posição de cada autor e papel vazio vale como RoleAuthor
+func SetBookAuthors(tx *gorm.DB, bookID uint, credits []AuthorCredit) error {
+	if err := tx.Where("book_id = ?", bookID).Delete(&BookAuthor{}).Error; err != nil {
+		return err
+	}
+
+	for i, credit := range credits {
+		role := credit.Role
+		if role == "" {
+			role = RoleAuthor
+		}
+		link := BookAuthor{BookID: bookID, AuthorID: credit.AuthorID, Role: role, Position: i + 1}
+		if err := tx.Create(&link).Error; err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// LoadBook carrega o livro com os autores (com papel e posição) e os
+// assuntos
+func LoadBook(db *gorm.DB, id uint) (Book, error) {
+	var book Book
+	if err := db.Preload("Authors").Preload("Subjects").First(&book, id).Error; err != nil {
+		return book, err
+	}
+	FillAuthorRoles(db, &book)
+	return book, nil
+}
+
+// FillAuthorRoles completa papel e posição dos autores já carregados em
+// cada livro e os ordena pela posição
+func FillAuthorRoles(db *gorm.DB, books ...*Book) {
+	if len(books) == 0 {
+		return
+	}
+
+	ids := make([]uint, 0, len(books))
+	for _, book := range books {
+		ids = append(ids, book.ID)
+	}
+
+	links := map[[2]uint]BookAuthor{}
+	var rows []BookAuthor
+	db.Where("book_id IN ?", ids).Find(&rows)
+	for _, link := range rows {
+		links[[2]uint{link.BookID, link.AuthorID}] = link
+	}
+
+	for _, book := range books {
+		for i := range book.Authors {
+			link := links[[2]uint{book.ID, book.Authors[i].ID}]
+			book.Authors[i].Role = link.Role
+			book.Authors[i].Position = link.Position
+		}
+		sort.SliceStable(book.Authors, func(i, j int) bool {
+			return book.Authors[i].Position < book.Authors[j].Position
+		})
+	}
+}
+
+// FillBookRoles completa o papel do autor em cada livro já carregado
+func FillBookRoles(db *gorm.DB, authors ...*Author) {
+	if len(authors) == 0 {
+		return
+	}
+
+	ids := make([]uint, 0, len(authors))
+	for _, author := range authors {
+		ids = append(ids, author.ID)
+	}
+
+	links := map[[2]uint]BookAuthor{}
+	var rows []BookAuthor
+	db.Where("author_id IN ?", ids).Find(&rows)
+	for _, link := range rows {
+		links[[2]uint{link.BookID, link.AuthorID}] = link
+	}
+
+	for _, author := range authors {
+		for i := range author.Books {
+			link := links[[2]uint{author.Books[i].ID, author.ID}]
+			author.Books[i].Role = link.Role
+			author.Books[i].Position = link.Position
+		}
+	}
+}
+
+// Subject é um assunto ou gênero usado para classificar livros
+type Subject struct {
+	ID        uint      `json:"id" gorm:"primaryKey"`
+	Name      string    `json:"name" gorm:"not null;uniqueIndex"`
+	Books     []Book    `json:"books,omitempty" gorm:"many2many:book_subjects;"`
+	CreatedAt time.Time `json:"created_at"`
+	UpdatedAt time.Time `json:"updated_at"`
+}
+
+// BookCover descreve a capa enviada para um livro; a imagem original e as
+// miniaturas ficam no storage, em covers/<book_id>/
+type BookCover struct {
+	BookID      uint      `json:"book_id" gorm:"primaryKey;autoIncrement:false"`
+	ContentType string    `json:"content_type" gorm:"not null"`
+	Extension   string    `json:"-" gorm:"not null"`
+	Width       int       `json:"width"`
+	Height      int       `json:"height"`
+	Size        int64     `json:"size"`
+	Checksum    string    `json:"checksum" gorm:"not null"` // SHA-256 do original
+	CreatedAt   time.Time `json:"created_at"`
+	UpdatedAt   time.Time `json:"updated_at"`
+}
+
+// Series agrupa os volumes de uma obra, ordenados por SeriesVolume
+type Series struct {
+	ID           uint           `json:"id" gorm:"primaryKey"`
+	Name         string         `json:"name" gorm:"not null"`
+	Description  string         `json:"description"`
+	TotalVolumes int            `json:"total_volumes"` // volumes previstos, mesmo os que não estão no acervo
+	Books        []Book         `json:"books,omitempty" gorm:"foreignKey:SeriesID"`
+	CreatedAt    time.Time      `json:"created_at"`
+	UpdatedAt    time.Time      `json:"updated_at"`
+	DeletedAt    gorm.DeletedAt `json:"-" gorm:"index"`
 }
 
 type Loan struct {
 	ID         uint       `json:"id" gorm:"primaryKey"`
-	BookID     uint       `json:"book_id" gorm:"not null"`
+	BookID     uint       `json:"book_id" gorm:"not null;index:idx_loans_book_date,priority:1"`
 	Book       Book       `json:"book" gorm:"foreignKey:BookID"`
-	UserName   string     `json:"user_name" gorm:"not null"`
-	LoanDate   time.Time  `json:"loan_date"`
+	UserName   string     `json:"user_name" gorm:"not null;index"`
+	LoanDate   time.Time  `json:"loan_date" gorm:"index:idx_loans_book_date,priority:2"`
+	DueDate    time.Time  `json:"due_date"`
 	ReturnDate *time.Time `json:"return_date"`
+	Overdue    bool       `json:"overdue" gorm:"-"` // ainda não devolvido e com prazo vencido
+	OverdueAt  *time.Time `json:"-" gorm:"index"`   // quando o atraso foi notado (evento loan.overdue)
 	CreatedAt  time.Time  `json:"created_at"`
 	UpdatedAt  time.Time  `json:"updated_at"`
 }
+
+// LoanPeriod é o prazo padrão de devolução
+const LoanPeriod = 14 * 24 * time.Hour
+
+// IsOverdue informa se o empréstimo segue aberto depois do prazo
+func (l Loan) IsOverdue(now time.Time) bool {
+	return l.ReturnDate == nil && !l.DueDate.IsZero() && now.After(l.DueDate)
+}
+
+// NormalizeLanguage valida um código de idioma ISO 639 (duas letras do
+// 639-1 ou três do 639-2/3) e o devolve em minúsculas
+func NormalizeLanguage(code string) (string, bool) {
+	code = strings.ToLower(strings.TrimSpace(code))
+	if len(code) < 2 || len(code) > 3 {
+		return "", false
+	}
+	for _, r := range code {
+		if r < 'a' || r > 'z' {
+			return "", false
+		}
+	}
+	return code, true
+}
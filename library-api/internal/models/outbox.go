@@ -0,0 +1,28 @@
+package models
+
+import "time"
+
+// Situações de um evento na outbox
+const (
+	OutboxPending    = "pending"    // aguardando os assinantes
+	OutboxProcessing = "processing" // sendo entregue por uma instância, até LockedUntil
+	OutboxDispatched = "dispatched" // todos os assinantes processaram
+	OutboxFailed     = "failed"     // as tentativas acabaram
+)
+
+// OutboxEvent é um evento de domínio gravado na mesma transação da mudança
+// que o gerou. O dispatcher lê a tabela e entrega o evento aos assinantes.
+type OutboxEvent struct {
+	ID            uint       `json:"id" gorm:"primaryKey"`
+	EventID       string     `json:"event_id" gorm:"not null;uniqueIndex"`
+	Type          string     `json:"type" gorm:"not null;index"`
+	Data          string     `json:"data" gorm:"not null"` // JSON do payload
+	Status        string     `json:"status" gorm:"not null;default:pending;index:idx_outbox_queue,priority:1"`
+	Attempts      int        `json:"attempts"`
+	NextAttemptAt time.Time  `json:"next_attempt_at" gorm:"index:idx_outbox_queue,priority:2"`
+	Handled       []string   `json:"handled" gorm:"serializer:json"` // assinantes que já processaram
+	LastError     string     `json:"last_error,omitempty"`
+	DispatchedAt  *time.Time `json:"dispatched_at"`
+	LockedUntil   *time.Time `json:"-"` // fim da posse de quem está entregando
+	CreatedAt     time.Time  `json:"created_at"`
+}
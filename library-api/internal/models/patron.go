@@ -0,0 +1,37 @@
+package models
+
+import "time"
+
+// Idiomas das mensagens enviadas aos leitores
+const (
+	LanguagePortuguese = "pt"
+	LanguageEnglish    = "en"
+)
+
+// Patron guarda o contato de um leitor, identificado pelo mesmo user_name
+// dos empréstimos (sem diferenciar maiúsculas)
+type Patron struct {
+	ID        uint      `json:"id" gorm:"primaryKey"`
+	UserName  string    `json:"user_name" gorm:"not null;uniqueIndex"`
+	Email     string    `json:"email"`
+	Language  string    `json:"language" gorm:"not null;default:pt"` // pt ou en
+	CreatedAt time.Time `json:"created_at"`
+	UpdatedAt time.Time `json:"updated_at"`
+}
+
+// Tipos de aviso enviados sobre um empréstimo
+const (
+	NoticeDueSoon = "due_soon" // o prazo está chegando
+	NoticeOverdue = "overdue"  // o prazo passou
+)
+
+// Notification registra um aviso enviado, para que o mesmo aviso não seja
+// mandado duas vezes sobre o mesmo empréstimo
+type Notification struct {
+	ID        uint      `json:"id" gorm:"primaryKey"`
+	LoanID    uint      `json:"loan_id" gorm:"not null;uniqueIndex:idx_notifications_loan_kind,priority:1"`
+	Kind      string    `json:"kind" gorm:"not null;uniqueIndex:idx_notifications_loan_kind,priority:2"`
+	Recipient string    `json:"recipient" gorm:"not null"`
+	Subject   string    `json:"subject"`
+	SentAt    time.Time `json:"sent_at"`
+}
@@ -0,0 +1,43 @@
+package models
+
+import "time"
+
+// Webhook é uma assinatura de eventos: a API envia um POST assinado para
+// URL a cada evento que passa pelo filtro
+type Webhook struct {
+	ID          uint      `json:"id" gorm:"primaryKey"`
+	URL         string    `json:"url" gorm:"not null"`
+	Description string    `json:"description"`
+	Events      []string  `json:"events" gorm:"serializer:json"`    // vazio recebe todos; aceita "loan.*"
+	Secret      string    `json:"secret,omitempty" gorm:"not null"` // chave do HMAC, só devolvida na criação
+	Active      *bool     `json:"active" gorm:"not null;default:true"`
+	CreatedAt   time.Time `json:"created_at"`
+	UpdatedAt   time.Time `json:"updated_at"`
+}
+
+// Situações de uma entrega
+const (
+	DeliveryPending    = "pending"    // aguardando a primeira tentativa ou uma nova
+	DeliveryProcessing = "processing" // sendo enviada por uma instância, até LockedUntil
+	DeliverySucceeded  = "succeeded"  // o destino respondeu 2xx
+	DeliveryFailed     = "failed"     // as tentativas acabaram
+)
+
+// WebhookDelivery é um evento a entregar para uma assinatura. Funciona
+// como fila (pending, por NextAttemptAt) e como histórico das tentativas.
+type WebhookDelivery struct {
+	ID             uint       `json:"id" gorm:"primaryKey"`
+	WebhookID      uint       `json:"webhook_id" gorm:"not null;uniqueIndex:idx_deliveries_event,priority:1"`
+	EventID        string     `json:"event_id" gorm:"not null;uniqueIndex:idx_deliveries_event,priority:2"`
+	Event          string     `json:"event" gorm:"not null"`
+	Payload        string     `json:"payload" gorm:"not null"`
+	Status         string     `json:"status" gorm:"not null;default:pending;index:idx_deliveries_queue,priority:1"`
+	Attempts       int        `json:"attempts"`
+	NextAttemptAt  time.Time  `json:"next_attempt_at" gorm:"index:idx_deliveries_queue,priority:2"`
+	LastAttemptAt  *time.Time `json:"last_attempt_at"`
+	ResponseStatus int        `json:"response_status,omitempty"`
+	Error          string     `json:"error,omitempty"`
+	DeliveredAt    *time.Time `json:"delivered_at"`
+	LockedUntil    *time.Time `json:"-"` // fim da posse de quem está enviando
+	CreatedAt      time.Time  `json:"created_at"`
+}
@@ -0,0 +1,44 @@
+package notifications
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sync/atomic"
+	"time"
+)
+
+var unsafeFileChars = regexp.MustCompile(`[^A-Za-z0-9._@-]+`)
+
+// FileSink grava cada mensagem como um arquivo .eml, que pode ser aberto
+// num cliente de e-mail. Serve para testar os avisos sem servidor SMTP.
+type FileSink struct {
+	Dir  string
+	From string
+	seq  atomic.Int64
+}
+
+// NewFileSink cria o diretório, se preciso
+func NewFileSink(dir, from string) (*FileSink, error) {
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		return nil, err
+	}
+	if from == "" {
+		from = "biblioteca@localhost"
+	}
+	return &FileSink{Dir: dir, From: from}, nil
+}
+
+func (f *FileSink) Send(ctx context.Context, msg Message) error {
+	now := time.Now()
+	data, err := buildMIME(f.From, msg, now)
+	if err != nil {
+		return err
+	}
+
+	name := fmt.Sprintf("%s-%d-%s.eml", now.UTC().Format("20060102T150405"), f.seq.Add(1),
+		unsafeFileChars.ReplaceAllString(msg.To, "_"))
+	return os.WriteFile(filepath.Join(f.Dir, name), data, 0o644)
+}
@@ -0,0 +1,75 @@
+package notifications
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"os"
+	"strconv"
+	"strings"
+)
+
+// Message é um e-mail com versões em texto e HTML
+type Message struct {
+	To      string
+	Subject string
+	Text    string
+	HTML    string
+}
+
+// Notifier envia mensagens aos leitores
+type Notifier interface {
+	Send(ctx context.Context, msg Message) error
+}
+
+// FromEnv escolhe o Notifier por NOTIFIER:
+//   - "smtp": envia pelo servidor em SMTP_HOST e SMTP_PORT (padrão 587),
+//     com SMTP_USER e SMTP_PASSWORD se houver, a partir de SMTP_FROM
+//   - "file": grava cada mensagem como .eml em NOTIFY_DIR (padrão "outbox")
+//   - "log" (padrão): escreve as mensagens no log
+//   - "none": desativa os avisos (devolve nil)
+func FromEnv() (Notifier, error) {
+	switch strings.ToLower(os.Getenv("NOTIFIER")) {
+	case "none":
+		return nil, nil
+	case "smtp":
+		host := os.Getenv("SMTP_HOST")
+		from := os.Getenv("SMTP_FROM")
+		if host == "" || from == "" {
+			return nil, fmt.Errorf("NOTIFIER=smtp requires SMTP_HOST and SMTP_FROM")
+		}
+		port := 587
+		if v := os.Getenv("SMTP_PORT"); v != "" {
+			p, err := strconv.Atoi(v)
+			if err != nil {
+				return nil, fmt.Errorf("invalid SMTP_PORT %q", v)
+			}
+			port = p
+		}
+		return &SMTP{
+			Host:     host,
+			Port:     port,
+			Username: os.Getenv("SMTP_USER"),
+			Password: os.Getenv("SMTP_PASSWORD"),
+			From:     from,
+		}, nil
+	case "file":
+		dir := os.Getenv("NOTIFY_DIR")
+		if dir == "" {
+			dir = "outbox"
+		}
+		return NewFileSink(dir, os.Getenv("SMTP_FROM"))
+	case "", "log":
+		return LogSink{}, nil
+	default:
+		return nil, fmt.Errorf("unknown NOTIFIER %q", os.Getenv("NOTIFIER"))
+	}
+}
+
+// LogSink só escreve as mensagens no log, para desenvolvimento
+type LogSink struct{}
+
+func (LogSink) Send(ctx context.Context, msg Message) error {
+	slog.InfoContext(ctx, "Notification", "to", msg.To, "subject", msg.Subject, "text", msg.Text)
+	return nil
+}
@@ -0,0 +1,186 @@
+package notifications
+
+import (
+	"context"
+	"fmt"
+	"library-api/internal/models"
+	"log/slog"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// Config define quando os avisos são enviados
+type Config struct {
+	DaysBefore int           // quantos dias antes do prazo sai o lembrete
+	Interval   time.Duration // intervalo entre as verificações (job "reminders")
+	Library    string        // nome que assina as mensagens
+}
+
+// ConfigFromEnv lê REMINDER_DAYS (padrão 2), REMINDER_INTERVAL (padrão 1h)
+// e LIBRARY_NAME (padrão "Biblioteca")
+func ConfigFromEnv() (Config, error) {
+	cfg := Config{DaysBefore: 2, Interval: time.Hour, Library: "Biblioteca"}
+
+	if v := os.Getenv("REMINDER_DAYS"); v != "" {
+		days, err := strconv.Atoi(v)
+		if err != nil || days < 0 {
+			return cfg, fmt.Errorf("invalid REMINDER_DAYS %q", v)
+		}
+		cfg.DaysBefore = days
+	}
+	if v := os.Getenv("REMINDER_INTERVAL"); v != "" {
+		interval, err := time.ParseDuration(v)
+		if err != nil || interval <= 0 {
+			return cfg, fmt.Errorf("invalid REMINDER_INTERVAL %q", v)
+		}
+		cfg.Interval = interval
+	}
+	if v := strings.TrimSpace(os.Getenv("LIBRARY_NAME")); v != "" {
+		cfg.Library = v
+	}
+	return cfg, nil
+}
+
+// SendReminders avisa os leitores com e-mail cadastrado sobre os
+// empréstimos que vencem em até cfg.DaysBefore dias e sobre os atrasados.
+// Cada aviso é mandado uma única vez por empréstimo e registrado em
+// Notification depois do envio; se o registro falhar, o aviso pode se
+// repetir. Devolve quantas mensagens foram enviadas.
+func SendReminders(ctx context.Context, db *gorm.DB, notifier Notifier, cfg Config, now time.Time) (int, error) {
+	limit := startOfDay(now).AddDate(0, 0, cfg.DaysBefore+1)
+
+	// As datas passam por julianday porque o texto gravado traz o
+	// deslocamento do fuso, e a comparação de texto erraria a janela
+	var dueSoon, overdue []models.Loan
+	err := pendingLoans(db, models.NoticeDueSoon).
+		Where("julianday(loans.due_date) >= julianday(?) AND julianday(loans.due_date) < julianday(?)", now, limit).Find(&dueSoon).Error
+	if err != nil {
+		return 0, err
+	}
+	err = pendingLoans(db, models.NoticeOverdue).
+		Where("julianday(loans.due_date) < julianday(?)", now).Find(&overdue).Error
+	if err != nil {
+		return 0, err
+	}
+	if len(dueSoon)+len(overdue) == 0 {
+		return 0, nil
+	}
+
+	books := make([]*models.Book, 0, len(dueSoon)+len(overdue))
+	names := map[string]bool{}
+	for _, loans := range [][]models.Loan{dueSoon, overdue} {
+		for i := range loans {
+			books = append(books, &loans[i].Book)
+			names[strings.ToLower(loans[i].UserName)] = true
+		}
+	}
+	models.FillAuthorRoles(db, books...)
+
+	lower := make([]string, 0, len(names))
+	for name := range names {
+		lower = append(lower, name)
+	}
+	var list []models.Patron
+	if err := db.Where("LOWER(user_name) IN ?", lower).Find(&list).Error; err != nil {
+		return 0, err
+	}
+	patrons := map[string]models.Patron{}
+	for _, patron := range list {
+		patrons[strings.ToLower(patron.UserName)] = patron
+	}
+
+	sent := 0
+	for _, loan := range dueSoon {
+		if ctx.Err() != nil {
+			return sent, ctx.Err()
+		}
+		days := daysBetween(now, loan.DueDate)
+		if notify(ctx, db, notifier, cfg, models.NoticeDueSoon, loan, patrons[strings.ToLower(loan.UserName)], days, now) {
+			sent++
+		}
+	}
+	for _, loan := range overdue {
+		if ctx.Err() != nil {
+			return sent, ctx.Err()
+		}
+		days := daysBetween(loan.DueDate, now)
+		if notify(ctx, db, notifier, cfg, models.NoticeOverdue, loan, patrons[strings.ToLower(loan.UserName)], days, now) {
+			sent++
+		}
+	}
+	return sent, nil
+}
+
+// pendingLoans seleciona os empréstimos em aberto de leitores com e-mail
+// que ainda não receberam o aviso kind
+func pendingLoans(db *gorm.DB, kind string) *gorm.DB {
+	return db.Preload("Book.Authors").
+		Joins("JOIN patrons ON LOWER(patrons.user_name) = LOWER(loans.user_name)").
+		Where("loans.return_date IS NULL AND patrons.email <> ''").
+		Where("NOT EXISTS (SELECT 1 FROM notifications WHERE notifications.loan_id = loans.id AND notifications.kind = ?)", kind).
+		Order("loans.due_date")
+}
+
+// notify envia um aviso e o registra. Falhas vão para o log e o aviso
+// fica para a próxima verificação.
+func notify(ctx context.Context, db *gorm.DB, notifier Notifier, cfg Config, kind string,
+	loan models.Loan, patron models.Patron, days int, now time.Time) bool {
+	language := patron.Language
+	if _, ok := dateLayouts[language]; !ok {
+		language = models.LanguagePortuguese
+	}
+
+	authors := make([]string, 0, len(loan.Book.Authors))
+	for _, author := range loan.Book.Authors {
+		authors = append(authors, author.Name)
+	}
+
+	msg, err := Render(kind, language, Notice{
+		Library:  cfg.Library,
+		UserName: loan.UserName,
+		Title:    loan.Book.Title,
+		Authors:  strings.Join(authors, ", "),
+		LoanDate: formatDate(language, loan.LoanDate),
+		DueDate:  formatDate(language, loan.DueDate),
+		Days:     days,
+	})
+	if err != nil {
+		slog.ErrorContext(ctx, "Failed to render notification", "loan_id", loan.ID, "kind", kind, "error", err)
+		return false
+	}
+	msg.To = patron.Email
+
+	if err := notifier.Send(ctx, msg); err != nil {
+		slog.ErrorContext(ctx, "Failed to send notification", "loan_id", loan.ID, "kind", kind, "error", err)
+		return false
+	}
+
+	err = db.Create(&models.Notification{
+		LoanID:    loan.ID,
+		Kind:      kind,
+		Recipient: msg.To,
+		Subject:   msg.Subject,
+		SentAt:    now,
+	}).Error
+	if err != nil {
+		slog.ErrorContext(ctx, "Failed to record notification", "loan_id", loan.ID, "kind", kind, "error", err)
+	}
+	return true
+}
+
+// startOfDay é a meia-noite do dia de t, no fuso de t
+func startOfDay(t time.Time) time.Time {
+	year, month, day := t.Date()
+	return time.Date(year, month, day, 0, 0, 0, 0, t.Location())
+}
+
+// daysBetween conta os dias de calendário de from até to
+func daysBetween(from, to time.Time) int {
+	to = to.In(from.Location())
+	a, b := startOfDay(from), startOfDay(to)
+	return int(b.Sub(a).Hours()+12) / 24
+}
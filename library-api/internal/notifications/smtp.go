@@ -0,0 +1,43 @@
+package notifications
+
+import (
+	"context"
+	"fmt"
+	"net/mail"
+	"net/smtp"
+	"strconv"
+	"time"
+)
+
+// SMTP envia as mensagens por um servidor SMTP. Com Username, autentica
+// com PLAIN, o que o net/smtp só permite com TLS (STARTTLS) ou em localhost.
+type SMTP struct {
+	Host     string
+	Port     int
+	Username string
+	Password string
+	From     string
+}
+
+func (s *SMTP) Send(ctx context.Context, msg Message) error {
+	from, err := mail.ParseAddress(s.From)
+	if err != nil {
+		return fmt.Errorf("invalid sender: %w", err)
+	}
+	to, err := mail.ParseAddress(msg.To)
+	if err != nil {
+		return fmt.Errorf("invalid recipient: %w", err)
+	}
+
+	data, err := buildMIME(from.String(), msg, time.Now())
+	if err != nil {
+		return err
+	}
+
+	var auth smtp.Auth
+	if s.Username != "" {
+		auth = smtp.PlainAuth("", s.Username, s.Password, s.Host)
+	}
+	addr := s.Host + ":" + strconv.Itoa(s.Port)
+	return smtp.SendMail(addr, auth, from.Address, []string{to.Address}, data)
+}
@@ -0,0 +1,90 @@
+package notifications
+
+import (
+	"bytes"
+	"embed"
+	"fmt"
+	htmltemplate "html/template"
+	"library-api/internal/models"
+	"strings"
+	texttemplate "text/template"
+	"time"
+)
+
+//go:embed templates
+var templateFS embed.FS
+
+// Notice são os dados usados nos modelos de mensagem
+type Notice struct {
+	Library  string
+	UserName string
+	Title    string
+	Authors  string // nomes separados por vírgula
+	LoanDate string // já formatada no idioma da mensagem
+	DueDate  string
+	Days     int // até o prazo (due_soon) ou desde o prazo (overdue)
+}
+
+// dateLayouts é o formato de data de cada idioma
+var dateLayouts = map[string]string{
+	models.LanguagePortuguese: "02/01/2006",
+	models.LanguageEnglish:    "January 2, 2006",
+}
+
+type messageTemplate struct {
+	text *texttemplate.Template
+	html *htmltemplate.Template
+}
+
+// templates guarda os modelos por "<tipo>.<idioma>", como "overdue.en"
+var templates = map[string]messageTemplate{}
+
+func init() {
+	for _, kind := range []string{models.NoticeDueSoon, models.NoticeOverdue} {
+		for language := range dateLayouts {
+			name := kind + "." + language
+			templates[name] = messageTemplate{
+				text: texttemplate.Must(texttemplate.ParseFS(templateFS, "templates/"+name+".txt")),
+				html: htmltemplate.Must(htmltemplate.ParseFS(templateFS, "templates/"+name+".html")),
+			}
+		}
+	}
+}
+
+// Render monta a mensagem do tipo e idioma informados; idiomas sem
+// modelo usam o português
+func Render(kind, language string, notice Notice) (Message, error) {
+	tmpl, ok := templates[kind+"."+language]
+	if !ok {
+		tmpl, ok = templates[kind+"."+models.LanguagePortuguese]
+	}
+	if !ok {
+		return Message{}, fmt.Errorf("no template for notice %q", kind)
+	}
+
+	var subject, text, html bytes.Buffer
+	if err := tmpl.text.ExecuteTemplate(&subject, "subject", notice); err != nil {
+		return Message{}, err
+	}
+	if err := tmpl.text.Execute(&text, notice); err != nil {
+		return Message{}, err
+	}
+	if err := tmpl.html.Execute(&html, notice); err != nil {
+		return Message{}, err
+	}
+
+	return Message{
+		Subject: strings.TrimSpace(subject.String()),
+		Text:    strings.TrimSpace(text.String()) + "\n",
+		HTML:    html.String(),
+	}, nil
+}
+
+// formatDate formata a data no idioma da mensagem
+func formatDate(language string, t time.Time) string {
+	layout, ok := dateLayouts[language]
+	if !ok {
+		layout = dateLayouts[models.LanguagePortuguese]
+	}
+	return t.Format(layout)
+}
@@ -0,0 +1,233 @@
+package reports
+
+import (
+	"fmt"
+	"strconv"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// Períodos de agrupamento de LoansPerPeriod, com o formato do strftime
+var periods = map[string]string{
+	"day":   "%Y-%m-%d",
+	"week":  "%Y-W%W",
+	"month": "%Y-%m",
+}
+
+// ValidPeriod informa se o período é day, week ou month
+func ValidPeriod(period string) bool {
+	_, ok := periods[period]
+	return ok
+}
+
+// Range limita os relatórios pela data do empréstimo; From e To podem ser
+// nil. To é exclusivo.
+//
+// Os relatórios usam o fuso do servidor (TZ): as datas gravadas levam o
+// deslocamento de quando foram criadas, então as comparações passam por
+// julianday, que compara instantes, e os agrupamentos e datas devolvidas
+// usam o modificador 'localtime' do strftime, no mesmo fuso em que from e
+// to são lidos.
+type Range struct {
+	From *time.Time
+	To   *time.Time
+}
+
+// apply filtra a coluna de data de empréstimo informada pelo intervalo
+func (r Range) apply(db *gorm.DB, column string) *gorm.DB {
+	if r.From != nil {
+		db = db.Where("julianday("+column+") >= julianday(?)", *r.From)
+	}
+	if r.To != nil {
+		db = db.Where("julianday("+column+") < julianday(?)", *r.To)
+	}
+	return db
+}
+
+// Record é uma linha de relatório que também pode ser escrita em CSV
+type Record interface {
+	Header() []string
+	Values() []string
+}
+
+// PeriodCount é a quantidade de empréstimos e devoluções de um período
+type PeriodCount struct {
+	Period  string `json:"period"`
+	Loans   int64  `json:"loans"`
+	Returns int64  `json:"returns"` // dos empréstimos feitos no período
+}
+
+func (PeriodCount) Header() []string { return []string{"period", "loans", "returns"} }
+func (p PeriodCount) Values() []string {
+	return []string{p.Period, itoa(p.Loans), itoa(p.Returns)}
+}
+
+// LoansPerPeriod conta os empréstimos por dia, semana ou mês
+func LoansPerPeriod(db *gorm.DB, r Range, period string) ([]PeriodCount, error) {
+	format, ok := periods[period]
+	if !ok {
+		return nil, fmt.Errorf("invalid period %q", period)
+	}
+
+	rows := []PeriodCount{}
+	err := r.apply(db.Table("loans"), "loan_date").
+		Select("strftime(?, loan_date, 'localtime') AS period, COUNT(*) AS loans, COUNT(return_date) AS returns", format).
+		Group("period").Order("period").Scan(&rows).Error
+	return rows, err
+}
+
+// BookCount é um livro e a quantidade de empréstimos
+type BookCount struct {
+	BookID uint   `json:"book_id"`
+	Title  string `json:"title"`
+	ISBN   string `json:"isbn"`
+	Loans  int64  `json:"loans"`
+}
+
+func (BookCount) Header() []string { return []string{"book_id", "title", "isbn", "loans"} }
+func (b BookCount) Values() []string {
+	return []string{itoa(int64(b.BookID)), b.Title, b.ISBN, itoa(b.Loans)}
+}
+
+// TopBooks lista os livros mais emprestados
+func TopBooks(db *gorm.DB, r Range, limit int) ([]BookCount, error) {
+	rows := []BookCount{}
+	err := r.apply(db.Table("loans"), "loans.loan_date").
+		Select("books.id AS book_id, books.title, books.isbn, COUNT(*) AS loans").
+		Joins("JOIN books ON books.id = loans.book_id AND books.deleted_at IS NULL").
+		Group("books.id").Order("loans DESC, books.title").Limit(limit).Scan(&rows).Error
+	return rows, err
+}
+
+// AuthorCount é um autor e a quantidade de empréstimos dos seus livros
+type AuthorCount struct {
+	AuthorID uint   `json:"author_id"`
+	Name     string `json:"name"`
+	Loans    int64  `json:"loans"`
+}
+
+func (AuthorCount) Header() []string { return []string{"author_id", "name", "loans"} }
+func (a AuthorCount) Values() []string {
+	return []string{itoa(int64(a.AuthorID)), a.Name, itoa(a.Loans)}
+}
+
+// TopAuthors lista os autores mais emprestados; tradutores, editores e
+// ilustradores não entram na conta
+func TopAuthors(db *gorm.DB, r Range, limit int) ([]AuthorCount, error) {
+	rows := []AuthorCount{}
+	err := r.apply(db.Table("loans"), "loans.loan_date").
+		Select("authors.id AS author_id, authors.name, COUNT(*) AS loans").
+		Joins("JOIN book_authors ON book_authors.book_id = loans.book_id AND book_authors.role = ?", "author").
+		Joins("JOIN authors ON authors.id = book_authors.author_id AND authors.deleted_at IS NULL").
+		Group("authors.id").Order("loans DESC, authors.name").Limit(limit).Scan(&rows).Error
+	return rows, err
+}
+
+// IdleBook é um livro sem empréstimos
+type IdleBook struct {
+	BookID    uint   `json:"book_id"`
+	Title     string `json:"title"`
+	ISBN      string `json:"isbn"`
+	AddedDate string `json:"added_date"` // data de cadastro, AAAA-MM-DD
+}
+
+func (IdleBook) Header() []string { return []string{"book_id", "title", "isbn", "added_date"} }
+func (b IdleBook) Values() []string {
+	return []string{itoa(int64(b.BookID)), b.Title, b.ISBN, b.AddedDate}
+}
+
+// NeverBorrowed lista os livros sem nenhum empréstimo no intervalo
+func NeverBorrowed(db *gorm.DB, r Range) ([]IdleBook, error) {
+	rows := []IdleBook{}
+	borrowed := r.apply(db.Table("loans"), "loan_date").Select("book_id")
+	err := db.Table("books").
+		Select("id AS book_id, title, isbn, strftime('%Y-%m-%d', created_at, 'localtime') AS added_date").
+		Where("deleted_at IS NULL AND id NOT IN (?)", borrowed).
+		Order("title").Scan(&rows).Error
+	return rows, err
+}
+
+// PatronCount é um leitor e seus empréstimos no intervalo
+type PatronCount struct {
+	UserName     string `json:"user_name"`
+	Loans        int64  `json:"loans"`
+	LastLoanDate string `json:"last_loan_date"` // AAAA-MM-DD
+}
+
+func (PatronCount) Header() []string { return []string{"user_name", "loans", "last_loan_date"} }
+func (p PatronCount) Values() []string {
+	return []string{p.UserName, itoa(p.Loans), p.LastLoanDate}
+}
+
+// ActivePatrons lista os leitores que pegaram livros no intervalo, dos
+// que mais pegaram para os que menos pegaram
+func ActivePatrons(db *gorm.DB, r Range, limit int) ([]PatronCount, error) {
+	rows := []PatronCount{}
+	err := r.apply(db.Table("loans"), "loan_date").
+		Select("MIN(user_name) AS user_name, COUNT(*) AS loans, strftime('%Y-%m-%d', MAX(julianday(loan_date)), 'localtime') AS last_loan_date").
+		Group("LOWER(user_name)").Order("loans DESC, user_name").Limit(limit).Scan(&rows).Error
+	return rows, err
+}
+
+// Summary são os indicadores gerais dos empréstimos feitos no intervalo
+type Summary struct {
+	TotalLoans      int64    `json:"total_loans"`
+	Returned        int64    `json:"returned"`
+	Active          int64    `json:"active"`
+	Overdue         int64    `json:"overdue"`       // em aberto com prazo vencido
+	ReturnedLate    int64    `json:"returned_late"` // devolvidos depois do prazo
+	OverdueRate     float64  `json:"overdue_rate"`  // (overdue + returned_late) / total_loans
+	AverageLoanDays *float64 `json:"average_loan_days"`
+	ActivePatrons   int64    `json:"active_patrons"`
+	BooksBorrowed   int64    `json:"books_borrowed"` // livros diferentes
+}
+
+func (Summary) Header() []string {
+	return []string{"total_loans", "returned", "active", "overdue", "returned_late",
+		"overdue_rate", "average_loan_days", "active_patrons", "books_borrowed"}
+}
+func (s Summary) Values() []string {
+	avg := ""
+	if s.AverageLoanDays != nil {
+		avg = strconv.FormatFloat(*s.AverageLoanDays, 'f', 1, 64)
+	}
+	return []string{itoa(s.TotalLoans), itoa(s.Returned), itoa(s.Active), itoa(s.Overdue),
+		itoa(s.ReturnedLate), strconv.FormatFloat(s.OverdueRate, 'f', 3, 64), avg,
+		itoa(s.ActivePatrons), itoa(s.BooksBorrowed)}
+}
+
+// Summarize calcula os indicadores em uma única consulta
+func Summarize(db *gorm.DB, r Range, now time.Time) (Summary, error) {
+	var s Summary
+	err := r.apply(db.Table("loans"), "loan_date").
+		Select(`COUNT(*) AS total_loans,
+			COUNT(return_date) AS returned,
+			COUNT(*) - COUNT(return_date) AS active,
+			COALESCE(SUM(CASE WHEN return_date IS NULL AND julianday(due_date) < julianday(?) THEN 1 ELSE 0 END), 0) AS overdue,
+			COALESCE(SUM(CASE WHEN julianday(return_date) > julianday(due_date) THEN 1 ELSE 0 END), 0) AS returned_late,
+			AVG(julianday(return_date) - julianday(loan_date)) AS average_loan_days,
+			COUNT(DISTINCT LOWER(user_name)) AS active_patrons,
+			COUNT(DISTINCT book_id) AS books_borrowed`, now).
+		Scan(&s).Error
+	if err != nil {
+		return s, err
+	}
+
+	if s.TotalLoans > 0 {
+		s.OverdueRate = round(float64(s.Overdue+s.ReturnedLate)/float64(s.TotalLoans), 1000)
+	}
+	if s.AverageLoanDays != nil {
+		days := round(*s.AverageLoanDays, 10)
+		s.AverageLoanDays = &days
+	}
+	return s, nil
+}
+
+func itoa(n int64) string {
+	return strconv.FormatInt(n, 10)
+}
+
+func round(v, scale float64) float64 {
+	return float64(int64(v*scale+0.5)) / scale
+}
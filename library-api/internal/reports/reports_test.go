@@ -0,0 +1,87 @@
+package reports
+
+import (
+	"library-api/internal/models"
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+	"time"
+
+	"gorm.io/driver/sqlite"
+	"gorm.io/gorm"
+	"gorm.io/gorm/logger"
+)
+
+// TestMain fixa o fuso em São Paulo (UTC-3) antes de qualquer consulta,
+// para o Go e para o 'localtime' do SQLite
+func TestMain(m *testing.M) {
+	os.Setenv("TZ", "America/Sao_Paulo")
+	loc, err := time.LoadLocation("America/Sao_Paulo")
+	if err != nil {
+		panic(err)
+	}
+	time.Local = loc
+	os.Exit(m.Run())
+}
+
+func openReports(t *testing.T) *gorm.DB {
+	t.Helper()
+	db, err := gorm.Open(sqlite.Open(filepath.Join(t.TempDir(), "reports.db")), &gorm.Config{Logger: logger.Discard})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := db.AutoMigrate(&models.Book{}, &models.Loan{}); err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() {
+		sqlDB, _ := db.DB()
+		sqlDB.Close()
+	})
+	return db
+}
+
+func TestReportsUseLocalTime(t *testing.T) {
+	db := openReports(t)
+	book := models.Book{Title: "Dom Casmurro"}
+	db.Create(&book)
+
+	// 22:30 do dia 31 em São Paulo já é dia 1 em UTC; um empréstimo foi
+	// gravado com o deslocamento local e o outro em UTC
+	late := time.Date(2026, 1, 31, 22, 30, 0, 0, time.Local)
+	loans := []models.Loan{
+		{BookID: book.ID, UserName: "ana", LoanDate: late, DueDate: late.AddDate(0, 0, 14)},
+		{BookID: book.ID, UserName: "bia", LoanDate: late.Add(time.Minute).UTC(), DueDate: late.AddDate(0, 0, 14).UTC()},
+	}
+	if err := db.Omit("Book").Create(&loans).Error; err != nil {
+		t.Fatal(err)
+	}
+
+	days, err := LoansPerPeriod(db, Range{}, "day")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := []PeriodCount{{Period: "2026-01-31", Loans: 2}}; !reflect.DeepEqual(days, want) {
+		t.Errorf("LoansPerPeriod = %+v, want %+v", days, want)
+	}
+
+	// O intervalo de 31/01 inclui os dois; o de 01/02, nenhum
+	from := time.Date(2026, 1, 31, 0, 0, 0, 0, time.Local)
+	to := from.AddDate(0, 0, 1)
+	if s, _ := Summarize(db, Range{From: &from, To: &to}, late); s.TotalLoans != 2 {
+		t.Errorf("loans on 2026-01-31 = %d, want 2", s.TotalLoans)
+	}
+	if s, _ := Summarize(db, Range{From: &to}, late); s.TotalLoans != 0 {
+		t.Errorf("loans from 2026-02-01 = %d, want 0", s.TotalLoans)
+	}
+
+	patrons, err := ActivePatrons(db, Range{}, 10)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, p := range patrons {
+		if p.LastLoanDate != "2026-01-31" {
+			t.Errorf("%s: last_loan_date = %q, want 2026-01-31", p.UserName, p.LastLoanDate)
+		}
+	}
+}
@@ -0,0 +1,62 @@
+package rpc
+
+import (
+	"context"
+	"library-api/internal/rpc/libraryv1"
+	"library-api/internal/service"
+
+	"gorm.io/gorm"
+)
+
+type authorServer struct {
+	libraryv1.UnimplementedAuthorServiceServer
+	db *gorm.DB
+}
+
+func (s *authorServer) ListAuthors(ctx context.Context, req *libraryv1.ListAuthorsRequest) (*libraryv1.ListAuthorsResponse, error) {
+	authors, err := service.ListAuthors(s.db.WithContext(ctx), service.AuthorFilter{
+		Query:       req.Q,
+		Nationality: req.Nationality,
+	})
+	if err != nil {
+		return nil, statusError(err)
+	}
+
+	resp := &libraryv1.ListAuthorsResponse{Authors: make([]*libraryv1.Author, len(authors))}
+	for i, author := range authors {
+		resp.Authors[i] = toAuthor(author)
+	}
+	return resp, nil
+}
+
+func (s *authorServer) GetAuthor(ctx context.Context, req *libraryv1.GetAuthorRequest) (*libraryv1.Author, error) {
+	author, err := service.GetAuthor(s.db.WithContext(ctx), uint(req.Id))
+	if err != nil {
+		return nil, statusError(err)
+	}
+	return toAuthor(author), nil
+}
+
+func (s *authorServer) CreateAuthor(ctx context.Context, req *libraryv1.CreateAuthorRequest) (*libraryv1.Author, error) {
+	author, err := service.CreateAuthor(s.db.WithContext(ctx), fromAuthorInput(req.GetAuthor()))
+	if err != nil {
+		return nil, statusError(err)
+	}
+	created(ctx)
+	return toAuthor(author), nil
+}
+
+func (s *authorServer) UpdateAuthor(ctx context.Context, req *libraryv1.UpdateAuthorRequest) (*libraryv1.Author, error) {
+	author, err := service.UpdateAuthor(s.db.WithContext(ctx), uint(req.Id), fromAuthorInput(req.GetAuthor()))
+	if err != nil {
+		return nil, statusError(err)
+	}
+	return toAuthor(author), nil
+}
+
+func (s *authorServer) DeleteAuthor(ctx context.Context, req *libraryv1.DeleteAuthorRequest) (*libraryv1.DeleteResponse, error) {
+	if err := service.DeleteAuthor(s.db.WithContext(ctx), uint(req.Id)); err != nil {
+		return nil, statusError(err)
+	}
+	return &libraryv1.DeleteResponse{Message: "Author deleted"}, nil
+}
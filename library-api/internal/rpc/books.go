@@ -0,0 +1,73 @@
+package rpc
+
+import (
+	"context"
+	"library-api/internal/rpc/libraryv1"
+	"library-api/internal/service"
+
+	"gorm.io/gorm"
+)
+
+type bookServer struct {
+	libraryv1.UnimplementedBookServiceServer
+	db *gorm.DB
+}
+
+func (s *bookServer) ListBooks(ctx context.Context, req *libraryv1.ListBooksRequest) (*libraryv1.ListBooksResponse, error) {
+	books, err := service.ListBooks(s.db.WithContext(ctx), service.BookFilter{
+		Title:     req.Title,
+		ISBN:      req.Isbn,
+		Available: req.Available,
+		AuthorID:  uint(req.AuthorId),
+		Author:    req.Author,
+		Publisher: req.Publisher,
+		Language:  req.Language,
+		Year:      optionalInt(req.Year),
+		YearFrom:  optionalInt(req.YearFrom),
+		YearTo:    optionalInt(req.YearTo),
+		SubjectID: uint(req.SubjectId),
+		Subject:   req.Subject,
+		SeriesID:  uint(req.SeriesId),
+	})
+	if err != nil {
+		return nil, statusError(err)
+	}
+
+	resp := &libraryv1.ListBooksResponse{Books: make([]*libraryv1.Book, len(books))}
+	for i, book := range books {
+		resp.Books[i] = toBook(book)
+	}
+	return resp, nil
+}
+
+func (s *bookServer) GetBook(ctx context.Context, req *libraryv1.GetBookRequest) (*libraryv1.Book, error) {
+	book, err := service.GetBook(s.db.WithContext(ctx), uint(req.Id))
+	if err != nil {
+		return nil, statusError(err)
+	}
+	return toBook(book), nil
+}
+
+func (s *bookServer) CreateBook(ctx context.Context, req *libraryv1.CreateBookRequest) (*libraryv1.Book, error) {
+	book, err := service.CreateBook(s.db.WithContext(ctx), fromBookInput(req.GetBook()), nil)
+	if err != nil {
+		return nil, statusError(err)
+	}
+	created(ctx)
+	return toBook(book), nil
+}
+
+func (s *bookServer) UpdateBook(ctx context.Context, req *libraryv1.UpdateBookRequest) (*libraryv1.Book, error) {
+	book, err := service.UpdateBook(s.db.WithContext(ctx), uint(req.Id), fromBookInput(req.GetBook()), req.GetBook().Available)
+	if err != nil {
+		return nil, statusError(err)
+	}
+	return toBook(book), nil
+}
+
+func (s *bookServer) DeleteBook(ctx context.Context, req *libraryv1.DeleteBookRequest) (*libraryv1.DeleteResponse, error) {
+	if err := service.DeleteBook(s.db.WithContext(ctx), uint(req.Id)); err != nil {
+		return nil, statusError(err)
+	}
+	return &libraryv1.DeleteResponse{Message: "Book deleted"}, nil
+}
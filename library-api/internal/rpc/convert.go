@@ -0,0 +1,193 @@
+package rpc
+
+import (
+	"library-api/internal/models"
+	"library-api/internal/rpc/libraryv1"
+	"time"
+
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+func timestamp(t time.Time) *timestamppb.Timestamp {
+	if t.IsZero() {
+		return nil
+	}
+	return timestamppb.New(t)
+}
+
+func optionalInt32(v *int) *int32 {
+	if v == nil {
+		return nil
+	}
+	n := int32(*v)
+	return &n
+}
+
+func optionalInt(v *int32) *int {
+	if v == nil {
+		return nil
+	}
+	n := int(*v)
+	return &n
+}
+
+func ids(values []uint32) []uint {
+	if values == nil {
+		return nil
+	}
+	result := make([]uint, len(values))
+	for i, v := range values {
+		result[i] = uint(v)
+	}
+	return result
+}
+
+func toBook(book models.Book) *libraryv1.Book {
+	pb := &libraryv1.Book{
+		Id:              uint32(book.ID),
+		Title:           book.Title,
+		Isbn:            book.ISBN,
+		Available:       book.Available,
+		Publisher:       book.Publisher,
+		PublicationYear: int32(book.PublicationYear),
+		Edition:         book.Edition,
+		Language:        book.Language,
+		Pages:           int32(book.Pages),
+		Description:     book.Description,
+		CoverUrl:        book.CoverURL,
+		SeriesVolume:    int32(book.SeriesVolume),
+		Role:            book.Role,
+		Position:        int32(book.Position),
+		CreatedAt:       timestamp(book.CreatedAt),
+		UpdatedAt:       timestamp(book.UpdatedAt),
+	}
+	if book.SeriesID != nil {
+		id := uint32(*book.SeriesID)
+		pb.SeriesId = &id
+	}
+	if book.Series != nil {
+		pb.Series = &libraryv1.Series{
+			Id:           uint32(book.Series.ID),
+			Name:         book.Series.Name,
+			Description:  book.Series.Description,
+			TotalVolumes: int32(book.Series.TotalVolumes),
+			CreatedAt:    timestamp(book.Series.CreatedAt),
+			UpdatedAt:    timestamp(book.Series.UpdatedAt),
+		}
+	}
+	for _, author := range book.Authors {
+		pb.Authors = append(pb.Authors, toAuthor(author))
+	}
+	for _, subject := range book.Subjects {
+		pb.Subjects = append(pb.Subjects, &libraryv1.Subject{
+			Id:        uint32(subject.ID),
+			Name:      subject.Name,
+			CreatedAt: timestamp(subject.CreatedAt),
+			UpdatedAt: timestamp(subject.UpdatedAt),
+		})
+	}
+	return pb
+}
+
+func toAuthor(author models.Author) *libraryv1.Author {
+	pb := &libraryv1.Author{
+		Id:          uint32(author.ID),
+		Name:        author.Name,
+		SortName:    author.SortName,
+		Bio:         author.Bio,
+		BirthYear:   optionalInt32(author.BirthYear),
+		DeathYear:   optionalInt32(author.DeathYear),
+		Nationality: author.Nationality,
+		Orcid:       author.ORCID,
+		Viaf:        author.VIAF,
+		Isni:        author.ISNI,
+		Role:        author.Role,
+		Position:    int32(author.Position),
+		CreatedAt:   timestamp(author.CreatedAt),
+		UpdatedAt:   timestamp(author.UpdatedAt),
+	}
+	for _, book := range author.Books {
+		pb.Books = append(pb.Books, toBook(book))
+	}
+	for _, alias := range author.Aliases {
+		pb.Aliases = append(pb.Aliases, &libraryv1.AuthorAlias{
+			Id:        uint32(alias.ID),
+			AuthorId:  uint32(alias.AuthorID),
+			Name:      alias.Name,
+			Kind:      alias.Kind,
+			CreatedAt: timestamp(alias.CreatedAt),
+		})
+	}
+	return pb
+}
+
+func toLoan(loan models.Loan) *libraryv1.Loan {
+	pb := &libraryv1.Loan{
+		Id:        uint32(loan.ID),
+		BookId:    uint32(loan.BookID),
+		UserName:  loan.UserName,
+		LoanDate:  timestamp(loan.LoanDate),
+		DueDate:   timestamp(loan.DueDate),
+		Overdue:   loan.Overdue,
+		CreatedAt: timestamp(loan.CreatedAt),
+		UpdatedAt: timestamp(loan.UpdatedAt),
+	}
+	if loan.Book.ID != 0 {
+		pb.Book = toBook(loan.Book)
+	}
+	if loan.ReturnDate != nil {
+		pb.ReturnDate = timestamppb.New(*loan.ReturnDate)
+	}
+	return pb
+}
+
+// fromBookInput converte BookInput no livro recebido pelo serviço
+func fromBookInput(input *libraryv1.BookInput) models.Book {
+	book := models.Book{
+		Title:           input.GetTitle(),
+		ISBN:            input.GetIsbn(),
+		Publisher:       input.GetPublisher(),
+		PublicationYear: int(input.GetPublicationYear()),
+		Edition:         input.GetEdition(),
+		Language:        input.GetLanguage(),
+		Pages:           int(input.GetPages()),
+		Description:     input.GetDescription(),
+		CoverURL:        input.GetCoverUrl(),
+		SeriesVolume:    int(input.GetSeriesVolume()),
+		AuthorIDs:       ids(input.GetAuthorIds()),
+		SubjectIDs:      ids(input.GetSubjectIds()),
+	}
+	if input.Available != nil {
+		book.Available = *input.Available
+	}
+	if input.SeriesId != nil {
+		id := uint(*input.SeriesId)
+		book.SeriesID = &id
+	}
+	for _, credit := range input.GetCredits() {
+		book.Credits = append(book.Credits, models.AuthorCredit{AuthorID: uint(credit.AuthorId), Role: credit.Role})
+	}
+	return book
+}
+
+// fromAuthorInput converte AuthorInput no autor recebido pelo serviço
+func fromAuthorInput(input *libraryv1.AuthorInput) models.Author {
+	author := models.Author{
+		Name:        input.GetName(),
+		SortName:    input.GetSortName(),
+		Bio:         input.GetBio(),
+		BirthYear:   optionalInt(input.BirthYear),
+		DeathYear:   optionalInt(input.DeathYear),
+		Nationality: input.GetNationality(),
+		ORCID:       input.Orcid,
+		VIAF:        input.Viaf,
+		ISNI:        input.Isni,
+	}
+	if len(input.GetAliases()) > 0 || input.GetClearAliases() {
+		author.Aliases = []models.AuthorAlias{}
+	}
+	for _, alias := range input.GetAliases() {
+		author.Aliases = append(author.Aliases, models.AuthorAlias{Name: alias.Name, Kind: alias.Kind})
+	}
+	return author
+}
@@ -0,0 +1,116 @@
+package rpc
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"library-api/internal/logging"
+	"library-api/internal/rpc/libraryv1"
+	"net"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/grpc-ecosystem/grpc-gateway/v2/runtime"
+	"go.opentelemetry.io/contrib/instrumentation/google.golang.org/grpc/otelgrpc"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/encoding/protojson"
+	"google.golang.org/protobuf/proto"
+)
+
+// Gateway traduz as rotas HTTP de proto/library/v1/library.yaml (/v1/books,
+// /v1/authors, /v1/loans) em chamadas ao servidor gRPC em addr. O JSON usa
+// os nomes da API REST, e os erros, o mesmo formato de errorJSON; assim a
+// mesma requisição em /books e em /v1/books deve ter a mesma resposta.
+// A conexão é fechada quando ctx termina.
+func Gateway(ctx context.Context, addr string) (http.Handler, error) {
+	mux := runtime.NewServeMux(
+		runtime.WithMarshalerOption(runtime.MIMEWildcard, &runtime.JSONPb{
+			MarshalOptions:   protojson.MarshalOptions{UseProtoNames: true, EmitUnpopulated: true},
+			UnmarshalOptions: protojson.UnmarshalOptions{DiscardUnknown: true},
+		}),
+		runtime.WithMetadata(func(ctx context.Context, r *http.Request) metadata.MD {
+			return metadata.Pairs(strings.ToLower(logging.RequestIDHeader), logging.RequestIDFrom(r.Context()))
+		}),
+		runtime.WithOutgoingHeaderMatcher(func(string) (string, bool) { return "", false }),
+		runtime.WithForwardResponseOption(forwardStatus),
+		runtime.WithErrorHandler(writeError),
+	)
+
+	conn, err := grpc.NewClient(dialAddr(addr),
+		grpc.WithTransportCredentials(insecure.NewCredentials()),
+		grpc.WithStatsHandler(otelgrpc.NewClientHandler()),
+	)
+	if err != nil {
+		return nil, err
+	}
+	go func() {
+		<-ctx.Done()
+		conn.Close()
+	}()
+
+	for _, register := range []func(context.Context, *runtime.ServeMux, *grpc.ClientConn) error{
+		libraryv1.RegisterBookServiceHandler,
+		libraryv1.RegisterAuthorServiceHandler,
+		libraryv1.RegisterLoanServiceHandler,
+	} {
+		if err := register(ctx, mux, conn); err != nil {
+			return nil, err
+		}
+	}
+	return mux, nil
+}
+
+// dialAddr completa um endereço de escuta como ":9090" com o host local
+func dialAddr(addr string) string {
+	host, port, err := net.SplitHostPort(addr)
+	if err != nil || host != "" {
+		return addr
+	}
+	return net.JoinHostPort("localhost", port)
+}
+
+// forwardStatus aplica o status definido pelo servidor em x-http-code,
+// como o 201 das criações
+func forwardStatus(ctx context.Context, w http.ResponseWriter, _ proto.Message) error {
+	md, ok := runtime.ServerMetadataFromContext(ctx)
+	if !ok {
+		return nil
+	}
+	if values := md.HeaderMD.Get(httpCodeHeader); len(values) > 0 {
+		if code, err := strconv.Atoi(values[0]); err == nil {
+			w.WriteHeader(code)
+		}
+	}
+	return nil
+}
+
+// writeError responde no formato de erro da API REST, com o status HTTP
+// equivalente ao código gRPC
+func writeError(_ context.Context, _ *runtime.ServeMux, _ runtime.Marshaler, w http.ResponseWriter, r *http.Request, err error) {
+	code := http.StatusInternalServerError
+	message := err.Error()
+
+	var httpErr *runtime.HTTPStatusError
+	if errors.As(err, &httpErr) {
+		// Rota ou método não encontrado no gateway
+		code = httpErr.HTTPStatus
+		err = httpErr.Err
+	}
+	if st, ok := status.FromError(err); ok {
+		if httpErr == nil {
+			code = runtime.HTTPStatusFromCode(st.Code())
+		}
+		message = st.Message()
+	}
+
+	w.Header().Set("Content-Type", "application/json; charset=utf-8")
+	w.WriteHeader(code)
+	json.NewEncoder(w).Encode(map[string]string{
+		"error":      message,
+		"request_id": logging.RequestIDFrom(r.Context()),
+	})
+}
@@ -0,0 +1,606 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.36.10
+// 	protoc        (unknown)
+// source: library/v1/authors.proto
+
+package libraryv1
+
+import (
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	reflect "reflect"
+	sync "sync"
+	unsafe "unsafe"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+// ListAuthorsRequest tem os filtros de GET /authors
+type ListAuthorsRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Q             string                 `protobuf:"bytes,1,opt,name=q,proto3" json:"q,omitempty"` // parte do nome, da forma de ordenação ou de um alias
+	Nationality   string                 `protobuf:"bytes,2,opt,name=nationality,proto3" json:"nationality,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListAuthorsRequest) Reset() {
+	*x = ListAuthorsRequest{}
+	mi := &file_library_v1_authors_proto_msgTypes[0]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListAuthorsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListAuthorsRequest) ProtoMessage() {}
+
+func (x *ListAuthorsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_library_v1_authors_proto_msgTypes[0]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListAuthorsRequest.ProtoReflect.Descriptor instead.
+func (*ListAuthorsRequest) Descriptor() ([]byte, []int) {
+	return file_library_v1_authors_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *ListAuthorsRequest) GetQ() string {
+	if x != nil {
+		return x.Q
+	}
+	return ""
+}
+
+func (x *ListAuthorsRequest) GetNationality() string {
+	if x != nil {
+		return x.Nationality
+	}
+	return ""
+}
+
+type ListAuthorsResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Authors       []*Author              `protobuf:"bytes,1,rep,name=authors,proto3" json:"authors,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListAuthorsResponse) Reset() {
+	*x = ListAuthorsResponse{}
+	mi := &file_library_v1_authors_proto_msgTypes[1]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListAuthorsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListAuthorsResponse) ProtoMessage() {}
+
+func (x *ListAuthorsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_library_v1_authors_proto_msgTypes[1]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListAuthorsResponse.ProtoReflect.Descriptor instead.
+func (*ListAuthorsResponse) Descriptor() ([]byte, []int) {
+	return file_library_v1_authors_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *ListAuthorsResponse) GetAuthors() []*Author {
+	if x != nil {
+		return x.Authors
+	}
+	return nil
+}
+
+type GetAuthorRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            uint32                 `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetAuthorRequest) Reset() {
+	*x = GetAuthorRequest{}
+	mi := &file_library_v1_authors_proto_msgTypes[2]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetAuthorRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetAuthorRequest) ProtoMessage() {}
+
+func (x *GetAuthorRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_library_v1_authors_proto_msgTypes[2]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetAuthorRequest.ProtoReflect.Descriptor instead.
+func (*GetAuthorRequest) Descriptor() ([]byte, []int) {
+	return file_library_v1_authors_proto_rawDescGZIP(), []int{2}
+}
+
+func (x *GetAuthorRequest) GetId() uint32 {
+	if x != nil {
+		return x.Id
+	}
+	return 0
+}
+
+// AuthorInput são os dados enviados em POST e PUT /authors. Na
+// atualização, só os campos preenchidos mudam; aliases, se enviado,
+// substitui todos os nomes alternativos, e clear_aliases os remove.
+type AuthorInput struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Name          string                 `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	SortName      string                 `protobuf:"bytes,2,opt,name=sort_name,json=sortName,proto3" json:"sort_name,omitempty"`
+	Bio           string                 `protobuf:"bytes,3,opt,name=bio,proto3" json:"bio,omitempty"`
+	BirthYear     *int32                 `protobuf:"varint,4,opt,name=birth_year,json=birthYear,proto3,oneof" json:"birth_year,omitempty"`
+	DeathYear     *int32                 `protobuf:"varint,5,opt,name=death_year,json=deathYear,proto3,oneof" json:"death_year,omitempty"`
+	Nationality   string                 `protobuf:"bytes,6,opt,name=nationality,proto3" json:"nationality,omitempty"`
+	Orcid         *string                `protobuf:"bytes,7,opt,name=orcid,proto3,oneof" json:"orcid,omitempty"`
+	Viaf          *string                `protobuf:"bytes,8,opt,name=viaf,proto3,oneof" json:"viaf,omitempty"`
+	Isni          *string                `protobuf:"bytes,9,opt,name=isni,proto3,oneof" json:"isni,omitempty"`
+	Aliases       []*AliasInput          `protobuf:"bytes,10,rep,name=aliases,proto3" json:"aliases,omitempty"`
+	ClearAliases  bool                   `protobuf:"varint,11,opt,name=clear_aliases,json=clearAliases,proto3" json:"clear_aliases,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *AuthorInput) Reset() {
+	*x = AuthorInput{}
+	mi := &file_library_v1_authors_proto_msgTypes[3]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *AuthorInput) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*AuthorInput) ProtoMessage() {}
+
+func (x *AuthorInput) ProtoReflect() protoreflect.Message {
+	mi := &file_library_v1_authors_proto_msgTypes[3]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use AuthorInput.ProtoReflect.Descriptor instead.
+func (*AuthorInput) Descriptor() ([]byte, []int) {
+	return file_library_v1_authors_proto_rawDescGZIP(), []int{3}
+}
+
+func (x *AuthorInput) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *AuthorInput) GetSortName() string {
+	if x != nil {
+		return x.SortName
+	}
+	return ""
+}
+
+func (x *AuthorInput) GetBio() string {
+	if x != nil {
+		return x.Bio
+	}
+	return ""
+}
+
+func (x *AuthorInput) GetBirthYear() int32 {
+	if x != nil && x.BirthYear != nil {
+		return *x.BirthYear
+	}
+	return 0
+}
+
+func (x *AuthorInput) GetDeathYear() int32 {
+	if x != nil && x.DeathYear != nil {
+		return *x.DeathYear
+	}
+	return 0
+}
+
+func (x *AuthorInput) GetNationality() string {
+	if x != nil {
+		return x.Nationality
+	}
+	return ""
+}
+
+func (x *AuthorInput) GetOrcid() string {
+	if x != nil && x.Orcid != nil {
+		return *x.Orcid
+	}
+	return ""
+}
+
+func (x *AuthorInput) GetViaf() string {
+	if x != nil && x.Viaf != nil {
+		return *x.Viaf
+	}
+	return ""
+}
+
+func (x *AuthorInput) GetIsni() string {
+	if x != nil && x.Isni != nil {
+		return *x.Isni
+	}
+	return ""
+}
+
+func (x *AuthorInput) GetAliases() []*AliasInput {
+	if x != nil {
+		return x.Aliases
+	}
+	return nil
+}
+
+func (x *AuthorInput) GetClearAliases() bool {
+	if x != nil {
+		return x.ClearAliases
+	}
+	return false
+}
+
+type AliasInput struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Name          string                 `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	Kind          string                 `protobuf:"bytes,2,opt,name=kind,proto3" json:"kind,omitempty"` // alias (padrão) ou pseudonym
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *AliasInput) Reset() {
+	*x = AliasInput{}
+	mi := &file_library_v1_authors_proto_msgTypes[4]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *AliasInput) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*AliasInput) ProtoMessage() {}
+
+func (x *AliasInput) ProtoReflect() protoreflect.Message {
+	mi := &file_library_v1_authors_proto_msgTypes[4]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use AliasInput.ProtoReflect.Descriptor instead.
+func (*AliasInput) Descriptor() ([]byte, []int) {
+	return file_library_v1_authors_proto_rawDescGZIP(), []int{4}
+}
+
+func (x *AliasInput) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *AliasInput) GetKind() string {
+	if x != nil {
+		return x.Kind
+	}
+	return ""
+}
+
+type CreateAuthorRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Author        *AuthorInput           `protobuf:"bytes,1,opt,name=author,proto3" json:"author,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CreateAuthorRequest) Reset() {
+	*x = CreateAuthorRequest{}
+	mi := &file_library_v1_authors_proto_msgTypes[5]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CreateAuthorRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CreateAuthorRequest) ProtoMessage() {}
+
+func (x *CreateAuthorRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_library_v1_authors_proto_msgTypes[5]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CreateAuthorRequest.ProtoReflect.Descriptor instead.
+func (*CreateAuthorRequest) Descriptor() ([]byte, []int) {
+	return file_library_v1_authors_proto_rawDescGZIP(), []int{5}
+}
+
+func (x *CreateAuthorRequest) GetAuthor() *AuthorInput {
+	if x != nil {
+		return x.Author
+	}
+	return nil
+}
+
+type UpdateAuthorRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            uint32                 `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+	Author        *AuthorInput           `protobuf:"bytes,2,opt,name=author,proto3" json:"author,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *UpdateAuthorRequest) Reset() {
+	*x = UpdateAuthorRequest{}
+	mi := &file_library_v1_authors_proto_msgTypes[6]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *UpdateAuthorRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UpdateAuthorRequest) ProtoMessage() {}
+
+func (x *UpdateAuthorRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_library_v1_authors_proto_msgTypes[6]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UpdateAuthorRequest.ProtoReflect.Descriptor instead.
+func (*UpdateAuthorRequest) Descriptor() ([]byte, []int) {
+	return file_library_v1_authors_proto_rawDescGZIP(), []int{6}
+}
+
+func (x *UpdateAuthorRequest) GetId() uint32 {
+	if x != nil {
+		return x.Id
+	}
+	return 0
+}
+
+func (x *UpdateAuthorRequest) GetAuthor() *AuthorInput {
+	if x != nil {
+		return x.Author
+	}
+	return nil
+}
+
+type DeleteAuthorRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            uint32                 `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DeleteAuthorRequest) Reset() {
+	*x = DeleteAuthorRequest{}
+	mi := &file_library_v1_authors_proto_msgTypes[7]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DeleteAuthorRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DeleteAuthorRequest) ProtoMessage() {}
+
+func (x *DeleteAuthorRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_library_v1_authors_proto_msgTypes[7]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DeleteAuthorRequest.ProtoReflect.Descriptor instead.
+func (*DeleteAuthorRequest) Descriptor() ([]byte, []int) {
+	return file_library_v1_authors_proto_rawDescGZIP(), []int{7}
+}
+
+func (x *DeleteAuthorRequest) GetId() uint32 {
+	if x != nil {
+		return x.Id
+	}
+	return 0
+}
+
+var File_library_v1_authors_proto protoreflect.FileDescriptor
+
+const file_library_v1_authors_proto_rawDesc = "" +
+	"\n" +
+	"\x18library/v1/authors.proto\x12\n" +
+	"library.v1\x1a\x17library/v1/models.proto\"D\n" +
+	"\x12ListAuthorsRequest\x12\f\n" +
+	"\x01q\x18\x01 \x01(\tR\x01q\x12 \n" +
+	"\vnationality\x18\x02 \x01(\tR\vnationality\"C\n" +
+	"\x13ListAuthorsResponse\x12,\n" +
+	"\aauthors\x18\x01 \x03(\v2\x12.library.v1.AuthorR\aauthors\"\"\n" +
+	"\x10GetAuthorRequest\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\rR\x02id\"\x98\x03\n" +
+	"\vAuthorInput\x12\x12\n" +
+	"\x04name\x18\x01 \x01(\tR\x04name\x12\x1b\n" +
+	"\tsort_name\x18\x02 \x01(\tR\bsortName\x12\x10\n" +
+	"\x03bio\x18\x03 \x01(\tR\x03bio\x12\"\n" +
+	"\n" +
+	"birth_year\x18\x04 \x01(\x05H\x00R\tbirthYear\x88\x01\x01\x12\"\n" +
+	"\n" +
+	"death_year\x18\x05 \x01(\x05H\x01R\tdeathYear\x88\x01\x01\x12 \n" +
+	"\vnationality\x18\x06 \x01(\tR\vnationality\x12\x19\n" +
+	"\x05orcid\x18\a \x01(\tH\x02R\x05orcid\x88\x01\x01\x12\x17\n" +
+	"\x04viaf\x18\b \x01(\tH\x03R\x04viaf\x88\x01\x01\x12\x17\n" +
+	"\x04isni\x18\t \x01(\tH\x04R\x04isni\x88\x01\x01\x120\n" +
+	"\aaliases\x18\n" +
+	" \x03(\v2\x16.library.v1.AliasInputR\aaliases\x12#\n" +
+	"\rclear_aliases\x18\v \x01(\bR\fclearAliasesB\r\n" +
+	"\v_birth_yearB\r\n" +
+	"\v_death_yearB\b\n" +
+	"\x06_orcidB\a\n" +
+	"\x05_viafB\a\n" +
+	"\x05_isni\"4\n" +
+	"\n" +
+	"AliasInput\x12\x12\n" +
+	"\x04name\x18\x01 \x01(\tR\x04name\x12\x12\n" +
+	"\x04kind\x18\x02 \x01(\tR\x04kind\"F\n" +
+	"\x13CreateAuthorRequest\x12/\n" +
+	"\x06author\x18\x01 \x01(\v2\x17.library.v1.AuthorInputR\x06author\"V\n" +
+	"\x13UpdateAuthorRequest\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\rR\x02id\x12/\n" +
+	"\x06author\x18\x02 \x01(\v2\x17.library.v1.AuthorInputR\x06author\"%\n" +
+	"\x13DeleteAuthorRequest\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\rR\x02id2\xf5\x02\n" +
+	"\rAuthorService\x12N\n" +
+	"\vListAuthors\x12\x1e.library.v1.ListAuthorsRequest\x1a\x1f.library.v1.ListAuthorsResponse\x12=\n" +
+	"\tGetAuthor\x12\x1c.library.v1.GetAuthorRequest\x1a\x12.library.v1.Author\x12C\n" +
+	"\fCreateAuthor\x12\x1f.library.v1.CreateAuthorRequest\x1a\x12.library.v1.Author\x12C\n" +
+	"\fUpdateAuthor\x12\x1f.library.v1.UpdateAuthorRequest\x1a\x12.library.v1.Author\x12K\n" +
+	"\fDeleteAuthor\x12\x1f.library.v1.DeleteAuthorRequest\x1a\x1a.library.v1.DeleteResponseB.Z,library-api/internal/rpc/libraryv1;libraryv1b\x06proto3"
+
+var (
+	file_library_v1_authors_proto_rawDescOnce sync.Once
+	file_library_v1_authors_proto_rawDescData []byte
+)
+
+func file_library_v1_authors_proto_rawDescGZIP() []byte {
+	file_library_v1_authors_proto_rawDescOnce.Do(func() {
+		file_library_v1_authors_proto_rawDescData = protoimpl.X.CompressGZIP(unsafe.Slice(unsafe.StringData(file_library_v1_authors_proto_rawDesc), len(file_library_v1_authors_proto_rawDesc)))
+	})
+	return file_library_v1_authors_proto_rawDescData
+}
+
+var file_library_v1_authors_proto_msgTypes = make([]protoimpl.MessageInfo, 8)
+var file_library_v1_authors_proto_goTypes = []any{
+	(*ListAuthorsRequest)(nil),  // 0: library.v1.ListAuthorsRequest
+	(*ListAuthorsResponse)(nil), // 1: library.v1.ListAuthorsResponse
+	(*GetAuthorRequest)(nil),    // 2: library.v1.GetAuthorRequest
+	(*AuthorInput)(nil),         // 3: library.v1.AuthorInput
+	(*AliasInput)(nil),          // 4: library.v1.AliasInput
+	(*CreateAuthorRequest)(nil), // 5: library.v1.CreateAuthorRequest
+	(*UpdateAuthorRequest)(nil), // 6: library.v1.UpdateAuthorRequest
+	(*DeleteAuthorRequest)(nil), // 7: library.v1.DeleteAuthorRequest
+	(*Author)(nil),              // 8: library.v1.Author
+	(*DeleteResponse)(nil),      // 9: library.v1.DeleteResponse
+}
+var file_library_v1_authors_proto_depIdxs = []int32{
+	8, // 0: library.v1.ListAuthorsResponse.authors:type_name -> library.v1.Author
+	4, // 1: library.v1.AuthorInput.aliases:type_name -> library.v1.AliasInput
+	3, // 2: library.v1.CreateAuthorRequest.author:type_name -> library.v1.AuthorInput
+	3, // 3: library.v1.UpdateAuthorRequest.author:type_name -> library.v1.AuthorInput
+	0, // 4: library.v1.AuthorService.ListAuthors:input_type -> library.v1.ListAuthorsRequest
+	2, // 5: library.v1.AuthorService.GetAuthor:input_type -> library.v1.GetAuthorRequest
+	5, // 6: library.v1.AuthorService.CreateAuthor:input_type -> library.v1.CreateAuthorRequest
+	6, // 7: library.v1.AuthorService.UpdateAuthor:input_type -> library.v1.UpdateAuthorRequest
+	7, // 8: library.v1.AuthorService.DeleteAuthor:input_type -> library.v1.DeleteAuthorRequest
+	1, // 9: library.v1.AuthorService.ListAuthors:output_type -> library.v1.ListAuthorsResponse
+	8, // 10: library.v1.AuthorService.GetAuthor:output_type -> library.v1.Author
+	8, // 11: library.v1.AuthorService.CreateAuthor:output_type -> library.v1.Author
+	8, // 12: library.v1.AuthorService.UpdateAuthor:output_type -> library.v1.Author
+	9, // 13: library.v1.AuthorService.DeleteAuthor:output_type -> library.v1.DeleteResponse
+	9, // [9:14] is the sub-list for method output_type
+	4, // [4:9] is the sub-list for method input_type
+	4, // [4:4] is the sub-list for extension type_name
+	4, // [4:4] is the sub-list for extension extendee
+	0, // [0:4] is the sub-list for field type_name
+}
+
+func init() { file_library_v1_authors_proto_init() }
+func file_library_v1_authors_proto_init() {
+	if File_library_v1_authors_proto != nil {
+		return
+	}
+	file_library_v1_models_proto_init()
+	file_library_v1_authors_proto_msgTypes[3].OneofWrappers = []any{}
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: unsafe.Slice(unsafe.StringData(file_library_v1_authors_proto_rawDesc), len(file_library_v1_authors_proto_rawDesc)),
+			NumEnums:      0,
+			NumMessages:   8,
+			NumExtensions: 0,
+			NumServices:   1,
+		},
+		GoTypes:           file_library_v1_authors_proto_goTypes,
+		DependencyIndexes: file_library_v1_authors_proto_depIdxs,
+		MessageInfos:      file_library_v1_authors_proto_msgTypes,
+	}.Build()
+	File_library_v1_authors_proto = out.File
+	file_library_v1_authors_proto_goTypes = nil
+	file_library_v1_authors_proto_depIdxs = nil
+}
@@ -0,0 +1,753 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.36.10
+// 	protoc        (unknown)
+// source: library/v1/books.proto
+
+package libraryv1
+
+import (
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	reflect "reflect"
+	sync "sync"
+	unsafe "unsafe"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+// ListBooksRequest tem os filtros de GET /books; campos vazios não filtram
+type ListBooksRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Title         string                 `protobuf:"bytes,1,opt,name=title,proto3" json:"title,omitempty"`
+	Isbn          string                 `protobuf:"bytes,2,opt,name=isbn,proto3" json:"isbn,omitempty"`
+	Available     *bool                  `protobuf:"varint,3,opt,name=available,proto3,oneof" json:"available,omitempty"`
+	AuthorId      uint32                 `protobuf:"varint,4,opt,name=author_id,json=authorId,proto3" json:"author_id,omitempty"`
+	Author        string                 `protobuf:"bytes,5,opt,name=author,proto3" json:"author,omitempty"`
+	Publisher     string                 `protobuf:"bytes,6,opt,name=publisher,proto3" json:"publisher,omitempty"`
+	Language      string                 `protobuf:"bytes,7,opt,name=language,proto3" json:"language,omitempty"`
+	Year          *int32                 `protobuf:"varint,8,opt,name=year,proto3,oneof" json:"year,omitempty"`
+	YearFrom      *int32                 `protobuf:"varint,9,opt,name=year_from,json=yearFrom,proto3,oneof" json:"year_from,omitempty"`
+	YearTo        *int32                 `protobuf:"varint,10,opt,name=year_to,json=yearTo,proto3,oneof" json:"year_to,omitempty"`
+	SubjectId     uint32                 `protobuf:"varint,11,opt,name=subject_id,json=subjectId,proto3" json:"subject_id,omitempty"`
+	Subject       string                 `protobuf:"bytes,12,opt,name=subject,proto3" json:"subject,omitempty"`
+	SeriesId      uint32                 `protobuf:"varint,13,opt,name=series_id,json=seriesId,proto3" json:"series_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListBooksRequest) Reset() {
+	*x = ListBooksRequest{}
+	mi := &file_library_v1_books_proto_msgTypes[0]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListBooksRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListBooksRequest) ProtoMessage() {}
+
+func (x *ListBooksRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_library_v1_books_proto_msgTypes[0]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListBooksRequest.ProtoReflect.Descriptor instead.
+func (*ListBooksRequest) Descriptor() ([]byte, []int) {
+	return file_library_v1_books_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *ListBooksRequest) GetTitle() string {
+	if x != nil {
+		return x.Title
+	}
+	return ""
+}
+
+func (x *ListBooksRequest) GetIsbn() string {
+	if x != nil {
+		return x.Isbn
+	}
+	return ""
+}
+
+func (x *ListBooksRequest) GetAvailable() bool {
+	if x != nil && x.Available != nil {
+		return *x.Available
+	}
+	return false
+}
+
+func (x *ListBooksRequest) GetAuthorId() uint32 {
+	if x != nil {
+		return x.AuthorId
+	}
+	return 0
+}
+
+func (x *ListBooksRequest) GetAuthor() string {
+	if x != nil {
+		return x.Author
+	}
+	return ""
+}
+
+func (x *ListBooksRequest) GetPublisher() string {
+	if x != nil {
+		return x.Publisher
+	}
+	return ""
+}
+
+func (x *ListBooksRequest) GetLanguage() string {
+	if x != nil {
+		return x.Language
+	}
+	return ""
+}
+
+func (x *ListBooksRequest) GetYear() int32 {
+	if x != nil && x.Year != nil {
+		return *x.Year
+	}
+	return 0
+}
+
+func (x *ListBooksRequest) GetYearFrom() int32 {
+	if x != nil && x.YearFrom != nil {
+		return *x.YearFrom
+	}
+	return 0
+}
+
+func (x *ListBooksRequest) GetYearTo() int32 {
+	if x != nil && x.YearTo != nil {
+		return *x.YearTo
+	}
+	return 0
+}
+
+func (x *ListBooksRequest) GetSubjectId() uint32 {
+	if x != nil {
+		return x.SubjectId
+	}
+	return 0
+}
+
+func (x *ListBooksRequest) GetSubject() string {
+	if x != nil {
+		return x.Subject
+	}
+	return ""
+}
+
+func (x *ListBooksRequest) GetSeriesId() uint32 {
+	if x != nil {
+		return x.SeriesId
+	}
+	return 0
+}
+
+type ListBooksResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Books         []*Book                `protobuf:"bytes,1,rep,name=books,proto3" json:"books,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListBooksResponse) Reset() {
+	*x = ListBooksResponse{}
+	mi := &file_library_v1_books_proto_msgTypes[1]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListBooksResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListBooksResponse) ProtoMessage() {}
+
+func (x *ListBooksResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_library_v1_books_proto_msgTypes[1]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListBooksResponse.ProtoReflect.Descriptor instead.
+func (*ListBooksResponse) Descriptor() ([]byte, []int) {
+	return file_library_v1_books_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *ListBooksResponse) GetBooks() []*Book {
+	if x != nil {
+		return x.Books
+	}
+	return nil
+}
+
+type GetBookRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            uint32                 `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetBookRequest) Reset() {
+	*x = GetBookRequest{}
+	mi := &file_library_v1_books_proto_msgTypes[2]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetBookRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetBookRequest) ProtoMessage() {}
+
+func (x *GetBookRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_library_v1_books_proto_msgTypes[2]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetBookRequest.ProtoReflect.Descriptor instead.
+func (*GetBookRequest) Descriptor() ([]byte, []int) {
+	return file_library_v1_books_proto_rawDescGZIP(), []int{2}
+}
+
+func (x *GetBookRequest) GetId() uint32 {
+	if x != nil {
+		return x.Id
+	}
+	return 0
+}
+
+// BookInput são os dados enviados em POST e PUT /books. Na atualização, só
+// os campos preenchidos mudam; credits (ou author_ids) e subject_ids, se
+// enviados, substituem os atuais.
+type BookInput struct {
+	state           protoimpl.MessageState `protogen:"open.v1"`
+	Title           string                 `protobuf:"bytes,1,opt,name=title,proto3" json:"title,omitempty"`
+	Isbn            string                 `protobuf:"bytes,2,opt,name=isbn,proto3" json:"isbn,omitempty"`
+	Available       *bool                  `protobuf:"varint,3,opt,name=available,proto3,oneof" json:"available,omitempty"`
+	Publisher       string                 `protobuf:"bytes,4,opt,name=publisher,proto3" json:"publisher,omitempty"`
+	PublicationYear int32                  `protobuf:"varint,5,opt,name=publication_year,json=publicationYear,proto3" json:"publication_year,omitempty"`
+	Edition         string                 `protobuf:"bytes,6,opt,name=edition,proto3" json:"edition,omitempty"`
+	Language        string                 `protobuf:"bytes,7,opt,name=language,proto3" json:"language,omitempty"`
+	Pages           int32                  `protobuf:"varint,8,opt,name=pages,proto3" json:"pages,omitempty"`
+	Description     string                 `protobuf:"bytes,9,opt,name=description,proto3" json:"description,omitempty"`
+	CoverUrl        string                 `protobuf:"bytes,10,opt,name=cover_url,json=coverUrl,proto3" json:"cover_url,omitempty"`
+	SeriesId        *uint32                `protobuf:"varint,11,opt,name=series_id,json=seriesId,proto3,oneof" json:"series_id,omitempty"`
+	SeriesVolume    int32                  `protobuf:"varint,12,opt,name=series_volume,json=seriesVolume,proto3" json:"series_volume,omitempty"`
+	Credits         []*AuthorCredit        `protobuf:"bytes,13,rep,name=credits,proto3" json:"credits,omitempty"`
+	AuthorIds       []uint32               `protobuf:"varint,14,rep,packed,name=author_ids,json=authorIds,proto3" json:"author_ids,omitempty"`
+	SubjectIds      []uint32               `protobuf:"varint,15,rep,packed,name=subject_ids,json=subjectIds,proto3" json:"subject_ids,omitempty"`
+	unknownFields   protoimpl.UnknownFields
+	sizeCache       protoimpl.SizeCache
+}
+
+func (x *BookInput) Reset() {
+	*x = BookInput{}
+	mi := &file_library_v1_books_proto_msgTypes[3]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *BookInput) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*BookInput) ProtoMessage() {}
+
+func (x *BookInput) ProtoReflect() protoreflect.Message {
+	mi := &file_library_v1_books_proto_msgTypes[3]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use BookInput.ProtoReflect.Descriptor instead.
+func (*BookInput) Descriptor() ([]byte, []int) {
+	return file_library_v1_books_proto_rawDescGZIP(), []int{3}
+}
+
+func (x *BookInput) GetTitle() string {
+	if x != nil {
+		return x.Title
+	}
+	return ""
+}
+
+func (x *BookInput) GetIsbn() string {
+	if x != nil {
+		return x.Isbn
+	}
+	return ""
+}
+
+func (x *BookInput) GetAvailable() bool {
+	if x != nil && x.Available != nil {
+		return *x.Available
+	}
+	return false
+}
+
+func (x *BookInput) GetPublisher() string {
+	if x != nil {
+		return x.Publisher
+	}
+	return ""
+}
+
+func (x *BookInput) GetPublicationYear() int32 {
+	if x != nil {
+		return x.PublicationYear
+	}
+	return 0
+}
+
+func (x *BookInput) GetEdition() string {
+	if x != nil {
+		return x.Edition
+	}
+	return ""
+}
+
+func (x *BookInput) GetLanguage() string {
+	if x != nil {
+		return x.Language
+	}
+	return ""
+}
+
+func (x *BookInput) GetPages() int32 {
+	if x != nil {
+		return x.Pages
+	}
+	return 0
+}
+
+func (x *BookInput) GetDescription() string {
+	if x != nil {
+		return x.Description
+	}
+	return ""
+}
+
+func (x *BookInput) GetCoverUrl() string {
+	if x != nil {
+		return x.CoverUrl
+	}
+	return ""
+}
+
+func (x *BookInput) GetSeriesId() uint32 {
+	if x != nil && x.SeriesId != nil {
+		return *x.SeriesId
+	}
+	return 0
+}
+
+func (x *BookInput) GetSeriesVolume() int32 {
+	if x != nil {
+		return x.SeriesVolume
+	}
+	return 0
+}
+
+func (x *BookInput) GetCredits() []*AuthorCredit {
+	if x != nil {
+		return x.Credits
+	}
+	return nil
+}
+
+func (x *BookInput) GetAuthorIds() []uint32 {
+	if x != nil {
+		return x.AuthorIds
+	}
+	return nil
+}
+
+func (x *BookInput) GetSubjectIds() []uint32 {
+	if x != nil {
+		return x.SubjectIds
+	}
+	return nil
+}
+
+// AuthorCredit é um autor com seu papel no livro
+type AuthorCredit struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	AuthorId      uint32                 `protobuf:"varint,1,opt,name=author_id,json=authorId,proto3" json:"author_id,omitempty"`
+	Role          string                 `protobuf:"bytes,2,opt,name=role,proto3" json:"role,omitempty"` // author (padrão), editor, translator ou illustrator
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *AuthorCredit) Reset() {
+	*x = AuthorCredit{}
+	mi := &file_library_v1_books_proto_msgTypes[4]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *AuthorCredit) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*AuthorCredit) ProtoMessage() {}
+
+func (x *AuthorCredit) ProtoReflect() protoreflect.Message {
+	mi := &file_library_v1_books_proto_msgTypes[4]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use AuthorCredit.ProtoReflect.Descriptor instead.
+func (*AuthorCredit) Descriptor() ([]byte, []int) {
+	return file_library_v1_books_proto_rawDescGZIP(), []int{4}
+}
+
+func (x *AuthorCredit) GetAuthorId() uint32 {
+	if x != nil {
+		return x.AuthorId
+	}
+	return 0
+}
+
+func (x *AuthorCredit) GetRole() string {
+	if x != nil {
+		return x.Role
+	}
+	return ""
+}
+
+type CreateBookRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Book          *BookInput             `protobuf:"bytes,1,opt,name=book,proto3" json:"book,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CreateBookRequest) Reset() {
+	*x = CreateBookRequest{}
+	mi := &file_library_v1_books_proto_msgTypes[5]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CreateBookRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CreateBookRequest) ProtoMessage() {}
+
+func (x *CreateBookRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_library_v1_books_proto_msgTypes[5]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CreateBookRequest.ProtoReflect.Descriptor instead.
+func (*CreateBookRequest) Descriptor() ([]byte, []int) {
+	return file_library_v1_books_proto_rawDescGZIP(), []int{5}
+}
+
+func (x *CreateBookRequest) GetBook() *BookInput {
+	if x != nil {
+		return x.Book
+	}
+	return nil
+}
+
+type UpdateBookRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            uint32                 `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+	Book          *BookInput             `protobuf:"bytes,2,opt,name=book,proto3" json:"book,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *UpdateBookRequest) Reset() {
+	*x = UpdateBookRequest{}
+	mi := &file_library_v1_books_proto_msgTypes[6]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *UpdateBookRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UpdateBookRequest) ProtoMessage() {}
+
+func (x *UpdateBookRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_library_v1_books_proto_msgTypes[6]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UpdateBookRequest.ProtoReflect.Descriptor instead.
+func (*UpdateBookRequest) Descriptor() ([]byte, []int) {
+	return file_library_v1_books_proto_rawDescGZIP(), []int{6}
+}
+
+func (x *UpdateBookRequest) GetId() uint32 {
+	if x != nil {
+		return x.Id
+	}
+	return 0
+}
+
+func (x *UpdateBookRequest) GetBook() *BookInput {
+	if x != nil {
+		return x.Book
+	}
+	return nil
+}
+
+type DeleteBookRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            uint32                 `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DeleteBookRequest) Reset() {
+	*x = DeleteBookRequest{}
+	mi := &file_library_v1_books_proto_msgTypes[7]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DeleteBookRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DeleteBookRequest) ProtoMessage() {}
+
+func (x *DeleteBookRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_library_v1_books_proto_msgTypes[7]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DeleteBookRequest.ProtoReflect.Descriptor instead.
+func (*DeleteBookRequest) Descriptor() ([]byte, []int) {
+	return file_library_v1_books_proto_rawDescGZIP(), []int{7}
+}
+
+func (x *DeleteBookRequest) GetId() uint32 {
+	if x != nil {
+		return x.Id
+	}
+	return 0
+}
+
+var File_library_v1_books_proto protoreflect.FileDescriptor
+
+const file_library_v1_books_proto_rawDesc = "" +
+	"\n" +
+	"\x16library/v1/books.proto\x12\n" +
+	"library.v1\x1a\x17library/v1/models.proto\"\xae\x03\n" +
+	"\x10ListBooksRequest\x12\x14\n" +
+	"\x05title\x18\x01 \x01(\tR\x05title\x12\x12\n" +
+	"\x04isbn\x18\x02 \x01(\tR\x04isbn\x12!\n" +
+	"\tavailable\x18\x03 \x01(\bH\x00R\tavailable\x88\x01\x01\x12\x1b\n" +
+	"\tauthor_id\x18\x04 \x01(\rR\bauthorId\x12\x16\n" +
+	"\x06author\x18\x05 \x01(\tR\x06author\x12\x1c\n" +
+	"\tpublisher\x18\x06 \x01(\tR\tpublisher\x12\x1a\n" +
+	"\blanguage\x18\a \x01(\tR\blanguage\x12\x17\n" +
+	"\x04year\x18\b \x01(\x05H\x01R\x04year\x88\x01\x01\x12 \n" +
+	"\tyear_from\x18\t \x01(\x05H\x02R\byearFrom\x88\x01\x01\x12\x1c\n" +
+	"\ayear_to\x18\n" +
+	" \x01(\x05H\x03R\x06yearTo\x88\x01\x01\x12\x1d\n" +
+	"\n" +
+	"subject_id\x18\v \x01(\rR\tsubjectId\x12\x18\n" +
+	"\asubject\x18\f \x01(\tR\asubject\x12\x1b\n" +
+	"\tseries_id\x18\r \x01(\rR\bseriesIdB\f\n" +
+	"\n" +
+	"_availableB\a\n" +
+	"\x05_yearB\f\n" +
+	"\n" +
+	"_year_fromB\n" +
+	"\n" +
+	"\b_year_to\";\n" +
+	"\x11ListBooksResponse\x12&\n" +
+	"\x05books\x18\x01 \x03(\v2\x10.library.v1.BookR\x05books\" \n" +
+	"\x0eGetBookRequest\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\rR\x02id\"\x83\x04\n" +
+	"\tBookInput\x12\x14\n" +
+	"\x05title\x18\x01 \x01(\tR\x05title\x12\x12\n" +
+	"\x04isbn\x18\x02 \x01(\tR\x04isbn\x12!\n" +
+	"\tavailable\x18\x03 \x01(\bH\x00R\tavailable\x88\x01\x01\x12\x1c\n" +
+	"\tpublisher\x18\x04 \x01(\tR\tpublisher\x12)\n" +
+	"\x10publication_year\x18\x05 \x01(\x05R\x0fpublicationYear\x12\x18\n" +
+	"\aedition\x18\x06 \x01(\tR\aedition\x12\x1a\n" +
+	"\blanguage\x18\a \x01(\tR\blanguage\x12\x14\n" +
+	"\x05pages\x18\b \x01(\x05R\x05pages\x12 \n" +
+	"\vdescription\x18\t \x01(\tR\vdescription\x12\x1b\n" +
+	"\tcover_url\x18\n" +
+	" \x01(\tR\bcoverUrl\x12 \n" +
+	"\tseries_id\x18\v \x01(\rH\x01R\bseriesId\x88\x01\x01\x12#\n" +
+	"\rseries_volume\x18\f \x01(\x05R\fseriesVolume\x122\n" +
+	"\acredits\x18\r \x03(\v2\x18.library.v1.AuthorCreditR\acredits\x12\x1d\n" +
+	"\n" +
+	"author_ids\x18\x0e \x03(\rR\tauthorIds\x12\x1f\n" +
+	"\vsubject_ids\x18\x0f \x03(\rR\n" +
+	"subjectIdsB\f\n" +
+	"\n" +
+	"_availableB\f\n" +
+	"\n" +
+	"_series_id\"?\n" +
+	"\fAuthorCredit\x12\x1b\n" +
+	"\tauthor_id\x18\x01 \x01(\rR\bauthorId\x12\x12\n" +
+	"\x04role\x18\x02 \x01(\tR\x04role\">\n" +
+	"\x11CreateBookRequest\x12)\n" +
+	"\x04book\x18\x01 \x01(\v2\x15.library.v1.BookInputR\x04book\"N\n" +
+	"\x11UpdateBookRequest\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\rR\x02id\x12)\n" +
+	"\x04book\x18\x02 \x01(\v2\x15.library.v1.BookInputR\x04book\"#\n" +
+	"\x11DeleteBookRequest\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\rR\x02id2\xd7\x02\n" +
+	"\vBookService\x12H\n" +
+	"\tListBooks\x12\x1c.library.v1.ListBooksRequest\x1a\x1d.library.v1.ListBooksResponse\x127\n" +
+	"\aGetBook\x12\x1a.library.v1.GetBookRequest\x1a\x10.library.v1.Book\x12=\n" +
+	"\n" +
+	"CreateBook\x12\x1d.library.v1.CreateBookRequest\x1a\x10.library.v1.Book\x12=\n" +
+	"\n" +
+	"UpdateBook\x12\x1d.library.v1.UpdateBookRequest\x1a\x10.library.v1.Book\x12G\n" +
+	"\n" +
+	"DeleteBook\x12\x1d.library.v1.DeleteBookRequest\x1a\x1a.library.v1.DeleteResponseB.Z,library-api/internal/rpc/libraryv1;libraryv1b\x06proto3"
+
+var (
+	file_library_v1_books_proto_rawDescOnce sync.Once
+	file_library_v1_books_proto_rawDescData []byte
+)
+
+func file_library_v1_books_proto_rawDescGZIP() []byte {
+	file_library_v1_books_proto_rawDescOnce.Do(func() {
+		file_library_v1_books_proto_rawDescData = protoimpl.X.CompressGZIP(unsafe.Slice(unsafe.StringData(file_library_v1_books_proto_rawDesc), len(file_library_v1_books_proto_rawDesc)))
+	})
+	return file_library_v1_books_proto_rawDescData
+}
+
+var file_library_v1_books_proto_msgTypes = make([]protoimpl.MessageInfo, 8)
+var file_library_v1_books_proto_goTypes = []any{
+	(*ListBooksRequest)(nil),  // 0: library.v1.ListBooksRequest
+	(*ListBooksResponse)(nil), // 1: library.v1.ListBooksResponse
+	(*GetBookRequest)(nil),    // 2: library.v1.GetBookRequest
+	(*BookInput)(nil),         // 3: library.v1.BookInput
+	(*AuthorCredit)(nil),      // 4: library.v1.AuthorCredit
+	(*CreateBookRequest)(nil), // 5: library.v1.CreateBookRequest
+	(*UpdateBookRequest)(nil), // 6: library.v1.UpdateBookRequest
+	(*DeleteBookRequest)(nil), // 7: library.v1.DeleteBookRequest
+	(*Book)(nil),              // 8: library.v1.Book
+	(*DeleteResponse)(nil),    // 9: library.v1.DeleteResponse
+}
+var file_library_v1_books_proto_depIdxs = []int32{
+	8, // 0: library.v1.ListBooksResponse.books:type_name -> library.v1.Book
+	4, // 1: library.v1.BookInput.credits:type_name -> library.v1.AuthorCredit
+	3, // 2: library.v1.CreateBookRequest.book:type_name -> library.v1.BookInput
+	3, // 3: library.v1.UpdateBookRequest.book:type_name -> library.v1.BookInput
+	0, // 4: library.v1.BookService.ListBooks:input_type -> library.v1.ListBooksRequest
+	2, // 5: library.v1.BookService.GetBook:input_type -> library.v1.GetBookRequest
+	5, // 6: library.v1.BookService.CreateBook:input_type -> library.v1.CreateBookRequest
+	6, // 7: library.v1.BookService.UpdateBook:input_type -> library.v1.UpdateBookRequest
+	7, // 8: library.v1.BookService.DeleteBook:input_type -> library.v1.DeleteBookRequest
+	1, // 9: library.v1.BookService.ListBooks:output_type -> library.v1.ListBooksResponse
+	8, // 10: library.v1.BookService.GetBook:output_type -> library.v1.Book
+	8, // 11: library.v1.BookService.CreateBook:output_type -> library.v1.Book
+	8, // 12: library.v1.BookService.UpdateBook:output_type -> library.v1.Book
+	9, // 13: library.v1.BookService.DeleteBook:output_type -> library.v1.DeleteResponse
+	9, // [9:14] is the sub-list for method output_type
+	4, // [4:9] is the sub-list for method input_type
+	4, // [4:4] is the sub-list for extension type_name
+	4, // [4:4] is the sub-list for extension extendee
+	0, // [0:4] is the sub-list for field type_name
+}
+
+func init() { file_library_v1_books_proto_init() }
+func file_library_v1_books_proto_init() {
+	if File_library_v1_books_proto != nil {
+		return
+	}
+	file_library_v1_models_proto_init()
+	file_library_v1_books_proto_msgTypes[0].OneofWrappers = []any{}
+	file_library_v1_books_proto_msgTypes[3].OneofWrappers = []any{}
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: unsafe.Slice(unsafe.StringData(file_library_v1_books_proto_rawDesc), len(file_library_v1_books_proto_rawDesc)),
+			NumEnums:      0,
+			NumMessages:   8,
+			NumExtensions: 0,
+			NumServices:   1,
+		},
+		GoTypes:           file_library_v1_books_proto_goTypes,
+		DependencyIndexes: file_library_v1_books_proto_depIdxs,
+		MessageInfos:      file_library_v1_books_proto_msgTypes,
+	}.Build()
+	File_library_v1_books_proto = out.File
+	file_library_v1_books_proto_goTypes = nil
+	file_library_v1_books_proto_depIdxs = nil
+}
@@ -0,0 +1,469 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.36.10
+// 	protoc        (unknown)
+// source: library/v1/loans.proto
+
+package libraryv1
+
+import (
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	timestamppb "google.golang.org/protobuf/types/known/timestamppb"
+	reflect "reflect"
+	sync "sync"
+	unsafe "unsafe"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+// ListLoansRequest tem os filtros de GET /loans
+type ListLoansRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	UserName      string                 `protobuf:"bytes,1,opt,name=user_name,json=userName,proto3" json:"user_name,omitempty"` // sem diferenciar maiúsculas
+	Status        string                 `protobuf:"bytes,2,opt,name=status,proto3" json:"status,omitempty"`                     // active, returned ou overdue
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListLoansRequest) Reset() {
+	*x = ListLoansRequest{}
+	mi := &file_library_v1_loans_proto_msgTypes[0]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListLoansRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListLoansRequest) ProtoMessage() {}
+
+func (x *ListLoansRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_library_v1_loans_proto_msgTypes[0]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListLoansRequest.ProtoReflect.Descriptor instead.
+func (*ListLoansRequest) Descriptor() ([]byte, []int) {
+	return file_library_v1_loans_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *ListLoansRequest) GetUserName() string {
+	if x != nil {
+		return x.UserName
+	}
+	return ""
+}
+
+func (x *ListLoansRequest) GetStatus() string {
+	if x != nil {
+		return x.Status
+	}
+	return ""
+}
+
+type ListLoansResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Loans         []*Loan                `protobuf:"bytes,1,rep,name=loans,proto3" json:"loans,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListLoansResponse) Reset() {
+	*x = ListLoansResponse{}
+	mi := &file_library_v1_loans_proto_msgTypes[1]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListLoansResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListLoansResponse) ProtoMessage() {}
+
+func (x *ListLoansResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_library_v1_loans_proto_msgTypes[1]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListLoansResponse.ProtoReflect.Descriptor instead.
+func (*ListLoansResponse) Descriptor() ([]byte, []int) {
+	return file_library_v1_loans_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *ListLoansResponse) GetLoans() []*Loan {
+	if x != nil {
+		return x.Loans
+	}
+	return nil
+}
+
+type GetLoanRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            uint32                 `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetLoanRequest) Reset() {
+	*x = GetLoanRequest{}
+	mi := &file_library_v1_loans_proto_msgTypes[2]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetLoanRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetLoanRequest) ProtoMessage() {}
+
+func (x *GetLoanRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_library_v1_loans_proto_msgTypes[2]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetLoanRequest.ProtoReflect.Descriptor instead.
+func (*GetLoanRequest) Descriptor() ([]byte, []int) {
+	return file_library_v1_loans_proto_rawDescGZIP(), []int{2}
+}
+
+func (x *GetLoanRequest) GetId() uint32 {
+	if x != nil {
+		return x.Id
+	}
+	return 0
+}
+
+type CreateLoanRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	BookId        uint32                 `protobuf:"varint,1,opt,name=book_id,json=bookId,proto3" json:"book_id,omitempty"`
+	UserName      string                 `protobuf:"bytes,2,opt,name=user_name,json=userName,proto3" json:"user_name,omitempty"`
+	DueDate       *timestamppb.Timestamp `protobuf:"bytes,3,opt,name=due_date,json=dueDate,proto3" json:"due_date,omitempty"` // padrão: 14 dias
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CreateLoanRequest) Reset() {
+	*x = CreateLoanRequest{}
+	mi := &file_library_v1_loans_proto_msgTypes[3]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CreateLoanRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CreateLoanRequest) ProtoMessage() {}
+
+func (x *CreateLoanRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_library_v1_loans_proto_msgTypes[3]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CreateLoanRequest.ProtoReflect.Descriptor instead.
+func (*CreateLoanRequest) Descriptor() ([]byte, []int) {
+	return file_library_v1_loans_proto_rawDescGZIP(), []int{3}
+}
+
+func (x *CreateLoanRequest) GetBookId() uint32 {
+	if x != nil {
+		return x.BookId
+	}
+	return 0
+}
+
+func (x *CreateLoanRequest) GetUserName() string {
+	if x != nil {
+		return x.UserName
+	}
+	return ""
+}
+
+func (x *CreateLoanRequest) GetDueDate() *timestamppb.Timestamp {
+	if x != nil {
+		return x.DueDate
+	}
+	return nil
+}
+
+type ReturnLoanRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            uint32                 `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ReturnLoanRequest) Reset() {
+	*x = ReturnLoanRequest{}
+	mi := &file_library_v1_loans_proto_msgTypes[4]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ReturnLoanRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ReturnLoanRequest) ProtoMessage() {}
+
+func (x *ReturnLoanRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_library_v1_loans_proto_msgTypes[4]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ReturnLoanRequest.ProtoReflect.Descriptor instead.
+func (*ReturnLoanRequest) Descriptor() ([]byte, []int) {
+	return file_library_v1_loans_proto_rawDescGZIP(), []int{4}
+}
+
+func (x *ReturnLoanRequest) GetId() uint32 {
+	if x != nil {
+		return x.Id
+	}
+	return 0
+}
+
+type ReturnLoanResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Message       string                 `protobuf:"bytes,1,opt,name=message,proto3" json:"message,omitempty"`
+	Loan          *Loan                  `protobuf:"bytes,2,opt,name=loan,proto3" json:"loan,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ReturnLoanResponse) Reset() {
+	*x = ReturnLoanResponse{}
+	mi := &file_library_v1_loans_proto_msgTypes[5]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ReturnLoanResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ReturnLoanResponse) ProtoMessage() {}
+
+func (x *ReturnLoanResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_library_v1_loans_proto_msgTypes[5]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ReturnLoanResponse.ProtoReflect.Descriptor instead.
+func (*ReturnLoanResponse) Descriptor() ([]byte, []int) {
+	return file_library_v1_loans_proto_rawDescGZIP(), []int{5}
+}
+
+func (x *ReturnLoanResponse) GetMessage() string {
+	if x != nil {
+		return x.Message
+	}
+	return ""
+}
+
+func (x *ReturnLoanResponse) GetLoan() *Loan {
+	if x != nil {
+		return x.Loan
+	}
+	return nil
+}
+
+type DeleteLoanRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            uint32                 `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DeleteLoanRequest) Reset() {
+	*x = DeleteLoanRequest{}
+	mi := &file_library_v1_loans_proto_msgTypes[6]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DeleteLoanRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DeleteLoanRequest) ProtoMessage() {}
+
+func (x *DeleteLoanRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_library_v1_loans_proto_msgTypes[6]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DeleteLoanRequest.ProtoReflect.Descriptor instead.
+func (*DeleteLoanRequest) Descriptor() ([]byte, []int) {
+	return file_library_v1_loans_proto_rawDescGZIP(), []int{6}
+}
+
+func (x *DeleteLoanRequest) GetId() uint32 {
+	if x != nil {
+		return x.Id
+	}
+	return 0
+}
+
+var File_library_v1_loans_proto protoreflect.FileDescriptor
+
+const file_library_v1_loans_proto_rawDesc = "" +
+	"\n" +
+	"\x16library/v1/loans.proto\x12\n" +
+	"library.v1\x1a\x1fgoogle/protobuf/timestamp.proto\x1a\x17library/v1/models.proto\"G\n" +
+	"\x10ListLoansRequest\x12\x1b\n" +
+	"\tuser_name\x18\x01 \x01(\tR\buserName\x12\x16\n" +
+	"\x06status\x18\x02 \x01(\tR\x06status\";\n" +
+	"\x11ListLoansResponse\x12&\n" +
+	"\x05loans\x18\x01 \x03(\v2\x10.library.v1.LoanR\x05loans\" \n" +
+	"\x0eGetLoanRequest\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\rR\x02id\"\x80\x01\n" +
+	"\x11CreateLoanRequest\x12\x17\n" +
+	"\abook_id\x18\x01 \x01(\rR\x06bookId\x12\x1b\n" +
+	"\tuser_name\x18\x02 \x01(\tR\buserName\x125\n" +
+	"\bdue_date\x18\x03 \x01(\v2\x1a.google.protobuf.TimestampR\adueDate\"#\n" +
+	"\x11ReturnLoanRequest\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\rR\x02id\"T\n" +
+	"\x12ReturnLoanResponse\x12\x18\n" +
+	"\amessage\x18\x01 \x01(\tR\amessage\x12$\n" +
+	"\x04loan\x18\x02 \x01(\v2\x10.library.v1.LoanR\x04loan\"#\n" +
+	"\x11DeleteLoanRequest\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\rR\x02id2\xe5\x02\n" +
+	"\vLoanService\x12H\n" +
+	"\tListLoans\x12\x1c.library.v1.ListLoansRequest\x1a\x1d.library.v1.ListLoansResponse\x127\n" +
+	"\aGetLoan\x12\x1a.library.v1.GetLoanRequest\x1a\x10.library.v1.Loan\x12=\n" +
+	"\n" +
+	"CreateLoan\x12\x1d.library.v1.CreateLoanRequest\x1a\x10.library.v1.Loan\x12K\n" +
+	"\n" +
+	"ReturnLoan\x12\x1d.library.v1.ReturnLoanRequest\x1a\x1e.library.v1.ReturnLoanResponse\x12G\n" +
+	"\n" +
+	"DeleteLoan\x12\x1d.library.v1.DeleteLoanRequest\x1a\x1a.library.v1.DeleteResponseB.Z,library-api/internal/rpc/libraryv1;libraryv1b\x06proto3"
+
+var (
+	file_library_v1_loans_proto_rawDescOnce sync.Once
+	file_library_v1_loans_proto_rawDescData []byte
+)
+
+func file_library_v1_loans_proto_rawDescGZIP() []byte {
+	file_library_v1_loans_proto_rawDescOnce.Do(func() {
+		file_library_v1_loans_proto_rawDescData = protoimpl.X.CompressGZIP(unsafe.Slice(unsafe.StringData(file_library_v1_loans_proto_rawDesc), len(file_library_v1_loans_proto_rawDesc)))
+	})
+	return file_library_v1_loans_proto_rawDescData
+}
+
+var file_library_v1_loans_proto_msgTypes = make([]protoimpl.MessageInfo, 7)
+var file_library_v1_loans_proto_goTypes = []any{
+	(*ListLoansRequest)(nil),      // 0: library.v1.ListLoansRequest
+	(*ListLoansResponse)(nil),     // 1: library.v1.ListLoansResponse
+	(*GetLoanRequest)(nil),        // 2: library.v1.GetLoanRequest
+	(*CreateLoanRequest)(nil),     // 3: library.v1.CreateLoanRequest
+	(*ReturnLoanRequest)(nil),     // 4: library.v1.ReturnLoanRequest
+	(*ReturnLoanResponse)(nil),    // 5: library.v1.ReturnLoanResponse
+	(*DeleteLoanRequest)(nil),     // 6: library.v1.DeleteLoanRequest
+	(*Loan)(nil),                  // 7: library.v1.Loan
+	(*timestamppb.Timestamp)(nil), // 8: google.protobuf.Timestamp
+	(*DeleteResponse)(nil),        // 9: library.v1.DeleteResponse
+}
+var file_library_v1_loans_proto_depIdxs = []int32{
+	7, // 0: library.v1.ListLoansResponse.loans:type_name -> library.v1.Loan
+	8, // 1: library.v1.CreateLoanRequest.due_date:type_name -> google.protobuf.Timestamp
+	7, // 2: library.v1.ReturnLoanResponse.loan:type_name -> library.v1.Loan
+	0, // 3: library.v1.LoanService.ListLoans:input_type -> library.v1.ListLoansRequest
+	2, // 4: library.v1.LoanService.GetLoan:input_type -> library.v1.GetLoanRequest
+	3, // 5: library.v1.LoanService.CreateLoan:input_type -> library.v1.CreateLoanRequest
+	4, // 6: library.v1.LoanService.ReturnLoan:input_type -> library.v1.ReturnLoanRequest
+	6, // 7: library.v1.LoanService.DeleteLoan:input_type -> library.v1.DeleteLoanRequest
+	1, // 8: library.v1.LoanService.ListLoans:output_type -> library.v1.ListLoansResponse
+	7, // 9: library.v1.LoanService.GetLoan:output_type -> library.v1.Loan
+	7, // 10: library.v1.LoanService.CreateLoan:output_type -> library.v1.Loan
+	5, // 11: library.v1.LoanService.ReturnLoan:output_type -> library.v1.ReturnLoanResponse
+	9, // 12: library.v1.LoanService.DeleteLoan:output_type -> library.v1.DeleteResponse
+	8, // [8:13] is the sub-list for method output_type
+	3, // [3:8] is the sub-list for method input_type
+	3, // [3:3] is the sub-list for extension type_name
+	3, // [3:3] is the sub-list for extension extendee
+	0, // [0:3] is the sub-list for field type_name
+}
+
+func init() { file_library_v1_loans_proto_init() }
+func file_library_v1_loans_proto_init() {
+	if File_library_v1_loans_proto != nil {
+		return
+	}
+	file_library_v1_models_proto_init()
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: unsafe.Slice(unsafe.StringData(file_library_v1_loans_proto_rawDesc), len(file_library_v1_loans_proto_rawDesc)),
+			NumEnums:      0,
+			NumMessages:   7,
+			NumExtensions: 0,
+			NumServices:   1,
+		},
+		GoTypes:           file_library_v1_loans_proto_goTypes,
+		DependencyIndexes: file_library_v1_loans_proto_depIdxs,
+		MessageInfos:      file_library_v1_loans_proto_msgTypes,
+	}.Build()
+	File_library_v1_loans_proto = out.File
+	file_library_v1_loans_proto_goTypes = nil
+	file_library_v1_loans_proto_depIdxs = nil
+}
@@ -0,0 +1,953 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.36.10
+// 	protoc        (unknown)
+// source: library/v1/models.proto
+
+package libraryv1
+
+import (
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	timestamppb "google.golang.org/protobuf/types/known/timestamppb"
+	reflect "reflect"
+	sync "sync"
+	unsafe "unsafe"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+// Book é um livro do acervo, como em GET /books/{id}
+type Book struct {
+	state           protoimpl.MessageState `protogen:"open.v1"`
+	Id              uint32                 `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+	Title           string                 `protobuf:"bytes,2,opt,name=title,proto3" json:"title,omitempty"`
+	Isbn            string                 `protobuf:"bytes,3,opt,name=isbn,proto3" json:"isbn,omitempty"`
+	Available       bool                   `protobuf:"varint,4,opt,name=available,proto3" json:"available,omitempty"`
+	Publisher       string                 `protobuf:"bytes,5,opt,name=publisher,proto3" json:"publisher,omitempty"`
+	PublicationYear int32                  `protobuf:"varint,6,opt,name=publication_year,json=publicationYear,proto3" json:"publication_year,omitempty"`
+	Edition         string                 `protobuf:"bytes,7,opt,name=edition,proto3" json:"edition,omitempty"`
+	Language        string                 `protobuf:"bytes,8,opt,name=language,proto3" json:"language,omitempty"` // código ISO 639
+	Pages           int32                  `protobuf:"varint,9,opt,name=pages,proto3" json:"pages,omitempty"`
+	Description     string                 `protobuf:"bytes,10,opt,name=description,proto3" json:"description,omitempty"`
+	CoverUrl        string                 `protobuf:"bytes,11,opt,name=cover_url,json=coverUrl,proto3" json:"cover_url,omitempty"`
+	Authors         []*Author              `protobuf:"bytes,12,rep,name=authors,proto3" json:"authors,omitempty"`
+	Subjects        []*Subject             `protobuf:"bytes,13,rep,name=subjects,proto3" json:"subjects,omitempty"`
+	SeriesId        *uint32                `protobuf:"varint,14,opt,name=series_id,json=seriesId,proto3,oneof" json:"series_id,omitempty"`
+	SeriesVolume    int32                  `protobuf:"varint,15,opt,name=series_volume,json=seriesVolume,proto3" json:"series_volume,omitempty"`
+	Series          *Series                `protobuf:"bytes,16,opt,name=series,proto3" json:"series,omitempty"`
+	Role            string                 `protobuf:"bytes,17,opt,name=role,proto3" json:"role,omitempty"`          // papel do autor, em Author.books
+	Position        int32                  `protobuf:"varint,18,opt,name=position,proto3" json:"position,omitempty"` // ordem do autor, em Author.books
+	CreatedAt       *timestamppb.Timestamp `protobuf:"bytes,19,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
+	UpdatedAt       *timestamppb.Timestamp `protobuf:"bytes,20,opt,name=updated_at,json=updatedAt,proto3" json:"updated_at,omitempty"`
+	unknownFields   protoimpl.UnknownFields
+	sizeCache       protoimpl.SizeCache
+}
+
+func (x *Book) Reset() {
+	*x = Book{}
+	mi := &file_library_v1_models_proto_msgTypes[0]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Book) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Book) ProtoMessage() {}
+
+func (x *Book) ProtoReflect() protoreflect.Message {
+	mi := &file_library_v1_models_proto_msgTypes[0]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Book.ProtoReflect.Descriptor instead.
+func (*Book) Descriptor() ([]byte, []int) {
+	return file_library_v1_models_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *Book) GetId() uint32 {
+	if x != nil {
+		return x.Id
+	}
+	return 0
+}
+
+func (x *Book) GetTitle() string {
+	if x != nil {
+		return x.Title
+	}
+	return ""
+}
+
+func (x *Book) GetIsbn() string {
+	if x != nil {
+		return x.Isbn
+	}
+	return ""
+}
+
+func (x *Book) GetAvailable() bool {
+	if x != nil {
+		return x.Available
+	}
+	return false
+}
+
+func (x *Book) GetPublisher() string {
+	if x != nil {
+		return x.Publisher
+	}
+	return ""
+}
+
+func (x *Book) GetPublicationYear() int32 {
+	if x != nil {
+		return x.PublicationYear
+	}
+	return 0
+}
+
+func (x *Book) GetEdition() string {
+	if x != nil {
+		return x.Edition
+	}
+	return ""
+}
+
+func (x *Book) GetLanguage() string {
+	if x != nil {
+		return x.Language
+	}
+	return ""
+}
+
+func (x *Book) GetPages() int32 {
+	if x != nil {
+		return x.Pages
+	}
+	return 0
+}
+
+func (x *Book) GetDescription() string {
+	if x != nil {
+		return x.Description
+	}
+	return ""
+}
+
+func (x *Book) GetCoverUrl() string {
+	if x != nil {
+		return x.CoverUrl
+	}
+	return ""
+}
+
+func (x *Book) GetAuthors() []*Author {
+	if x != nil {
+		return x.Authors
+	}
+	return nil
+}
+
+func (x *Book) GetSubjects() []*Subject {
+	if x != nil {
+		return x.Subjects
+	}
+	return nil
+}
+
+func (x *Book) GetSeriesId() uint32 {
+	if x != nil && x.SeriesId != nil {
+		return *x.SeriesId
+	}
+	return 0
+}
+
+func (x *Book) GetSeriesVolume() int32 {
+	if x != nil {
+		return x.SeriesVolume
+	}
+	return 0
+}
+
+func (x *Book) GetSeries() *Series {
+	if x != nil {
+		return x.Series
+	}
+	return nil
+}
+
+func (x *Book) GetRole() string {
+	if x != nil {
+		return x.Role
+	}
+	return ""
+}
+
+func (x *Book) GetPosition() int32 {
+	if x != nil {
+		return x.Position
+	}
+	return 0
+}
+
+func (x *Book) GetCreatedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.CreatedAt
+	}
+	return nil
+}
+
+func (x *Book) GetUpdatedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.UpdatedAt
+	}
+	return nil
+}
+
+// Author é um autor, como em GET /authors/{id}
+type Author struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            uint32                 `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+	Name          string                 `protobuf:"bytes,2,opt,name=name,proto3" json:"name,omitempty"`
+	SortName      string                 `protobuf:"bytes,3,opt,name=sort_name,json=sortName,proto3" json:"sort_name,omitempty"`
+	Bio           string                 `protobuf:"bytes,4,opt,name=bio,proto3" json:"bio,omitempty"`
+	BirthYear     *int32                 `protobuf:"varint,5,opt,name=birth_year,json=birthYear,proto3,oneof" json:"birth_year,omitempty"`
+	DeathYear     *int32                 `protobuf:"varint,6,opt,name=death_year,json=deathYear,proto3,oneof" json:"death_year,omitempty"`
+	Nationality   string                 `protobuf:"bytes,7,opt,name=nationality,proto3" json:"nationality,omitempty"`
+	Orcid         *string                `protobuf:"bytes,8,opt,name=orcid,proto3,oneof" json:"orcid,omitempty"`
+	Viaf          *string                `protobuf:"bytes,9,opt,name=viaf,proto3,oneof" json:"viaf,omitempty"`
+	Isni          *string                `protobuf:"bytes,10,opt,name=isni,proto3,oneof" json:"isni,omitempty"`
+	Books         []*Book                `protobuf:"bytes,11,rep,name=books,proto3" json:"books,omitempty"`
+	Aliases       []*AuthorAlias         `protobuf:"bytes,12,rep,name=aliases,proto3" json:"aliases,omitempty"`
+	Role          string                 `protobuf:"bytes,13,opt,name=role,proto3" json:"role,omitempty"`          // papel no livro, em Book.authors
+	Position      int32                  `protobuf:"varint,14,opt,name=position,proto3" json:"position,omitempty"` // ordem de exibição, em Book.authors
+	CreatedAt     *timestamppb.Timestamp `protobuf:"bytes,15,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
+	UpdatedAt     *timestamppb.Timestamp `protobuf:"bytes,16,opt,name=updated_at,json=updatedAt,proto3" json:"updated_at,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Author) Reset() {
+	*x = Author{}
+	mi := &file_library_v1_models_proto_msgTypes[1]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Author) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Author) ProtoMessage() {}
+
+func (x *Author) ProtoReflect() protoreflect.Message {
+	mi := &file_library_v1_models_proto_msgTypes[1]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Author.ProtoReflect.Descriptor instead.
+func (*Author) Descriptor() ([]byte, []int) {
+	return file_library_v1_models_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *Author) GetId() uint32 {
+	if x != nil {
+		return x.Id
+	}
+	return 0
+}
+
+func (x *Author) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *Author) GetSortName() string {
+	if x != nil {
+		return x.SortName
+	}
+	return ""
+}
+
+func (x *Author) GetBio() string {
+	if x != nil {
+		return x.Bio
+	}
+	return ""
+}
+
+func (x *Author) GetBirthYear() int32 {
+	if x != nil && x.BirthYear != nil {
+		return *x.BirthYear
+	}
+	return 0
+}
+
+func (x *Author) GetDeathYear() int32 {
+	if x != nil && x.DeathYear != nil {
+		return *x.DeathYear
+	}
+	return 0
+}
+
+func (x *Author) GetNationality() string {
+	if x != nil {
+		return x.Nationality
+	}
+	return ""
+}
+
+func (x *Author) GetOrcid() string {
+	if x != nil && x.Orcid != nil {
+		return *x.Orcid
+	}
+	return ""
+}
+
+func (x *Author) GetViaf() string {
+	if x != nil && x.Viaf != nil {
+		return *x.Viaf
+	}
+	return ""
+}
+
+func (x *Author) GetIsni() string {
+	if x != nil && x.Isni != nil {
+		return *x.Isni
+	}
+	return ""
+}
+
+func (x *Author) GetBooks() []*Book {
+	if x != nil {
+		return x.Books
+	}
+	return nil
+}
+
+func (x *Author) GetAliases() []*AuthorAlias {
+	if x != nil {
+		return x.Aliases
+	}
+	return nil
+}
+
+func (x *Author) GetRole() string {
+	if x != nil {
+		return x.Role
+	}
+	return ""
+}
+
+func (x *Author) GetPosition() int32 {
+	if x != nil {
+		return x.Position
+	}
+	return 0
+}
+
+func (x *Author) GetCreatedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.CreatedAt
+	}
+	return nil
+}
+
+func (x *Author) GetUpdatedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.UpdatedAt
+	}
+	return nil
+}
+
+// AuthorAlias é outro nome pelo qual o autor é conhecido
+type AuthorAlias struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            uint32                 `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+	AuthorId      uint32                 `protobuf:"varint,2,opt,name=author_id,json=authorId,proto3" json:"author_id,omitempty"`
+	Name          string                 `protobuf:"bytes,3,opt,name=name,proto3" json:"name,omitempty"`
+	Kind          string                 `protobuf:"bytes,4,opt,name=kind,proto3" json:"kind,omitempty"` // alias ou pseudonym
+	CreatedAt     *timestamppb.Timestamp `protobuf:"bytes,5,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *AuthorAlias) Reset() {
+	*x = AuthorAlias{}
+	mi := &file_library_v1_models_proto_msgTypes[2]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *AuthorAlias) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*AuthorAlias) ProtoMessage() {}
+
+func (x *AuthorAlias) ProtoReflect() protoreflect.Message {
+	mi := &file_library_v1_models_proto_msgTypes[2]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use AuthorAlias.ProtoReflect.Descriptor instead.
+func (*AuthorAlias) Descriptor() ([]byte, []int) {
+	return file_library_v1_models_proto_rawDescGZIP(), []int{2}
+}
+
+func (x *AuthorAlias) GetId() uint32 {
+	if x != nil {
+		return x.Id
+	}
+	return 0
+}
+
+func (x *AuthorAlias) GetAuthorId() uint32 {
+	if x != nil {
+		return x.AuthorId
+	}
+	return 0
+}
+
+func (x *AuthorAlias) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *AuthorAlias) GetKind() string {
+	if x != nil {
+		return x.Kind
+	}
+	return ""
+}
+
+func (x *AuthorAlias) GetCreatedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.CreatedAt
+	}
+	return nil
+}
+
+type Subject struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            uint32                 `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+	Name          string                 `protobuf:"bytes,2,opt,name=name,proto3" json:"name,omitempty"`
+	CreatedAt     *timestamppb.Timestamp `protobuf:"bytes,3,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
+	UpdatedAt     *timestamppb.Timestamp `protobuf:"bytes,4,opt,name=updated_at,json=updatedAt,proto3" json:"updated_at,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Subject) Reset() {
+	*x = Subject{}
+	mi := &file_library_v1_models_proto_msgTypes[3]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Subject) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Subject) ProtoMessage() {}
+
+func (x *Subject) ProtoReflect() protoreflect.Message {
+	mi := &file_library_v1_models_proto_msgTypes[3]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Subject.ProtoReflect.Descriptor instead.
+func (*Subject) Descriptor() ([]byte, []int) {
+	return file_library_v1_models_proto_rawDescGZIP(), []int{3}
+}
+
+func (x *Subject) GetId() uint32 {
+	if x != nil {
+		return x.Id
+	}
+	return 0
+}
+
+func (x *Subject) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *Subject) GetCreatedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.CreatedAt
+	}
+	return nil
+}
+
+func (x *Subject) GetUpdatedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.UpdatedAt
+	}
+	return nil
+}
+
+type Series struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            uint32                 `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+	Name          string                 `protobuf:"bytes,2,opt,name=name,proto3" json:"name,omitempty"`
+	Description   string                 `protobuf:"bytes,3,opt,name=description,proto3" json:"description,omitempty"`
+	TotalVolumes  int32                  `protobuf:"varint,4,opt,name=total_volumes,json=totalVolumes,proto3" json:"total_volumes,omitempty"`
+	CreatedAt     *timestamppb.Timestamp `protobuf:"bytes,5,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
+	UpdatedAt     *timestamppb.Timestamp `protobuf:"bytes,6,opt,name=updated_at,json=updatedAt,proto3" json:"updated_at,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Series) Reset() {
+	*x = Series{}
+	mi := &file_library_v1_models_proto_msgTypes[4]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Series) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Series) ProtoMessage() {}
+
+func (x *Series) ProtoReflect() protoreflect.Message {
+	mi := &file_library_v1_models_proto_msgTypes[4]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Series.ProtoReflect.Descriptor instead.
+func (*Series) Descriptor() ([]byte, []int) {
+	return file_library_v1_models_proto_rawDescGZIP(), []int{4}
+}
+
+func (x *Series) GetId() uint32 {
+	if x != nil {
+		return x.Id
+	}
+	return 0
+}
+
+func (x *Series) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *Series) GetDescription() string {
+	if x != nil {
+		return x.Description
+	}
+	return ""
+}
+
+func (x *Series) GetTotalVolumes() int32 {
+	if x != nil {
+		return x.TotalVolumes
+	}
+	return 0
+}
+
+func (x *Series) GetCreatedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.CreatedAt
+	}
+	return nil
+}
+
+func (x *Series) GetUpdatedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.UpdatedAt
+	}
+	return nil
+}
+
+// Loan é um empréstimo, como em GET /loans/{id}
+type Loan struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            uint32                 `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+	BookId        uint32                 `protobuf:"varint,2,opt,name=book_id,json=bookId,proto3" json:"book_id,omitempty"`
+	Book          *Book                  `protobuf:"bytes,3,opt,name=book,proto3" json:"book,omitempty"`
+	UserName      string                 `protobuf:"bytes,4,opt,name=user_name,json=userName,proto3" json:"user_name,omitempty"`
+	LoanDate      *timestamppb.Timestamp `protobuf:"bytes,5,opt,name=loan_date,json=loanDate,proto3" json:"loan_date,omitempty"`
+	DueDate       *timestamppb.Timestamp `protobuf:"bytes,6,opt,name=due_date,json=dueDate,proto3" json:"due_date,omitempty"`
+	ReturnDate    *timestamppb.Timestamp `protobuf:"bytes,7,opt,name=return_date,json=returnDate,proto3" json:"return_date,omitempty"` // ausente enquanto não devolvido
+	Overdue       bool                   `protobuf:"varint,8,opt,name=overdue,proto3" json:"overdue,omitempty"`
+	CreatedAt     *timestamppb.Timestamp `protobuf:"bytes,9,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
+	UpdatedAt     *timestamppb.Timestamp `protobuf:"bytes,10,opt,name=updated_at,json=updatedAt,proto3" json:"updated_at,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Loan) Reset() {
+	*x = Loan{}
+	mi := &file_library_v1_models_proto_msgTypes[5]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Loan) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Loan) ProtoMessage() {}
+
+func (x *Loan) ProtoReflect() protoreflect.Message {
+	mi := &file_library_v1_models_proto_msgTypes[5]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Loan.ProtoReflect.Descriptor instead.
+func (*Loan) Descriptor() ([]byte, []int) {
+	return file_library_v1_models_proto_rawDescGZIP(), []int{5}
+}
+
+func (x *Loan) GetId() uint32 {
+	if x != nil {
+		return x.Id
+	}
+	return 0
+}
+
+func (x *Loan) GetBookId() uint32 {
+	if x != nil {
+		return x.BookId
+	}
+	return 0
+}
+
+func (x *Loan) GetBook() *Book {
+	if x != nil {
+		return x.Book
+	}
+	return nil
+}
+
+func (x *Loan) GetUserName() string {
+	if x != nil {
+		return x.UserName
+	}
+	return ""
+}
+
+func (x *Loan) GetLoanDate() *timestamppb.Timestamp {
+	if x != nil {
+		return x.LoanDate
+	}
+	return nil
+}
+
+func (x *Loan) GetDueDate() *timestamppb.Timestamp {
+	if x != nil {
+		return x.DueDate
+	}
+	return nil
+}
+
+func (x *Loan) GetReturnDate() *timestamppb.Timestamp {
+	if x != nil {
+		return x.ReturnDate
+	}
+	return nil
+}
+
+func (x *Loan) GetOverdue() bool {
+	if x != nil {
+		return x.Overdue
+	}
+	return false
+}
+
+func (x *Loan) GetCreatedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.CreatedAt
+	}
+	return nil
+}
+
+func (x *Loan) GetUpdatedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.UpdatedAt
+	}
+	return nil
+}
+
+// DeleteResponse confirma uma remoção
+type DeleteResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Message       string                 `protobuf:"bytes,1,opt,name=message,proto3" json:"message,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DeleteResponse) Reset() {
+	*x = DeleteResponse{}
+	mi := &file_library_v1_models_proto_msgTypes[6]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DeleteResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DeleteResponse) ProtoMessage() {}
+
+func (x *DeleteResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_library_v1_models_proto_msgTypes[6]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DeleteResponse.ProtoReflect.Descriptor instead.
+func (*DeleteResponse) Descriptor() ([]byte, []int) {
+	return file_library_v1_models_proto_rawDescGZIP(), []int{6}
+}
+
+func (x *DeleteResponse) GetMessage() string {
+	if x != nil {
+		return x.Message
+	}
+	return ""
+}
+
+var File_library_v1_models_proto protoreflect.FileDescriptor
+
+const file_library_v1_models_proto_rawDesc = "" +
+	"\n" +
+	"\x17library/v1/models.proto\x12\n" +
+	"library.v1\x1a\x1fgoogle/protobuf/timestamp.proto\"\xb8\x05\n" +
+	"\x04Book\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\rR\x02id\x12\x14\n" +
+	"\x05title\x18\x02 \x01(\tR\x05title\x12\x12\n" +
+	"\x04isbn\x18\x03 \x01(\tR\x04isbn\x12\x1c\n" +
+	"\tavailable\x18\x04 \x01(\bR\tavailable\x12\x1c\n" +
+	"\tpublisher\x18\x05 \x01(\tR\tpublisher\x12)\n" +
+	"\x10publication_year\x18\x06 \x01(\x05R\x0fpublicationYear\x12\x18\n" +
+	"\aedition\x18\a \x01(\tR\aedition\x12\x1a\n" +
+	"\blanguage\x18\b \x01(\tR\blanguage\x12\x14\n" +
+	"\x05pages\x18\t \x01(\x05R\x05pages\x12 \n" +
+	"\vdescription\x18\n" +
+	" \x01(\tR\vdescription\x12\x1b\n" +
+	"\tcover_url\x18\v \x01(\tR\bcoverUrl\x12,\n" +
+	"\aauthors\x18\f \x03(\v2\x12.library.v1.AuthorR\aauthors\x12/\n" +
+	"\bsubjects\x18\r \x03(\v2\x13.library.v1.SubjectR\bsubjects\x12 \n" +
+	"\tseries_id\x18\x0e \x01(\rH\x00R\bseriesId\x88\x01\x01\x12#\n" +
+	"\rseries_volume\x18\x0f \x01(\x05R\fseriesVolume\x12*\n" +
+	"\x06series\x18\x10 \x01(\v2\x12.library.v1.SeriesR\x06series\x12\x12\n" +
+	"\x04role\x18\x11 \x01(\tR\x04role\x12\x1a\n" +
+	"\bposition\x18\x12 \x01(\x05R\bposition\x129\n" +
+	"\n" +
+	"created_at\x18\x13 \x01(\v2\x1a.google.protobuf.TimestampR\tcreatedAt\x129\n" +
+	"\n" +
+	"updated_at\x18\x14 \x01(\v2\x1a.google.protobuf.TimestampR\tupdatedAtB\f\n" +
+	"\n" +
+	"_series_id\"\xcd\x04\n" +
+	"\x06Author\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\rR\x02id\x12\x12\n" +
+	"\x04name\x18\x02 \x01(\tR\x04name\x12\x1b\n" +
+	"\tsort_name\x18\x03 \x01(\tR\bsortName\x12\x10\n" +
+	"\x03bio\x18\x04 \x01(\tR\x03bio\x12\"\n" +
+	"\n" +
+	"birth_year\x18\x05 \x01(\x05H\x00R\tbirthYear\x88\x01\x01\x12\"\n" +
+	"\n" +
+	"death_year\x18\x06 \x01(\x05H\x01R\tdeathYear\x88\x01\x01\x12 \n" +
+	"\vnationality\x18\a \x01(\tR\vnationality\x12\x19\n" +
+	"\x05orcid\x18\b \x01(\tH\x02R\x05orcid\x88\x01\x01\x12\x17\n" +
+	"\x04viaf\x18\t \x01(\tH\x03R\x04viaf\x88\x01\x01\x12\x17\n" +
+	"\x04isni\x18\n" +
+	" \x01(\tH\x04R\x04isni\x88\x01\x01\x12&\n" +
+	"\x05books\x18\v \x03(\v2\x10.library.v1.BookR\x05books\x121\n" +
+	"\aaliases\x18\f \x03(\v2\x17.library.v1.AuthorAliasR\aaliases\x12\x12\n" +
+	"\x04role\x18\r \x01(\tR\x04role\x12\x1a\n" +
+	"\bposition\x18\x0e \x01(\x05R\bposition\x129\n" +
+	"\n" +
+	"created_at\x18\x0f \x01(\v2\x1a.google.protobuf.TimestampR\tcreatedAt\x129\n" +
+	"\n" +
+	"updated_at\x18\x10 \x01(\v2\x1a.google.protobuf.TimestampR\tupdatedAtB\r\n" +
+	"\v_birth_yearB\r\n" +
+	"\v_death_yearB\b\n" +
+	"\x06_orcidB\a\n" +
+	"\x05_viafB\a\n" +
+	"\x05_isni\"\x9d\x01\n" +
+	"\vAuthorAlias\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\rR\x02id\x12\x1b\n" +
+	"\tauthor_id\x18\x02 \x01(\rR\bauthorId\x12\x12\n" +
+	"\x04name\x18\x03 \x01(\tR\x04name\x12\x12\n" +
+	"\x04kind\x18\x04 \x01(\tR\x04kind\x129\n" +
+	"\n" +
+	"created_at\x18\x05 \x01(\v2\x1a.google.protobuf.TimestampR\tcreatedAt\"\xa3\x01\n" +
+	"\aSubject\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\rR\x02id\x12\x12\n" +
+	"\x04name\x18\x02 \x01(\tR\x04name\x129\n" +
+	"\n" +
+	"created_at\x18\x03 \x01(\v2\x1a.google.protobuf.TimestampR\tcreatedAt\x129\n" +
+	"\n" +
+	"updated_at\x18\x04 \x01(\v2\x1a.google.protobuf.TimestampR\tupdatedAt\"\xe9\x01\n" +
+	"\x06Series\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\rR\x02id\x12\x12\n" +
+	"\x04name\x18\x02 \x01(\tR\x04name\x12 \n" +
+	"\vdescription\x18\x03 \x01(\tR\vdescription\x12#\n" +
+	"\rtotal_volumes\x18\x04 \x01(\x05R\ftotalVolumes\x129\n" +
+	"\n" +
+	"created_at\x18\x05 \x01(\v2\x1a.google.protobuf.TimestampR\tcreatedAt\x129\n" +
+	"\n" +
+	"updated_at\x18\x06 \x01(\v2\x1a.google.protobuf.TimestampR\tupdatedAt\"\xaf\x03\n" +
+	"\x04Loan\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\rR\x02id\x12\x17\n" +
+	"\abook_id\x18\x02 \x01(\rR\x06bookId\x12$\n" +
+	"\x04book\x18\x03 \x01(\v2\x10.library.v1.BookR\x04book\x12\x1b\n" +
+	"\tuser_name\x18\x04 \x01(\tR\buserName\x127\n" +
+	"\tloan_date\x18\x05 \x01(\v2\x1a.google.protobuf.TimestampR\bloanDate\x125\n" +
+	"\bdue_date\x18\x06 \x01(\v2\x1a.google.protobuf.TimestampR\adueDate\x12;\n" +
+	"\vreturn_date\x18\a \x01(\v2\x1a.google.protobuf.TimestampR\n" +
+	"returnDate\x12\x18\n" +
+	"\aoverdue\x18\b \x01(\bR\aoverdue\x129\n" +
+	"\n" +
+	"created_at\x18\t \x01(\v2\x1a.google.protobuf.TimestampR\tcreatedAt\x129\n" +
+	"\n" +
+	"updated_at\x18\n" +
+	" \x01(\v2\x1a.google.protobuf.TimestampR\tupdatedAt\"*\n" +
+	"\x0eDeleteResponse\x12\x18\n" +
+	"\amessage\x18\x01 \x01(\tR\amessageB.Z,library-api/internal/rpc/libraryv1;libraryv1b\x06proto3"
+
+var (
+	file_library_v1_models_proto_rawDescOnce sync.Once
+	file_library_v1_models_proto_rawDescData []byte
+)
+
+func file_library_v1_models_proto_rawDescGZIP() []byte {
+	file_library_v1_models_proto_rawDescOnce.Do(func() {
+		file_library_v1_models_proto_rawDescData = protoimpl.X.CompressGZIP(unsafe.Slice(unsafe.StringData(file_library_v1_models_proto_rawDesc), len(file_library_v1_models_proto_rawDesc)))
+	})
+	return file_library_v1_models_proto_rawDescData
+}
+
+var file_library_v1_models_proto_msgTypes = make([]protoimpl.MessageInfo, 7)
+var file_library_v1_models_proto_goTypes = []any{
+	(*Book)(nil),                  // 0: library.v1.Book
+	(*Author)(nil),                // 1: library.v1.Author
+	(*AuthorAlias)(nil),           // 2: library.v1.AuthorAlias
+	(*Subject)(nil),               // 3: library.v1.Subject
+	(*Series)(nil),                // 4: library.v1.Series
+	(*Loan)(nil),                  // 5: library.v1.Loan
+	(*DeleteResponse)(nil),        // 6: library.v1.DeleteResponse
+	(*timestamppb.Timestamp)(nil), // 7: google.protobuf.Timestamp
+}
+var file_library_v1_models_proto_depIdxs = []int32{
+	1,  // 0: library.v1.Book.authors:type_name -> library.v1.Author
+	3,  // 1: library.v1.Book.subjects:type_name -> library.v1.Subject
+	4,  // 2: library.v1.Book.series:type_name -> library.v1.Series
+	7,  // 3: library.v1.Book.created_at:type_name -> google.protobuf.Timestamp
+	7,  // 4: library.v1.Book.updated_at:type_name -> google.protobuf.Timestamp
+	0,  // 5: library.v1.Author.books:type_name -> library.v1.Book
+	2,  // 6: library.v1.Author.aliases:type_name -> library.v1.AuthorAlias
+	7,  // 7: library.v1.Author.created_at:type_name -> google.protobuf.Timestamp
+	7,  // 8: library.v1.Author.updated_at:type_name -> google.protobuf.Timestamp
+	7,  // 9: library.v1.AuthorAlias.created_at:type_name -> google.protobuf.Timestamp
+	7,  // 10: library.v1.Subject.created_at:type_name -> google.protobuf.Timestamp
+	7,  // 11: library.v1.Subject.updated_at:type_name -> google.protobuf.Timestamp
+	7,  // 12: library.v1.Series.created_at:type_name -> google.protobuf.Timestamp
+	7,  // 13: library.v1.Series.updated_at:type_name -> google.protobuf.Timestamp
+	0,  // 14: library.v1.Loan.book:type_name -> library.v1.Book
+	7,  // 15: library.v1.Loan.loan_date:type_name -> google.protobuf.Timestamp
+	7,  // 16: library.v1.Loan.due_date:type_name -> google.protobuf.Timestamp
+	7,  // 17: library.v1.Loan.return_date:type_name -> google.protobuf.Timestamp
+	7,  // 18: library.v1.Loan.created_at:type_name -> google.protobuf.Timestamp
+	7,  // 19: library.v1.Loan.updated_at:type_name -> google.protobuf.Timestamp
+	20, // [20:20] is the sub-list for method output_type
+	20, // [20:20] is the sub-list for method input_type
+	20, // [20:20] is the sub-list for extension type_name
+	20, // [20:20] is the sub-list for extension extendee
+	0,  // [0:20] is the sub-list for field type_name
+}
+
+func init() { file_library_v1_models_proto_init() }
+func file_library_v1_models_proto_init() {
+	if File_library_v1_models_proto != nil {
+		return
+	}
+	file_library_v1_models_proto_msgTypes[0].OneofWrappers = []any{}
+	file_library_v1_models_proto_msgTypes[1].OneofWrappers = []any{}
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: unsafe.Slice(unsafe.StringData(file_library_v1_models_proto_rawDesc), len(file_library_v1_models_proto_rawDesc)),
+			NumEnums:      0,
+			NumMessages:   7,
+			NumExtensions: 0,
+			NumServices:   0,
+		},
+		GoTypes:           file_library_v1_models_proto_goTypes,
+		DependencyIndexes: file_library_v1_models_proto_depIdxs,
+		MessageInfos:      file_library_v1_models_proto_msgTypes,
+	}.Build()
+	File_library_v1_models_proto = out.File
+	file_library_v1_models_proto_goTypes = nil
+	file_library_v1_models_proto_depIdxs = nil
+}
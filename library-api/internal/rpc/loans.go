@@ -0,0 +1,72 @@
+package rpc
+
+import (
+	"context"
+	"library-api/internal/models"
+	"library-api/internal/rpc/libraryv1"
+	"library-api/internal/service"
+
+	"gorm.io/gorm"
+)
+
+type loanServer struct {
+	libraryv1.UnimplementedLoanServiceServer
+	db *gorm.DB
+}
+
+func (s *loanServer) ListLoans(ctx context.Context, req *libraryv1.ListLoansRequest) (*libraryv1.ListLoansResponse, error) {
+	loans, err := service.ListLoans(s.db.WithContext(ctx), service.LoanFilter{
+		UserName: req.UserName,
+		Status:   req.Status,
+	})
+	if err != nil {
+		return nil, statusError(err)
+	}
+
+	resp := &libraryv1.ListLoansResponse{Loans: make([]*libraryv1.Loan, len(loans))}
+	for i, loan := range loans {
+		resp.Loans[i] = toLoan(loan)
+	}
+	return resp, nil
+}
+
+func (s *loanServer) GetLoan(ctx context.Context, req *libraryv1.GetLoanRequest) (*libraryv1.Loan, error) {
+	loan, err := service.GetLoan(s.db.WithContext(ctx), uint(req.Id))
+	if err != nil {
+		return nil, statusError(err)
+	}
+	return toLoan(loan), nil
+}
+
+// CreateLoan empresta o livro pelas mesmas regras de POST /loans: só se
+// estiver disponível, e o prazo informado precisa estar no futuro
+func (s *loanServer) CreateLoan(ctx context.Context, req *libraryv1.CreateLoanRequest) (*libraryv1.Loan, error) {
+	loan := models.Loan{BookID: uint(req.BookId), UserName: req.UserName}
+	if req.DueDate != nil {
+		loan.DueDate = req.DueDate.AsTime()
+	}
+
+	loan, err := service.CreateLoan(s.db.WithContext(ctx), loan)
+	if err != nil {
+		return nil, statusError(err)
+	}
+	created(ctx)
+	return toLoan(loan), nil
+}
+
+// ReturnLoan registra a devolução e libera o livro, como em
+// PUT /loans/{id}/return
+func (s *loanServer) ReturnLoan(ctx context.Context, req *libraryv1.ReturnLoanRequest) (*libraryv1.ReturnLoanResponse, error) {
+	loan, err := service.ReturnLoan(s.db.WithContext(ctx), uint(req.Id))
+	if err != nil {
+		return nil, statusError(err)
+	}
+	return &libraryv1.ReturnLoanResponse{Message: "Book returned successfully", Loan: toLoan(loan)}, nil
+}
+
+func (s *loanServer) DeleteLoan(ctx context.Context, req *libraryv1.DeleteLoanRequest) (*libraryv1.DeleteResponse, error) {
+	if err := service.DeleteLoan(s.db.WithContext(ctx), uint(req.Id)); err != nil {
+		return nil, statusError(err)
+	}
+	return &libraryv1.DeleteResponse{Message: "Loan deleted"}, nil
+}
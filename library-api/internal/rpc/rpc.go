@@ -0,0 +1,73 @@
+// Package rpc implementa a API gRPC de livros, autores e empréstimos
+// (proto/library/v1), com as mesmas regras da API REST (internal/service).
+// O gateway traduz as mesmas chamadas para HTTP/JSON em /v1.
+package rpc
+
+import (
+	"context"
+	"library-api/internal/logging"
+	"library-api/internal/rpc/libraryv1"
+	"library-api/internal/service"
+	"os"
+
+	"go.opentelemetry.io/contrib/instrumentation/google.golang.org/grpc/otelgrpc"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/reflection"
+	"google.golang.org/grpc/status"
+	"gorm.io/gorm"
+)
+
+// DefaultAddr é o endereço do servidor gRPC se GRPC_ADDR não for definido
+const DefaultAddr = ":9090"
+
+// AddrFromEnv devolve o endereço do servidor gRPC (GRPC_ADDR); "off"
+// desliga o servidor e o gateway
+func AddrFromEnv() string {
+	addr := os.Getenv("GRPC_ADDR")
+	switch addr {
+	case "":
+		return DefaultAddr
+	case "off":
+		return ""
+	}
+	return addr
+}
+
+// NewServer cria o servidor gRPC com os serviços de livros, autores e
+// empréstimos e a reflection, para clientes como o grpcurl
+func NewServer(db *gorm.DB) *grpc.Server {
+	srv := grpc.NewServer(
+		grpc.StatsHandler(otelgrpc.NewServerHandler()),
+		grpc.ChainUnaryInterceptor(logging.UnaryInterceptor()),
+	)
+
+	libraryv1.RegisterBookServiceServer(srv, &bookServer{db: db})
+	libraryv1.RegisterAuthorServiceServer(srv, &authorServer{db: db})
+	libraryv1.RegisterLoanServiceServer(srv, &loanServer{db: db})
+	reflection.Register(srv)
+	return srv
+}
+
+// statusError traduz um erro do serviço para o código gRPC correspondente;
+// o gateway os devolve com o mesmo status HTTP da API REST
+func statusError(err error) error {
+	switch service.KindOf(err) {
+	case service.Invalid:
+		return status.Error(codes.InvalidArgument, err.Error())
+	case service.NotFound:
+		return status.Error(codes.NotFound, err.Error())
+	case service.Conflict:
+		return status.Error(codes.AlreadyExists, err.Error())
+	}
+	return status.Error(codes.Internal, err.Error())
+}
+
+// httpCodeHeader leva ao gateway um status HTTP diferente de 200
+const httpCodeHeader = "x-http-code"
+
+// created marca a resposta como 201 Created no gateway
+func created(ctx context.Context) {
+	grpc.SetHeader(ctx, metadata.Pairs(httpCodeHeader, "201"))
+}
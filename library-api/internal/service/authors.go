@@ -0,0 +1,205 @@
+package service
+
+import (
+	"errors"
+	"fmt"
+	"library-api/internal/events"
+	"library-api/internal/models"
+	"strings"
+
+	"gorm.io/gorm"
+)
+
+// ValidateAuthor confere anos, aliases e identificadores, deixando os
+// identificadores na forma em que são gravados
+func ValidateAuthor(author *models.Author) error {
+	if author.BirthYear != nil && author.DeathYear != nil && *author.DeathYear < *author.BirthYear {
+		return invalid("death_year must not be before birth_year")
+	}
+	author.Nationality = strings.TrimSpace(author.Nationality)
+
+	for i := range author.Aliases {
+		alias := &author.Aliases[i]
+		alias.Name = strings.TrimSpace(alias.Name)
+		if alias.Name == "" {
+			return invalid("Alias name is required")
+		}
+		if alias.Kind == "" {
+			alias.Kind = models.AliasVariant
+		} else if alias.Kind != models.AliasVariant && alias.Kind != models.AliasPseudonym {
+			return invalid("Alias kind must be alias or pseudonym")
+		}
+	}
+
+	return NormalizeIdentifiers(author)
+}
+
+// NormalizeIdentifiers valida ORCID, VIAF e ISNI; strings vazias viram nil
+func NormalizeIdentifiers(author *models.Author) error {
+	fields := []struct {
+		name      string
+		value     **string
+		normalize func(string) (string, bool)
+	}{
+		{"orcid", &author.ORCID, models.NormalizeORCID},
+		{"viaf", &author.VIAF, models.NormalizeVIAF},
+		{"isni", &author.ISNI, models.NormalizeISNI},
+	}
+
+	for _, field := range fields {
+		if *field.value == nil {
+			continue
+		}
+		if strings.TrimSpace(**field.value) == "" {
+			*field.value = nil
+			continue
+		}
+		normalized, ok := field.normalize(**field.value)
+		if !ok {
+			return invalid(fmt.Sprintf("Invalid %s", field.name))
+		}
+		*field.value = &normalized
+	}
+	return nil
+}
+
+// CheckIdentifiers garante que nenhum outro autor, inclusive os removidos,
+// já usa os identificadores informados
+func CheckIdentifiers(db *gorm.DB, author models.Author, exceptID uint) error {
+	columns := map[string]*string{"orcid": author.ORCID, "viaf": author.VIAF, "isni": author.ISNI}
+
+	for _, column := range []string{"orcid", "viaf", "isni"} {
+		value := columns[column]
+		if value == nil {
+			continue
+		}
+		var other models.Author
+		db.Unscoped().Where(column+" = ? AND id <> ?", *value, exceptID).Limit(1).Find(&other)
+		if other.ID != 0 {
+			return conflict(fmt.Sprintf("%s already assigned to author %d", strings.ToUpper(column), other.ID))
+		}
+	}
+	return nil
+}
+
+// CreateAuthor cadastra o autor com os aliases enviados. sort_name é
+// gerado a partir do nome quando não informado.
+func CreateAuthor(db *gorm.DB, author models.Author) (models.Author, error) {
+	author.Name = strings.TrimSpace(author.Name)
+	if author.SortName == "" {
+		author.SortName = models.SortName(author.Name)
+	}
+	if err := ValidateAuthor(&author); err != nil {
+		return author, err
+	}
+	if err := CheckIdentifiers(db, author, 0); err != nil {
+		return author, err
+	}
+
+	// Os livros são vinculados pelo livro, não na criação do autor
+	author.Books = nil
+
+	err := db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Create(&author).Error; err != nil { // cria também os aliases enviados
+			return err
+		}
+		return events.Publish(tx, events.AuthorCreated{Author: author})
+	})
+	return author, err
+}
+
+// UpdateAuthor atualiza os campos enviados do autor. Se aliases for
+// enviado, substitui todos os nomes alternativos. Devolve o autor com os
+// aliases.
+func UpdateAuthor(db *gorm.DB, id uint, input models.Author) (models.Author, error) {
+	var author models.Author
+
+	if err := db.First(&author, id).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return author, notFound("Author not found")
+		}
+		return author, err
+	}
+
+	input.Name = strings.TrimSpace(input.Name)
+	if input.Name != "" && input.SortName == "" && input.Name != author.Name {
+		input.SortName = models.SortName(input.Name)
+	}
+	if err := ValidateAuthor(&input); err != nil {
+		return author, err
+	}
+
+	birth, death := author.BirthYear, author.DeathYear
+	if input.BirthYear != nil {
+		birth = input.BirthYear
+	}
+	if input.DeathYear != nil {
+		death = input.DeathYear
+	}
+	if birth != nil && death != nil && *death < *birth {
+		return author, invalid("death_year must not be before birth_year")
+	}
+
+	if err := CheckIdentifiers(db, input, author.ID); err != nil {
+		return author, err
+	}
+
+	var updated models.Author
+	err := db.Transaction(func(tx *gorm.DB) error {
+		err := tx.Model(&author).Updates(models.Author{
+			Name:        input.Name,
+			SortName:    input.SortName,
+			Bio:         input.Bio,
+			BirthYear:   input.BirthYear,
+			DeathYear:   input.DeathYear,
+			Nationality: input.Nationality,
+			ORCID:       input.ORCID,
+			VIAF:        input.VIAF,
+			ISNI:        input.ISNI,
+		}).Error
+		if err != nil {
+			return err
+		}
+
+		if input.Aliases != nil {
+			if err := tx.Where("author_id = ?", author.ID).Delete(&models.AuthorAlias{}).Error; err != nil {
+				return err
+			}
+			for _, alias := range input.Aliases {
+				alias.ID = 0
+				alias.AuthorID = author.ID
+				if err := tx.Create(&alias).Error; err != nil {
+					return err
+				}
+			}
+		}
+
+		if err := tx.Preload("Aliases").First(&updated, author.ID).Error; err != nil {
+			return err
+		}
+		return events.Publish(tx, events.AuthorUpdated{Author: updated})
+	})
+	if err != nil {
+		return author, err
+	}
+	return updated, nil
+}
+
+// DeleteAuthor remove o autor
+func DeleteAuthor(db *gorm.DB, id uint) error {
+	var author models.Author
+
+	if err := db.First(&author, id).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return notFound("Author not found")
+		}
+		return err
+	}
+
+	return db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Delete(&author).Error; err != nil {
+			return err
+		}
+		return events.Publish(tx, events.AuthorDeleted{Author: author})
+	})
+}
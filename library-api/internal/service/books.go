@@ -0,0 +1,278 @@
+package service
+
+import (
+	"errors"
+	"library-api/internal/events"
+	"library-api/internal/importer"
+	"library-api/internal/metadata"
+	"library-api/internal/models"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// ValidateBook confere os metadados bibliográficos e normaliza o idioma
+func ValidateBook(book *models.Book) error {
+	if book.Language != "" {
+		language, ok := models.NormalizeLanguage(book.Language)
+		if !ok {
+			return invalid("language must be an ISO 639 code")
+		}
+		book.Language = language
+	}
+	if book.Pages < 0 {
+		return invalid("pages must not be negative")
+	}
+	if book.PublicationYear < 0 || book.PublicationYear > time.Now().Year()+1 {
+		return invalid("Invalid publication_year")
+	}
+	return nil
+}
+
+// BookCredits monta os créditos enviados em credits ou, na falta deles,
+// em author_ids (todos como autor). Devolve nil se nenhum foi enviado.
+func BookCredits(db *gorm.DB, input models.Book) ([]models.AuthorCredit, error) {
+	credits := input.Credits
+	if len(credits) == 0 {
+		for _, id := range input.AuthorIDs {
+			credits = append(credits, models.AuthorCredit{AuthorID: id, Role: models.RoleAuthor})
+		}
+	}
+	if len(credits) == 0 {
+		return nil, nil
+	}
+
+	seen := map[uint]bool{}
+	ids := make([]uint, 0, len(credits))
+	for i, credit := range credits {
+		if credit.Role == "" {
+			credits[i].Role = models.RoleAuthor
+		} else if !models.ValidRole(credit.Role) {
+			return nil, invalid("role must be author, editor, translator or illustrator")
+		}
+		if seen[credit.AuthorID] {
+			return nil, invalid("Each author can appear only once in credits")
+		}
+		seen[credit.AuthorID] = true
+		ids = append(ids, credit.AuthorID)
+	}
+
+	var count int64
+	if err := db.Model(&models.Author{}).Where("id IN ?", ids).Count(&count).Error; err != nil {
+		return nil, err
+	}
+	if int(count) != len(ids) {
+		return nil, invalid("Some authors were not found")
+	}
+
+	return credits, nil
+}
+
+// CheckSeriesVolume valida a série e se o volume está livre, ignorando
+// o próprio livro (bookID) na verificação
+func CheckSeriesVolume(db *gorm.DB, seriesID uint, volume int, bookID uint) error {
+	var series models.Series
+	if err := db.First(&series, seriesID).Error; err != nil {
+		return invalid("Series not found")
+	}
+
+	if volume < 1 {
+		return invalid("series_volume must be at least 1")
+	}
+
+	var count int64
+	db.Model(&models.Book{}).
+		Where("series_id = ? AND series_volume = ? AND id <> ?", seriesID, volume, bookID).
+		Count(&count)
+	if count > 0 {
+		return conflict("Series volume already taken")
+	}
+
+	return nil
+}
+
+// CreateBook cadastra o livro com os créditos e assuntos informados. Com
+// meta (o autofill pelo ISBN, já aplicado aos campos do livro), os autores
+// e assuntos não enviados vêm dos metadados, encontrados pelo nome ou
+// criados. Devolve o livro com autores e assuntos.
+func CreateBook(db *gorm.DB, book models.Book, meta *metadata.Metadata) (models.Book, error) {
+	if err := ValidateBook(&book); err != nil {
+		return book, err
+	}
+
+	// A série é definida apenas por series_id e series_volume
+	book.Series = nil
+	if book.SeriesID != nil {
+		if err := CheckSeriesVolume(db, *book.SeriesID, book.SeriesVolume, 0); err != nil {
+			return book, err
+		}
+	}
+
+	credits, err := BookCredits(db, book)
+	if err != nil {
+		return book, err
+	}
+
+	// Associa os assuntos informados
+	if len(book.SubjectIDs) > 0 {
+		db.Find(&book.Subjects, book.SubjectIDs)
+	}
+
+	// Os autores são gravados pelos créditos, com papel e ordem
+	book.Authors = nil
+	var created models.Book
+	err = db.Transaction(func(tx *gorm.DB) error {
+		// Autores e assuntos dos metadados são encontrados pelo nome ou criados
+		if meta != nil && credits == nil {
+			resolved, err := importer.ResolveAuthors(tx, MetadataCredits(meta))
+			if err != nil {
+				return err
+			}
+			credits = resolved
+		}
+		if meta != nil && len(book.SubjectIDs) == 0 {
+			subjects, err := importer.ResolveSubjects(tx, meta.Subjects)
+			if err != nil {
+				return err
+			}
+			book.Subjects = subjects
+		}
+
+		if err := tx.Create(&book).Error; err != nil {
+			return err
+		}
+		if err := models.SetBookAuthors(tx, book.ID, credits); err != nil {
+			return err
+		}
+
+		created, err = models.LoadBook(tx, book.ID)
+		if err != nil {
+			return err
+		}
+		return events.Publish(tx, events.BookCreated{Book: created})
+	})
+	if err != nil {
+		return book, err
+	}
+	return created, nil
+}
+
+// UpdateBook atualiza os campos enviados do livro. Créditos (ou
+// author_ids) e subject_ids, quando enviados, substituem os atuais.
+// available é separado porque false também é um valor: nil mantém a
+// disponibilidade atual. Devolve o livro com autores e assuntos.
+func UpdateBook(db *gorm.DB, id uint, input models.Book, available *bool) (models.Book, error) {
+	var book models.Book
+
+	if err := db.First(&book, id).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return book, notFound("Book not found")
+		}
+		return book, err
+	}
+
+	if err := ValidateBook(&input); err != nil {
+		return book, err
+	}
+
+	credits, err := BookCredits(db, input)
+	if err != nil {
+		return book, err
+	}
+
+	// Mudança de série ou de volume
+	if input.SeriesID != nil || input.SeriesVolume != 0 {
+		if input.SeriesID == nil {
+			input.SeriesID = book.SeriesID
+		}
+		if input.SeriesID == nil {
+			return book, invalid("Book is not part of a series")
+		}
+		if err := CheckSeriesVolume(db, *input.SeriesID, input.SeriesVolume, book.ID); err != nil {
+			return book, err
+		}
+	}
+
+	// Assuntos informados em SubjectIDs substituem os atuais
+	var subjects []models.Subject
+	if len(input.SubjectIDs) > 0 {
+		db.Find(&subjects, input.SubjectIDs)
+	}
+
+	var updated models.Book
+	err = db.Transaction(func(tx *gorm.DB) error {
+		// Atualiza dados básicos
+		err := tx.Model(&book).Updates(models.Book{
+			Title:           input.Title,
+			ISBN:            input.ISBN,
+			Publisher:       input.Publisher,
+			PublicationYear: input.PublicationYear,
+			Edition:         input.Edition,
+			Language:        input.Language,
+			Pages:           input.Pages,
+			Description:     input.Description,
+			CoverURL:        input.CoverURL,
+			SeriesID:        input.SeriesID,
+			SeriesVolume:    input.SeriesVolume,
+		}).Error
+		if err != nil {
+			return err
+		}
+		if available != nil {
+			if err := tx.Model(&book).Update("available", *available).Error; err != nil {
+				return err
+			}
+		}
+
+		// Atualiza autores se credits ou author_ids foi enviado
+		if credits != nil {
+			if err := models.SetBookAuthors(tx, book.ID, credits); err != nil {
+				return err
+			}
+		}
+
+		if len(input.SubjectIDs) > 0 {
+			if err := tx.Model(&book).Association("Subjects").Replace(&subjects); err != nil {
+				return err
+			}
+		}
+
+		updated, err = models.LoadBook(tx, book.ID)
+		if err != nil {
+			return err
+		}
+		return events.Publish(tx, events.BookUpdated{Book: updated})
+	})
+	if err != nil {
+		return book, err
+	}
+	return updated, nil
+}
+
+// DeleteBook remove o livro
+func DeleteBook(db *gorm.DB, id uint) error {
+	var book models.Book
+
+	if err := db.First(&book, id).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return notFound("Book not found")
+		}
+		return err
+	}
+
+	return db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Delete(&book).Error; err != nil {
+			return err
+		}
+		return events.Publish(tx, events.BookDeleted{Book: book})
+	})
+}
+
+// MetadataCredits converte os autores dos metadados para a importação
+func MetadataCredits(meta *metadata.Metadata) []importer.Credit {
+	credits := make([]importer.Credit, 0, len(meta.Authors))
+	for _, name := range meta.Authors {
+		credits = append(credits, importer.Credit{Name: name, Role: models.RoleAuthor})
+	}
+	return credits
+}
@@ -0,0 +1,129 @@
+package service
+
+import (
+	"errors"
+	"library-api/internal/events"
+	"library-api/internal/models"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// CreateLoan empresta o livro, se estiver disponível; um livro já
+// emprestado é Conflict. O prazo padrão é models.LoanPeriod; um due_date
+// informado precisa estar no futuro.
+func CreateLoan(db *gorm.DB, loan models.Loan) (models.Loan, error) {
+	// Verifica se o livro existe
+	var book models.Book
+	if err := db.First(&book, loan.BookID).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return loan, notFound("Book not found")
+		}
+		return loan, err
+	}
+
+	// Define data do empréstimo e o prazo de devolução
+	loan.LoanDate = time.Now()
+	if loan.DueDate.IsZero() {
+		loan.DueDate = loan.LoanDate.Add(models.LoanPeriod)
+	} else if !loan.DueDate.After(loan.LoanDate) {
+		return loan, invalid("due_date must be in the future")
+	}
+
+	// Marca livro como indisponível e registra o empréstimo, com o evento,
+	// na mesma transação. O UPDATE condicional é a verificação da
+	// disponibilidade: de dois empréstimos simultâneos, só um muda a linha.
+	err := db.Transaction(func(tx *gorm.DB) error {
+		result := tx.Model(&models.Book{}).Where("id = ? AND available = ?", book.ID, true).
+			Update("available", false)
+		if result.Error != nil {
+			return result.Error
+		}
+		if result.RowsAffected == 0 {
+			return conflict("Book is not available")
+		}
+		if err := tx.Omit("Book").Create(&loan).Error; err != nil {
+			return err
+		}
+		if err := tx.First(&book, book.ID).Error; err != nil {
+			return err
+		}
+		loan.Book = book
+		return events.Publish(tx, events.LoanCreated{Loan: loan})
+	})
+	return loan, err
+}
+
+// ReturnLoan registra a devolução e libera o livro. Um empréstimo já
+// devolvido é Invalid; se outra devolução ganhar a corrida, Conflict.
+func ReturnLoan(db *gorm.DB, id uint) (models.Loan, error) {
+	var loan models.Loan
+
+	if err := db.Preload("Book").First(&loan, id).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return loan, notFound("Loan not found")
+		}
+		return loan, err
+	}
+
+	// Se já foi devolvido
+	if loan.ReturnDate != nil {
+		return loan, invalid("Book already returned")
+	}
+
+	// Atualiza data de devolução e marca livro como disponível novamente. O
+	// UPDATE condicional garante que só uma de duas devoluções simultâneas
+	// libere o livro e publique loan.returned.
+	now := time.Now()
+	err := db.Transaction(func(tx *gorm.DB) error {
+		result := tx.Model(&models.Loan{}).Where("id = ? AND return_date IS NULL", loan.ID).
+			Update("return_date", now)
+		if result.Error != nil {
+			return result.Error
+		}
+		if result.RowsAffected == 0 {
+			return conflict("Book already returned")
+		}
+		loan.ReturnDate = &now
+		loan.Book.Available = true
+		if err := tx.Save(&loan.Book).Error; err != nil {
+			return err
+		}
+		late := !loan.DueDate.IsZero() && now.After(loan.DueDate)
+		return events.Publish(tx, events.LoanReturned{Loan: loan, Late: late})
+	})
+	return loan, err
+}
+
+// DeleteLoan remove o empréstimo; se ainda estava em aberto, libera o livro
+func DeleteLoan(db *gorm.DB, id uint) error {
+	var loan models.Loan
+
+	if err := db.Preload("Book").First(&loan, id).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return notFound("Loan not found")
+		}
+		return err
+	}
+
+	// O livro liberado gera book.updated na mesma transação, para que o
+	// stream e os webhooks saibam que ele voltou a ficar disponível
+	return db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Delete(&loan).Error; err != nil {
+			return err
+		}
+		if loan.ReturnDate != nil {
+			return nil
+		}
+
+		loan.Book.Available = true
+		if err := tx.Save(&loan.Book).Error; err != nil {
+			return err
+		}
+		book, err := models.LoadBook(tx, loan.BookID)
+		if err != nil {
+			return err
+		}
+		return events.Publish(tx, events.BookUpdated{Book: book})
+	})
+}
@@ -0,0 +1,160 @@
+package service
+
+import (
+	"library-api/internal/models"
+	"path/filepath"
+	"sync"
+	"testing"
+	"time"
+
+	"gorm.io/driver/sqlite"
+	"gorm.io/gorm"
+	"gorm.io/gorm/logger"
+)
+
+func openService(t *testing.T) *gorm.DB {
+	t.Helper()
+	db, err := gorm.Open(sqlite.Open(filepath.Join(t.TempDir(), "service.db")), &gorm.Config{Logger: logger.Discard})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := db.AutoMigrate(&models.Book{}, &models.Loan{}, &models.OutboxEvent{}); err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() {
+		sqlDB, _ := db.DB()
+		sqlDB.Close()
+	})
+	return db
+}
+
+func TestCreateLoanConcurrent(t *testing.T) {
+	db := openService(t)
+	book := models.Book{Title: "Dom Casmurro", Available: true}
+	db.Create(&book)
+
+	const requests = 8
+	errs := make([]error, requests)
+	var wg sync.WaitGroup
+	for i := range requests {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			_, errs[i] = CreateLoan(db, models.Loan{BookID: book.ID, UserName: "leitor"})
+		}()
+	}
+	wg.Wait()
+
+	created := 0
+	for _, err := range errs {
+		switch {
+		case err == nil:
+			created++
+		case KindOf(err) != Conflict:
+			t.Errorf("unexpected error: %v", err)
+		}
+	}
+	if created != 1 {
+		t.Errorf("%d loans created, want 1", created)
+	}
+
+	var loans int64
+	db.Model(&models.Loan{}).Count(&loans)
+	if loans != 1 {
+		t.Errorf("%d loans stored, want 1", loans)
+	}
+}
+
+func TestCreateLoanUnavailable(t *testing.T) {
+	db := openService(t)
+	book := models.Book{Title: "Duna", Available: true}
+	db.Create(&book)
+
+	loan, err := CreateLoan(db, models.Loan{BookID: book.ID, UserName: "ana"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if loan.Book.Available {
+		t.Error("loan.book.available = true, want false")
+	}
+
+	if _, err := CreateLoan(db, models.Loan{BookID: book.ID, UserName: "bia"}); KindOf(err) != Conflict {
+		t.Errorf("second loan: err = %v, want Conflict", err)
+	}
+	if _, err := CreateLoan(db, models.Loan{BookID: book.ID + 1, UserName: "bia"}); KindOf(err) != NotFound {
+		t.Errorf("missing book: err = %v, want NotFound", err)
+	}
+}
+
+func TestReturnLoanConcurrent(t *testing.T) {
+	db := openService(t)
+	book := models.Book{Title: "Dom Casmurro", Available: true}
+	db.Create(&book)
+	loan, err := CreateLoan(db, models.Loan{BookID: book.ID, UserName: "leitor"})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	const requests = 8
+	errs := make([]error, requests)
+	var wg sync.WaitGroup
+	for i := range requests {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			_, errs[i] = ReturnLoan(db, loan.ID)
+		}()
+	}
+	wg.Wait()
+
+	returned := 0
+	for _, err := range errs {
+		switch {
+		case err == nil:
+			returned++
+		case KindOf(err) != Conflict && KindOf(err) != Invalid:
+			t.Errorf("unexpected error: %v", err)
+		}
+	}
+	if returned != 1 {
+		t.Errorf("%d returns succeeded, want 1", returned)
+	}
+
+	var published int64
+	db.Model(&models.OutboxEvent{}).Where("type = ?", "loan.returned").Count(&published)
+	if published != 1 {
+		t.Errorf("%d loan.returned events, want 1", published)
+	}
+
+	if _, err := ReturnLoan(db, loan.ID); KindOf(err) != Invalid {
+		t.Errorf("second return: err = %v, want Invalid", err)
+	}
+}
+
+// O SQLite serializa as escritas e raramente reproduz a corrida; aqui outra
+// devolução é gravada logo depois da leitura do empréstimo
+func TestReturnLoanLosesRace(t *testing.T) {
+	db := openService(t)
+	book := models.Book{Title: "Duna", Available: true}
+	db.Create(&book)
+	loan, err := CreateLoan(db, models.Loan{BookID: book.ID, UserName: "ana"})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var once sync.Once
+	db.Callback().Query().After("gorm:query").Register("test:concurrent_return", func(d *gorm.DB) {
+		once.Do(func() {
+			d.Session(&gorm.Session{NewDB: true}).Exec("UPDATE loans SET return_date = ? WHERE id = ?", time.Now(), loan.ID)
+		})
+	})
+
+	if _, err := ReturnLoan(db, loan.ID); KindOf(err) != Conflict {
+		t.Errorf("err = %v, want Conflict", err)
+	}
+	var published int64
+	db.Model(&models.OutboxEvent{}).Where("type = ?", "loan.returned").Count(&published)
+	if published != 0 {
+		t.Errorf("%d loan.returned events, want 0", published)
+	}
+}
@@ -0,0 +1,225 @@
+package service
+
+import (
+	"errors"
+	"library-api/internal/models"
+	"strings"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// BookFilter são os filtros da listagem de livros; campos vazios não
+// filtram
+type BookFilter struct {
+	Title     string // parte do título
+	ISBN      string
+	Available *bool
+	AuthorID  uint
+	Author    string // parte do nome do autor
+	Publisher string // parte do nome da editora
+	Language  string // código ISO 639
+	Year      *int
+	YearFrom  *int
+	YearTo    *int
+	SubjectID uint
+	Subject   string // parte do nome do assunto
+	SeriesID  uint
+}
+
+// FilterBooks aplica os filtros à consulta de livros
+func FilterBooks(db *gorm.DB, f BookFilter) (*gorm.DB, error) {
+	query := db
+	if f.Title != "" {
+		query = query.Where("books.title LIKE ?", "%"+f.Title+"%")
+	}
+	if f.ISBN != "" {
+		query = query.Where("books.isbn = ?", f.ISBN)
+	}
+	if f.Available != nil {
+		query = query.Where("books.available = ?", *f.Available)
+	}
+	if f.AuthorID != 0 {
+		query = query.Where("books.id IN (?)", db.Table("book_authors").
+			Select("book_id").Where("author_id = ?", f.AuthorID))
+	}
+	if f.Author != "" {
+		query = query.Where("books.id IN (?)", db.Table("book_authors").
+			Select("book_authors.book_id").
+			Joins("JOIN authors ON authors.id = book_authors.author_id AND authors.deleted_at IS NULL").
+			Where("authors.name LIKE ?", "%"+f.Author+"%"))
+	}
+	if f.Publisher != "" {
+		query = query.Where("books.publisher LIKE ?", "%"+f.Publisher+"%")
+	}
+	if f.Language != "" {
+		language, ok := models.NormalizeLanguage(f.Language)
+		if !ok {
+			return nil, invalid("Invalid language code")
+		}
+		query = query.Where("books.language = ?", language)
+	}
+	if f.Year != nil {
+		query = query.Where("books.publication_year = ?", *f.Year)
+	}
+	if f.YearFrom != nil {
+		query = query.Where("books.publication_year >= ?", *f.YearFrom)
+	}
+	if f.YearTo != nil {
+		query = query.Where("books.publication_year <= ?", *f.YearTo)
+	}
+	if f.SubjectID != 0 {
+		query = query.Where("books.id IN (?)", db.Table("book_subjects").
+			Select("book_id").Where("subject_id = ?", f.SubjectID))
+	}
+	if f.Subject != "" {
+		query = query.Where("books.id IN (?)", db.Table("book_subjects").
+			Select("book_subjects.book_id").
+			Joins("JOIN subjects ON subjects.id = book_subjects.subject_id").
+			Where("subjects.name LIKE ?", "%"+f.Subject+"%"))
+	}
+	if f.SeriesID != 0 {
+		query = query.Where("books.series_id = ?", f.SeriesID)
+	}
+	return query, nil
+}
+
+// ListBooks lista os livros que passam nos filtros
+func ListBooks(db *gorm.DB, f BookFilter) ([]models.Book, error) {
+	query, err := FilterBooks(db, f)
+	if err != nil {
+		return nil, err
+	}
+
+	var books []models.Book
+	err = query.Find(&books).Error
+	return books, err
+}
+
+// GetBook busca o livro com autores (com papel e posição), assuntos e série
+func GetBook(db *gorm.DB, id uint) (models.Book, error) {
+	var book models.Book
+
+	if err := db.Preload("Authors").Preload("Subjects").Preload("Series").First(&book, id).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return book, notFound("Book not found")
+		}
+		return book, err
+	}
+
+	models.FillAuthorRoles(db, &book)
+	return book, nil
+}
+
+// AuthorFilter são os filtros da listagem de autores
+type AuthorFilter struct {
+	Query       string // parte do nome, da forma de ordenação ou de um alias
+	Nationality string
+}
+
+// ListAuthors lista os autores que passam nos filtros, com livros e aliases
+func ListAuthors(db *gorm.DB, f AuthorFilter) ([]models.Author, error) {
+	query := db.Preload("Books").Preload("Aliases")
+
+	if q := strings.TrimSpace(f.Query); q != "" {
+		pattern := "%" + strings.ToLower(q) + "%"
+		query = query.Where("LOWER(name) LIKE ? OR LOWER(sort_name) LIKE ? OR id IN (?)", pattern, pattern,
+			db.Model(&models.AuthorAlias{}).Select("author_id").Where("LOWER(name) LIKE ?", pattern))
+	}
+	if f.Nationality != "" {
+		query = query.Where("LOWER(nationality) = ?", strings.ToLower(f.Nationality))
+	}
+
+	var authors []models.Author
+	if err := query.Find(&authors).Error; err != nil {
+		return nil, err
+	}
+
+	refs := make([]*models.Author, len(authors))
+	for i := range authors {
+		refs[i] = &authors[i]
+	}
+	models.FillBookRoles(db, refs...)
+	return authors, nil
+}
+
+// GetAuthor busca o autor com livros (com o papel em cada um) e aliases
+func GetAuthor(db *gorm.DB, id uint) (models.Author, error) {
+	var author models.Author
+
+	if err := db.Preload("Books").Preload("Aliases").First(&author, id).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return author, notFound("Author not found")
+		}
+		return author, err
+	}
+
+	models.FillBookRoles(db, &author)
+	return author, nil
+}
+
+// Situações de um empréstimo, para LoanFilter
+const (
+	LoanActive   = "active"   // em aberto
+	LoanReturned = "returned" // devolvido
+	LoanOverdue  = "overdue"  // em aberto e atrasado
+)
+
+// LoanFilter são os filtros da listagem de empréstimos
+type LoanFilter struct {
+	UserName string // sem diferenciar maiúsculas
+	Status   string // LoanActive, LoanReturned ou LoanOverdue
+}
+
+// ListLoans lista os empréstimos que passam nos filtros, com o livro e os
+// autores, marcando os atrasados
+func ListLoans(db *gorm.DB, f LoanFilter) ([]models.Loan, error) {
+	query := db.Preload("Book.Authors")
+
+	if userName := strings.TrimSpace(f.UserName); userName != "" {
+		query = query.Where("LOWER(user_name) = ?", strings.ToLower(userName))
+	}
+
+	now := time.Now()
+	switch f.Status {
+	case "":
+	case LoanActive:
+		query = query.Where("return_date IS NULL")
+	case LoanReturned:
+		query = query.Where("return_date IS NOT NULL")
+	case LoanOverdue:
+		// julianday: o texto gravado traz o deslocamento do fuso
+		query = query.Where("return_date IS NULL AND julianday(due_date) < julianday(?)", now)
+	default:
+		return nil, invalid("status must be active, returned or overdue")
+	}
+
+	var loans []models.Loan
+	if err := query.Find(&loans).Error; err != nil {
+		return nil, err
+	}
+
+	books := make([]*models.Book, len(loans))
+	for i := range loans {
+		books[i] = &loans[i].Book
+		loans[i].Overdue = loans[i].IsOverdue(now)
+	}
+	models.FillAuthorRoles(db, books...)
+	return loans, nil
+}
+
+// GetLoan busca o empréstimo com o livro e os autores
+func GetLoan(db *gorm.DB, id uint) (models.Loan, error) {
+	var loan models.Loan
+
+	if err := db.Preload("Book.Authors").First(&loan, id).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return loan, notFound("Loan not found")
+		}
+		return loan, err
+	}
+
+	models.FillAuthorRoles(db, &loan.Book)
+	loan.Overdue = loan.IsOverdue(time.Now())
+	return loan, nil
+}
@@ -0,0 +1,49 @@
+// Package service reúne as regras de negócio de livros, autores e
+// empréstimos, compartilhadas pela API REST, pelo GraphQL e pelo gRPC.
+// Cada função recebe a conexão (já com o contexto da requisição) e devolve
+// um *Error quando a operação é recusada.
+package service
+
+import "errors"
+
+// Kind classifica os erros de regra de negócio, para que cada API os
+// traduza no seu próprio código (status HTTP, código do GraphQL...)
+type Kind int
+
+const (
+	Invalid  Kind = iota + 1 // dados recusados
+	NotFound                 // registro não existe
+	Conflict                 // conflita com outro registro
+)
+
+// Error é uma operação recusada por uma regra de negócio
+type Error struct {
+	Kind    Kind
+	Message string
+}
+
+func (e *Error) Error() string {
+	return e.Message
+}
+
+func invalid(message string) error {
+	return &Error{Kind: Invalid, Message: message}
+}
+
+func notFound(message string) error {
+	return &Error{Kind: NotFound, Message: message}
+}
+
+func conflict(message string) error {
+	return &Error{Kind: Conflict, Message: message}
+}
+
+// KindOf devolve o tipo do erro; zero indica um erro inesperado, como uma
+// falha no banco
+func KindOf(err error) Kind {
+	var e *Error
+	if errors.As(err, &e) {
+		return e.Kind
+	}
+	return 0
+}
@@ -0,0 +1,107 @@
+package service
+
+import (
+	"context"
+	"library-api/internal/covers"
+	"library-api/internal/models"
+	"library-api/internal/storage"
+	"log/slog"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// TrashRetention é por quanto tempo livros, autores e séries removidos
+// (soft delete) ficam na lixeira antes de serem apagados de vez
+var TrashRetention = 30 * 24 * time.Hour
+
+// PurgeTrash apaga de vez os livros, autores e séries removidos há mais de
+// TrashRetention, com as ligações e as capas, e devolve quantos registros
+// foram apagados. Livros citados por empréstimos ficam, para não quebrar o
+// histórico. Roda como o job "purge-trash"; files pode ser nil.
+func PurgeTrash(ctx context.Context, db *gorm.DB, files storage.Storage, now time.Time) (int64, error) {
+	// deleted_at é comparado por julianday: o texto gravado traz o fuso
+	cutoff := now.Add(-TrashRetention)
+	var purged int64
+	var coverKeys []string
+
+	err := db.Transaction(func(tx *gorm.DB) error {
+		var bookIDs []uint
+		if err := tx.Unscoped().Model(&models.Book{}).
+			Where("julianday(deleted_at) < julianday(?)", cutoff).
+			Where("id NOT IN (?)", tx.Model(&models.Loan{}).Select("book_id")).
+			Pluck("id", &bookIDs).Error; err != nil {
+			return err
+		}
+		if len(bookIDs) > 0 {
+			var bookCovers []models.BookCover
+			if err := tx.Where("book_id IN ?", bookIDs).Find(&bookCovers).Error; err != nil {
+				return err
+			}
+			for _, cover := range bookCovers {
+				coverKeys = append(coverKeys, covers.Keys(cover.BookID, cover.Extension)...)
+			}
+
+			for _, table := range []string{"book_authors", "book_subjects", "book_covers"} {
+				if err := tx.Table(table).Where("book_id IN ?", bookIDs).Delete(nil).Error; err != nil {
+					return err
+				}
+			}
+			result := tx.Unscoped().Delete(&models.Book{}, bookIDs)
+			if result.Error != nil {
+				return result.Error
+			}
+			purged += result.RowsAffected
+		}
+
+		var authorIDs []uint
+		if err := tx.Unscoped().Model(&models.Author{}).Where("julianday(deleted_at) < julianday(?)", cutoff).Pluck("id", &authorIDs).Error; err != nil {
+			return err
+		}
+		if len(authorIDs) > 0 {
+			if err := tx.Table("book_authors").Where("author_id IN ?", authorIDs).Delete(nil).Error; err != nil {
+				return err
+			}
+			if err := tx.Where("author_id IN ?", authorIDs).Delete(&models.AuthorAlias{}).Error; err != nil {
+				return err
+			}
+			result := tx.Unscoped().Delete(&models.Author{}, authorIDs)
+			if result.Error != nil {
+				return result.Error
+			}
+			purged += result.RowsAffected
+		}
+
+		var seriesIDs []uint
+		if err := tx.Unscoped().Model(&models.Series{}).Where("julianday(deleted_at) < julianday(?)", cutoff).Pluck("id", &seriesIDs).Error; err != nil {
+			return err
+		}
+		if len(seriesIDs) > 0 {
+			// Livros na lixeira ainda podem apontar para a série
+			if err := tx.Unscoped().Model(&models.Book{}).Where("series_id IN ?", seriesIDs).
+				Updates(map[string]interface{}{"series_id": nil, "series_volume": 0}).Error; err != nil {
+				return err
+			}
+			result := tx.Unscoped().Delete(&models.Series{}, seriesIDs)
+			if result.Error != nil {
+				return result.Error
+			}
+			purged += result.RowsAffected
+		}
+		return nil
+	})
+	if err != nil {
+		return 0, err
+	}
+
+	// Os arquivos só saem depois do commit; uma falha deixa o arquivo
+	// órfão, mas não o registro sem arquivo
+	if files != nil {
+		for _, key := range coverKeys {
+			if err := files.Delete(ctx, key); err != nil {
+				slog.Warn("Failed to delete cover file", "key", key, "error", err)
+			}
+		}
+	}
+	return purged, nil
+}
@@ -0,0 +1,37 @@
+package storage
+
+import (
+	"context"
+	"errors"
+	"io"
+	"os"
+	"time"
+)
+
+// ErrNotFound indica que não existe objeto com a chave pedida
+var ErrNotFound = errors.New("object not found")
+
+// Object é um arquivo lido do storage; Body deve ser fechado
+type Object struct {
+	Body        io.ReadCloser
+	Size        int64
+	ContentType string
+	ModTime     time.Time
+}
+
+// Storage guarda arquivos por chave, como "covers/1/small.jpg". A chave usa
+// sempre "/" como separador, para servir também a storages como o S3.
+type Storage interface {
+	Put(ctx context.Context, key string, r io.Reader, contentType string) error
+	Get(ctx context.Context, key string) (*Object, error)
+	Delete(ctx context.Context, key string) error
+}
+
+// FromEnv cria o storage local no diretório STORAGE_DIR (padrão "uploads")
+func FromEnv() (Storage, error) {
+	dir := os.Getenv("STORAGE_DIR")
+	if dir == "" {
+		dir = "uploads"
+	}
+	return NewLocal(dir)
+}
@@ -0,0 +1,278 @@
+package stream
+
+import (
+	"context"
+	"encoding/json"
+	"library-api/internal/events"
+	"log/slog"
+	"os"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+// Tipos enviados em GET /events. Os de empréstimo repetem os eventos de
+// domínio; book.availability é derivado deles e das mudanças nos livros.
+const (
+	TypeAvailability = "book.availability"
+	TypeLoanCreated  = events.TypeLoanCreated
+	TypeLoanReturned = events.TypeLoanReturned
+
+	// TypeReset avisa que Last-Event-ID não está mais no buffer: o cliente
+	// perdeu eventos e deve recarregar o estado (GET /books)
+	TypeReset = "reset"
+)
+
+// Types lista os tipos que podem ser filtrados
+var Types = []string{TypeAvailability, TypeLoanCreated, TypeLoanReturned}
+
+// DefaultBufferSize é quantos eventos ficam guardados para retomada
+const DefaultBufferSize = 1000
+
+// clientBuffer é quantos eventos um cliente pode ter pendentes; um cliente
+// mais lento que isso é desconectado e retoma pelo Last-Event-ID
+const clientBuffer = 64
+
+// Availability é o dado de book.availability
+type Availability struct {
+	BookID    uint   `json:"book_id"`
+	Title     string `json:"title"`
+	Available bool   `json:"available"`
+	Deleted   bool   `json:"deleted,omitempty"`
+}
+
+// Event é um evento do stream. O ID é "<início do processo>-<sequência>",
+// para que um ID de antes de um reinício não seja confundido com um novo.
+type Event struct {
+	ID   string          `json:"-"`
+	Type string          `json:"-"`
+	Data json.RawMessage `json:"-"`
+
+	seq uint64
+}
+
+// Broker guarda os últimos eventos e os repassa aos clientes conectados
+type Broker struct {
+	mu        sync.Mutex
+	boot      string
+	seq       uint64
+	buffer    []Event // circular, com no máximo size eventos
+	size      int
+	clients   map[*Client]bool
+	available map[uint]bool // última disponibilidade enviada por livro
+	closed    bool
+}
+
+// NewBroker cria um broker que guarda até size eventos
+func NewBroker(size int) *Broker {
+	if size <= 0 {
+		size = DefaultBufferSize
+	}
+	return &Broker{
+		boot:      strconv.FormatInt(time.Now().Unix(), 36),
+		size:      size,
+		clients:   map[*Client]bool{},
+		available: map[uint]bool{},
+	}
+}
+
+// Client é uma conexão em GET /events
+type Client struct {
+	C     chan Event // fechado quando o cliente é desconectado pelo broker
+	types map[string]bool
+}
+
+// Subscribe conecta um cliente aos tipos informados (todos, sem tipos).
+// Com lastEventID, devolve também os eventos guardados depois dele; se o
+// ID não estiver mais no buffer, a lista começa com um evento reset.
+func (b *Broker) Subscribe(lastEventID string, types ...string) (*Client, []Event) {
+	client := &Client{C: make(chan Event, clientBuffer), types: map[string]bool{}}
+	for _, t := range types {
+		client.types[t] = true
+	}
+
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	if b.closed {
+		close(client.C)
+		return client, nil
+	}
+	b.clients[client] = true
+
+	if lastEventID == "" {
+		return client, nil
+	}
+
+	var missed []Event
+	seq, ok := b.parseID(lastEventID)
+	if !ok || (len(b.buffer) > 0 && seq+1 < b.buffer[0].seq) || seq > b.seq {
+		missed = append(missed, Event{ID: b.id(b.seq), Type: TypeReset, Data: json.RawMessage(`{}`), seq: b.seq})
+		seq = b.seq
+	}
+	for _, event := range b.buffer {
+		if event.seq > seq && client.wants(event.Type) {
+			missed = append(missed, event)
+		}
+	}
+	return client, missed
+}
+
+// Unsubscribe desconecta o cliente
+func (b *Broker) Unsubscribe(client *Client) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	if b.clients[client] {
+		delete(b.clients, client)
+		close(client.C)
+	}
+}
+
+// Close desconecta todos os clientes; novos clientes são recusados. Usado
+// no desligamento, para que as conexões abertas não o segurem.
+func (b *Broker) Close() {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.closed = true
+	for client := range b.clients {
+		delete(b.clients, client)
+		close(client.C)
+	}
+}
+
+// Publish guarda o evento no buffer e o envia aos clientes interessados
+func (b *Broker) Publish(eventType string, data any) error {
+	raw, err := json.Marshal(data)
+	if err != nil {
+		return err
+	}
+
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	b.seq++
+	event := Event{ID: b.id(b.seq), Type: eventType, Data: raw, seq: b.seq}
+	if len(b.buffer) == b.size {
+		b.buffer = append(b.buffer[:0], b.buffer[1:]...)
+	}
+	b.buffer = append(b.buffer, event)
+
+	for client := range b.clients {
+		if !client.wants(eventType) {
+			continue
+		}
+		select {
+		case client.C <- event:
+		default:
+			// Cliente lento: desconecta para não segurar os demais
+			delete(b.clients, client)
+			close(client.C)
+		}
+	}
+	return nil
+}
+
+// Handle recebe os eventos de domínio (ver events.Subscribe) e os converte
+// em eventos do stream
+func (b *Broker) Handle(ctx context.Context, event events.Event) error {
+	switch event.Type {
+	case events.TypeLoanCreated:
+		var payload events.LoanCreated
+		if err := event.Decode(&payload); err != nil {
+			return err
+		}
+		if err := b.Publish(TypeLoanCreated, payload); err != nil {
+			return err
+		}
+		return b.availability(payload.Loan.Book.ID, payload.Loan.Book.Title, false, false)
+	case events.TypeLoanReturned:
+		var payload events.LoanReturned
+		if err := event.Decode(&payload); err != nil {
+			return err
+		}
+		if err := b.Publish(TypeLoanReturned, payload); err != nil {
+			return err
+		}
+		return b.availability(payload.Loan.Book.ID, payload.Loan.Book.Title, true, false)
+	case events.TypeBookCreated, events.TypeBookUpdated:
+		var payload events.BookUpdated
+		if err := event.Decode(&payload); err != nil {
+			return err
+		}
+		return b.availability(payload.Book.ID, payload.Book.Title, payload.Book.Available, false)
+	case events.TypeBookDeleted:
+		var payload events.BookDeleted
+		if err := event.Decode(&payload); err != nil {
+			return err
+		}
+		return b.availability(payload.Book.ID, payload.Book.Title, false, true)
+	}
+	return nil
+}
+
+// SourceTypes são os eventos de domínio que Handle usa
+var SourceTypes = []string{
+	events.TypeLoanCreated, events.TypeLoanReturned,
+	events.TypeBookCreated, events.TypeBookUpdated, events.TypeBookDeleted,
+}
+
+// availability publica book.availability se a disponibilidade do livro
+// mudou desde o último evento (ou se o livro ainda não apareceu)
+func (b *Broker) availability(bookID uint, title string, available, deleted bool) error {
+	b.mu.Lock()
+	last, seen := b.available[bookID]
+	if deleted {
+		delete(b.available, bookID)
+	} else {
+		b.available[bookID] = available
+	}
+	b.mu.Unlock()
+
+	if seen && last == available && !deleted {
+		return nil
+	}
+	return b.Publish(TypeAvailability, Availability{BookID: bookID, Title: title, Available: available, Deleted: deleted})
+}
+
+func (b *Broker) id(seq uint64) string {
+	return b.boot + "-" + strconv.FormatUint(seq, 10)
+}
+
+// parseID lê a sequência de um ID deste processo
+func (b *Broker) parseID(id string) (uint64, bool) {
+	boot, seq, ok := strings.Cut(id, "-")
+	if !ok || boot != b.boot {
+		return 0, false
+	}
+	n, err := strconv.ParseUint(seq, 10, 64)
+	return n, err == nil
+}
+
+func (c *Client) wants(eventType string) bool {
+	return len(c.types) == 0 || c.types[eventType] || eventType == TypeReset
+}
+
+// ValidType informa se o tipo pode ser usado no filtro
+func ValidType(eventType string) bool {
+	for _, t := range Types {
+		if t == eventType {
+			return true
+		}
+	}
+	return false
+}
+
+// FromEnv cria o broker com SSE_BUFFER_SIZE eventos guardados (padrão 1000)
+func FromEnv() *Broker {
+	size := DefaultBufferSize
+	if v := os.Getenv("SSE_BUFFER_SIZE"); v != "" {
+		n, err := strconv.Atoi(v)
+		if err != nil || n <= 0 {
+			slog.Warn("Ignoring invalid SSE_BUFFER_SIZE", "value", v)
+		} else {
+			size = n
+		}
+	}
+	return NewBroker(size)
+}
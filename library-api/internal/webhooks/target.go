@@ -0,0 +1,70 @@
+package webhooks
+
+import (
+	"errors"
+	"net"
+	"net/http"
+	"net/netip"
+	"os"
+	"strconv"
+	"strings"
+	"syscall"
+)
+
+// AllowPrivateTargets libera webhooks para loopback, link-local e redes
+// privadas. Fica desligado por padrão, para que um webhook não faça o
+// servidor chamar serviços internos, como o 169.254.169.254 das nuvens;
+// WEBHOOK_ALLOW_PRIVATE=true liga, para desenvolvimento e redes fechadas.
+var AllowPrivateTargets, _ = strconv.ParseBool(os.Getenv("WEBHOOK_ALLOW_PRIVATE"))
+
+// ErrPrivateTarget indica um destino em loopback, link-local ou rede privada
+var ErrPrivateTarget = errors.New("url must not point to a loopback, link-local or private address")
+
+// CheckHost recusa os hosts que já se sabem internos: IPs de loopback,
+// link-local ou privados e "localhost". Nomes que resolvem para esses
+// endereços são barrados na conexão, pelo cliente do Worker.
+func CheckHost(host string) error {
+	if AllowPrivateTargets {
+		return nil
+	}
+	host = strings.ToLower(strings.TrimSuffix(host, "."))
+	if host == "localhost" || strings.HasSuffix(host, ".localhost") {
+		return ErrPrivateTarget
+	}
+	if addr, err := netip.ParseAddr(strings.Trim(host, "[]")); err == nil && private(addr) {
+		return ErrPrivateTarget
+	}
+	return nil
+}
+
+func private(addr netip.Addr) bool {
+	addr = addr.Unmap()
+	return addr.IsLoopback() || addr.IsPrivate() || addr.IsUnspecified() ||
+		addr.IsLinkLocalUnicast() || addr.IsLinkLocalMulticast() || addr.IsInterfaceLocalMulticast()
+}
+
+// newClient cria o cliente HTTP das entregas. O endereço é conferido na
+// hora de conectar, depois da resolução do nome, o que cobre nomes que
+// apontam para a rede interna e os redirecionamentos.
+func newClient() *http.Client {
+	dialer := &net.Dialer{
+		Timeout: requestTimeout,
+		Control: func(network, address string, _ syscall.RawConn) error {
+			if AllowPrivateTargets {
+				return nil
+			}
+			addrPort, err := netip.ParseAddrPort(address)
+			if err != nil || private(addrPort.Addr()) {
+				return ErrPrivateTarget
+			}
+			return nil
+		},
+	}
+
+	// Sem proxy, para que o endereço conferido seja o do destino
+	transport := http.DefaultTransport.(*http.Transport).Clone()
+	transport.Proxy = nil
+	transport.DialContext = dialer.DialContext
+
+	return &http.Client{Timeout: requestTimeout, Transport: transport}
+}
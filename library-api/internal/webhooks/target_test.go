@@ -0,0 +1,70 @@
+package webhooks
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestCheckHost(t *testing.T) {
+	tests := []struct {
+		host string
+		want error
+	}{
+		{"example.com", nil},
+		{"hooks.example.com", nil},
+		{"93.184.216.34", nil},
+		{"2606:2800:220:1:248:1893:25c8:1946", nil},
+		{"localhost", ErrPrivateTarget},
+		{"LOCALHOST.", ErrPrivateTarget},
+		{"api.localhost", ErrPrivateTarget},
+		{"127.0.0.1", ErrPrivateTarget},
+		{"127.1.2.3", ErrPrivateTarget},
+		{"::1", ErrPrivateTarget},
+		{"[::1]", ErrPrivateTarget},
+		{"0.0.0.0", ErrPrivateTarget},
+		{"169.254.169.254", ErrPrivateTarget},
+		{"fe80::1", ErrPrivateTarget},
+		{"10.0.0.5", ErrPrivateTarget},
+		{"172.16.0.1", ErrPrivateTarget},
+		{"192.168.1.10", ErrPrivateTarget},
+		{"fd00::1", ErrPrivateTarget},
+		{"::ffff:127.0.0.1", ErrPrivateTarget},
+	}
+	for _, tt := range tests {
+		if err := CheckHost(tt.host); !errors.Is(err, tt.want) {
+			t.Errorf("CheckHost(%q) = %v, want %v", tt.host, err, tt.want)
+		}
+	}
+}
+
+func TestCheckHostAllowPrivate(t *testing.T) {
+	allow := AllowPrivateTargets
+	AllowPrivateTargets = true
+	t.Cleanup(func() { AllowPrivateTargets = allow })
+
+	if err := CheckHost("127.0.0.1"); err != nil {
+		t.Errorf("CheckHost = %v with private targets allowed", err)
+	}
+}
+
+// Um nome que resolve para a rede interna passa pela validação da URL, mas
+// o cliente recusa a conexão
+func TestClientRefusesPrivateAddress(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {}))
+	defer srv.Close()
+
+	if _, err := newClient().Get(srv.URL); !errors.Is(err, ErrPrivateTarget) {
+		t.Errorf("err = %v, want ErrPrivateTarget", err)
+	}
+
+	allow := AllowPrivateTargets
+	AllowPrivateTargets = true
+	t.Cleanup(func() { AllowPrivateTargets = allow })
+	resp, err := newClient().Get(srv.URL)
+	if err != nil {
+		t.Fatal(err)
+	}
+	resp.Body.Close()
+}
@@ -0,0 +1,97 @@
+package webhooks
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"encoding/json"
+	"library-api/internal/events"
+	"library-api/internal/models"
+	"strings"
+	"time"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// ValidFilter aceita um tipo de evento, "*" ou um grupo como "loan.*"
+func ValidFilter(filter string) bool {
+	if filter == "*" {
+		return true
+	}
+	for _, event := range events.Types {
+		if filter == event || filter == group(event)+".*" {
+			return true
+		}
+	}
+	return false
+}
+
+// Matches informa se o evento passa pelos filtros; sem filtros, passa
+func Matches(filters []string, event string) bool {
+	if len(filters) == 0 {
+		return true
+	}
+	for _, filter := range filters {
+		if filter == "*" || filter == event || filter == group(event)+".*" {
+			return true
+		}
+	}
+	return false
+}
+
+func group(event string) string {
+	prefix, _, _ := strings.Cut(event, ".")
+	return prefix
+}
+
+// Enqueue cria uma entrega pendente do evento para cada webhook ativo que
+// o assina. O envio fica com o Worker. Chamado de novo para o mesmo
+// evento, não duplica as entregas.
+func Enqueue(db *gorm.DB, event events.Event) error {
+	var hooks []models.Webhook
+	if err := db.Where("active = ?", true).Find(&hooks).Error; err != nil {
+		return err
+	}
+
+	var deliveries []models.WebhookDelivery
+	var payload []byte
+	for _, hook := range hooks {
+		if !Matches(hook.Events, event.Type) {
+			continue
+		}
+		if payload == nil {
+			var err error
+			if payload, err = json.Marshal(event); err != nil {
+				return err
+			}
+		}
+		deliveries = append(deliveries, models.WebhookDelivery{
+			WebhookID:     hook.ID,
+			EventID:       event.ID,
+			Event:         event.Type,
+			Payload:       string(payload),
+			Status:        models.DeliveryPending,
+			NextAttemptAt: time.Now(),
+		})
+	}
+	if len(deliveries) == 0 {
+		return nil
+	}
+	return db.Clauses(clause.OnConflict{DoNothing: true}).Create(&deliveries).Error
+}
+
+// Subscriber é o assinante dos eventos de domínio que os enfileira para
+// os webhooks
+func Subscriber(db *gorm.DB) events.Handler {
+	return func(ctx context.Context, event events.Event) error {
+		return Enqueue(db.WithContext(ctx), event)
+	}
+}
+
+// NewSecret gera a chave de assinatura de um webhook
+func NewSecret() string {
+	b := make([]byte, 32)
+	rand.Read(b)
+	return "whsec_" + hex.EncodeToString(b)
+}
@@ -0,0 +1,200 @@
+package webhooks
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"library-api/internal/models"
+	"log/slog"
+	"net/http"
+	"strconv"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// Parâmetros da fila de entregas
+const (
+	// MaxAttempts é o número de tentativas antes de a entrega falhar
+	MaxAttempts = 8
+
+	pollInterval   = 2 * time.Second
+	batchSize      = 20
+	requestTimeout = 10 * time.Second
+	retryBase      = 30 * time.Second
+	retryMax       = time.Hour
+	maxErrorLength = 500
+	leaseTime      = time.Minute // posse de uma entrega por uma instância
+)
+
+// Backoff é a espera antes da próxima tentativa: 30s, 1min, 2min... até 1h
+func Backoff(attempts int) time.Duration {
+	wait := retryBase << (attempts - 1)
+	if wait <= 0 || wait > retryMax {
+		return retryMax
+	}
+	return wait
+}
+
+// Worker envia as entregas pendentes
+type Worker struct {
+	db     *gorm.DB
+	client *http.Client
+}
+
+// Start inicia o worker em segundo plano. A função devolvida o para e
+// espera a entrega em andamento terminar.
+func Start(db *gorm.DB) func() {
+	w := &Worker{db: db, client: newClient()}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		w.run(ctx)
+	}()
+
+	return func() {
+		cancel()
+		<-done
+	}
+}
+
+func (w *Worker) run(ctx context.Context) {
+	ticker := time.NewTicker(pollInterval)
+	defer ticker.Stop()
+
+	for {
+		w.deliverPending(ctx)
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// deliverPending tenta as entregas vencidas dos webhooks ativos e as que
+// ficaram com uma instância que caiu no meio do envio
+func (w *Worker) deliverPending(ctx context.Context) {
+	var deliveries []models.WebhookDelivery
+	err := w.db.Joins("JOIN webhooks ON webhooks.id = webhook_deliveries.webhook_id AND webhooks.active = ?", true).
+		Where("(webhook_deliveries.status = ? AND julianday(webhook_deliveries.next_attempt_at) <= julianday(?)) OR (webhook_deliveries.status = ? AND julianday(webhook_deliveries.locked_until) < julianday(?))",
+			models.DeliveryPending, time.Now(), models.DeliveryProcessing, time.Now()).
+		Order("julianday(webhook_deliveries.next_attempt_at)").Limit(batchSize).
+		Find(&deliveries).Error
+	if err != nil {
+		slog.Error("Failed to load webhook deliveries", "error", err)
+		return
+	}
+
+	hooks := map[uint]models.Webhook{}
+	for _, delivery := range deliveries {
+		if ctx.Err() != nil {
+			return
+		}
+		hook, ok := hooks[delivery.WebhookID]
+		if !ok {
+			if err := w.db.First(&hook, delivery.WebhookID).Error; err != nil {
+				continue
+			}
+			hooks[hook.ID] = hook
+		}
+		claimed, err := w.claim(delivery.ID)
+		if err != nil {
+			slog.Error("Failed to claim webhook delivery", "delivery_id", delivery.ID, "error", err)
+			continue
+		}
+		if claimed {
+			w.deliver(ctx, hook, &delivery)
+		}
+	}
+}
+
+// claim pega a entrega por leaseTime, para que as outras réplicas não a
+// enviem ao mesmo tempo. Devolve false se outra instância chegou antes.
+func (w *Worker) claim(id uint) (bool, error) {
+	now := time.Now()
+	result := w.db.Model(&models.WebhookDelivery{}).
+		Where("id = ? AND ((status = ? AND julianday(next_attempt_at) <= julianday(?)) OR (status = ? AND julianday(locked_until) < julianday(?)))",
+			id, models.DeliveryPending, now, models.DeliveryProcessing, now).
+		Updates(map[string]interface{}{"status": models.DeliveryProcessing, "locked_until": now.Add(leaseTime)})
+	return result.RowsAffected == 1, result.Error
+}
+
+// deliver faz uma tentativa e grava o resultado na entrega. Um envio
+// cortado pelo desligamento não conta como tentativa: a entrega volta para
+// a fila como estava.
+func (w *Worker) deliver(ctx context.Context, hook models.Webhook, delivery *models.WebhookDelivery) {
+	now := time.Now()
+	status, err := w.send(ctx, hook, delivery, now)
+
+	if err != nil && ctx.Err() != nil {
+		err := w.db.Model(delivery).Updates(map[string]interface{}{"status": models.DeliveryPending, "locked_until": nil}).Error
+		if err != nil {
+			slog.Error("Failed to release webhook delivery", "delivery_id", delivery.ID, "error", err)
+		}
+		return
+	}
+
+	delivery.Attempts++
+	delivery.LastAttemptAt = &now
+	delivery.ResponseStatus = status
+	delivery.Status = models.DeliveryPending
+	delivery.LockedUntil = nil
+	if err == nil {
+		delivery.Status = models.DeliverySucceeded
+		delivery.DeliveredAt = &now
+		delivery.Error = ""
+	} else {
+		delivery.Error = truncate(err.Error(), maxErrorLength)
+		if delivery.Attempts >= MaxAttempts {
+			delivery.Status = models.DeliveryFailed
+		} else {
+			delivery.NextAttemptAt = now.Add(Backoff(delivery.Attempts))
+		}
+		slog.Warn("Webhook delivery failed", "webhook_id", hook.ID, "delivery_id", delivery.ID,
+			"event", delivery.Event, "attempts", delivery.Attempts, "status", delivery.Status, "error", err)
+	}
+
+	if err := w.db.Save(delivery).Error; err != nil {
+		slog.Error("Failed to save webhook delivery", "delivery_id", delivery.ID, "error", err)
+	}
+}
+
+// send faz o POST assinado e devolve o status HTTP da resposta
+func (w *Worker) send(ctx context.Context, hook models.Webhook, delivery *models.WebhookDelivery, now time.Time) (int, error) {
+	body := []byte(delivery.Payload)
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, hook.URL, bytes.NewReader(body))
+	if err != nil {
+		return 0, err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("User-Agent", "library-api-webhooks")
+	req.Header.Set(HeaderEvent, delivery.Event)
+	req.Header.Set(HeaderEventID, delivery.EventID)
+	req.Header.Set(HeaderDelivery, strconv.FormatUint(uint64(delivery.ID), 10))
+	req.Header.Set(HeaderTimestamp, strconv.FormatInt(now.Unix(), 10))
+	req.Header.Set(HeaderSignature, Sign(hook.Secret, now, body))
+
+	resp, err := w.client.Do(req)
+	if err != nil {
+		return 0, err
+	}
+	defer resp.Body.Close()
+	io.Copy(io.Discard, io.LimitReader(resp.Body, 64<<10))
+
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		return resp.StatusCode, fmt.Errorf("unexpected status %s", resp.Status)
+	}
+	return resp.StatusCode, nil
+}
+
+func truncate(s string, n int) string {
+	if len(s) <= n {
+		return s
+	}
+	return s[:n]
+}
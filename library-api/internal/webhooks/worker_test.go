@@ -0,0 +1,109 @@
+package webhooks
+
+import (
+	"context"
+	"library-api/internal/models"
+	"net/http"
+	"net/http/httptest"
+	"path/filepath"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"gorm.io/driver/sqlite"
+	"gorm.io/gorm"
+	"gorm.io/gorm/logger"
+)
+
+func openWebhooks(t *testing.T) *gorm.DB {
+	t.Helper()
+	db, err := gorm.Open(sqlite.Open(filepath.Join(t.TempDir(), "webhooks.db")), &gorm.Config{Logger: logger.Discard})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := db.AutoMigrate(&models.Webhook{}, &models.WebhookDelivery{}); err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() {
+		sqlDB, _ := db.DB()
+		sqlDB.Close()
+	})
+	return db
+}
+
+func TestDeliverPendingClaims(t *testing.T) {
+	db := openWebhooks(t)
+
+	var received atomic.Int32
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		received.Add(1)
+	}))
+	defer srv.Close()
+
+	active := true
+	hook := models.Webhook{URL: srv.URL, Secret: "s", Active: &active}
+	db.Create(&hook)
+	delivery := models.WebhookDelivery{WebhookID: hook.ID, EventID: "e1", Event: "book.created", Payload: "{}",
+		Status: models.DeliveryPending, NextAttemptAt: time.Now()}
+	db.Create(&delivery)
+
+	// A primeira réplica pegou a entrega e ainda está enviando: a segunda
+	// não a envia de novo
+	first := &Worker{db: db, client: srv.Client()}
+	second := &Worker{db: db, client: srv.Client()}
+	if claimed, err := first.claim(delivery.ID); err != nil || !claimed {
+		t.Fatalf("first claim = %v, %v", claimed, err)
+	}
+	second.deliverPending(context.Background())
+	if n := received.Load(); n != 0 {
+		t.Fatalf("received %d requests while the delivery was claimed, want 0", n)
+	}
+
+	// A primeira réplica caiu: quando a posse expira, a segunda envia
+	db.Model(&delivery).Update("locked_until", time.Now().Add(-time.Second))
+	second.deliverPending(context.Background())
+	if n := received.Load(); n != 1 {
+		t.Errorf("received %d requests after the lease expired, want 1", n)
+	}
+
+	var saved models.WebhookDelivery
+	db.First(&saved, delivery.ID)
+	if saved.Status != models.DeliverySucceeded || saved.LockedUntil != nil || saved.Attempts != 1 {
+		t.Errorf("got status %q, locked_until %v, attempts %d", saved.Status, saved.LockedUntil, saved.Attempts)
+	}
+}
+
+func TestDeliverCanceledByShutdown(t *testing.T) {
+	db := openWebhooks(t)
+
+	// O destino não responde enquanto o teste não terminar
+	release := make(chan struct{})
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		<-release
+	}))
+	defer srv.Close()
+	defer close(release)
+
+	active := true
+	hook := models.Webhook{URL: srv.URL, Secret: "s", Active: &active}
+	db.Create(&hook)
+	delivery := models.WebhookDelivery{WebhookID: hook.ID, EventID: "e1", Event: "book.created", Payload: "{}",
+		Status: models.DeliveryPending, NextAttemptAt: time.Now()}
+	db.Create(&delivery)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 100*time.Millisecond)
+	defer cancel()
+	w := &Worker{db: db, client: &http.Client{Timeout: requestTimeout}}
+
+	started := time.Now()
+	w.deliverPending(ctx)
+	if elapsed := time.Since(started); elapsed > requestTimeout/2 {
+		t.Fatalf("delivery took %v after shutdown", elapsed)
+	}
+
+	var saved models.WebhookDelivery
+	db.First(&saved, delivery.ID)
+	if saved.Status != models.DeliveryPending || saved.LockedUntil != nil || saved.Attempts != 0 {
+		t.Errorf("got status %q, locked_until %v, attempts %d", saved.Status, saved.LockedUntil, saved.Attempts)
+	}
+}